@@ -0,0 +1,631 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// ERC20AllowanceSignature is the function signature for the ERC20 allowance function
+const ERC20AllowanceSignature = "allowance(address,address)"
+
+// DefaultAllowanceScanChunk is the number of blocks requested per eth_getLogs call
+const DefaultAllowanceScanChunk = 50000
+
+// unlimitedAllowance is the threshold above which an allowance is shown as unlimited. Wallets
+// approve type(uint256).max, which stays above it even after tokens were spent from it.
+var unlimitedAllowance = new(big.Int).Lsh(big.NewInt(1), 255)
+
+// allowancePair is a token/spender pair the owner approved
+type allowancePair struct {
+	Token     common.Address
+	Spender   common.Address
+	LastBlock uint64 // block of the last Approval event, 0 if the pair was given on the command line
+	Allowance *big.Int
+	Symbol    string
+	Decimals  uint8
+	// ReadErr is set, and Allowance nil, when allowance() reverted or returned no usable value,
+	// as for the spam tokens that emit fake Approval events
+	ReadErr error
+}
+
+// AllowancesCmd creates the command that lists the ERC20 allowances granted by a wallet
+func AllowancesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowances",
+		Short: "List the ERC20 allowances granted by a wallet",
+		Long: `Find the ERC20 allowances granted by a wallet by scanning the Approval events it emitted
+over a block range, and show the current allowance(owner, spender) of each token/spender pair.
+
+The owner is the wallet given by --provider/--name or --file (no password needed), or --owner.
+The scan asks the node for logs in chunks of --chunk-size blocks and halves the chunk when the
+node rejects a range. Pairs whose allowance is now zero are hidden unless --show-zero is set.
+
+Use the revoke subcommand to set allowances back to zero.
+
+Examples:
+  eth-cli allowances -p google -n myWallet
+  eth-cli allowances --owner 0xOwner --from-block 18000000 --token 0xUSDC
+  eth-cli allowances revoke -p google -n myWallet --pair 0xUSDC:0xRouter --pair 0xDAI:0xRouter
+  eth-cli allowances revoke -p google -n myWallet --all --from-block 18000000`,
+		RunE: runAllowances,
+	}
+
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().String("owner", "", "Owner address to inspect instead of a wallet")
+	addAllowanceScanFlags(cmd)
+	cmd.Flags().Bool("show-zero", false, "Also show pairs whose allowance is zero")
+
+	cmd.AddCommand(allowancesRevokeCmd())
+
+	return cmd
+}
+
+// allowancesRevokeCmd creates the subcommand that sets allowances back to zero
+func allowancesRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke ERC20 allowances by approving zero, in one batch",
+		Long: `Revoke ERC20 allowances with approve(spender, 0) transactions, one per token/spender pair,
+signed with sequential nonces after a single confirmation.
+
+Pairs are given as --pair TOKEN:SPENDER, or with --all every pair with a non-zero allowance
+found by scanning the wallet's Approval events (see the allowances command). Pairs whose
+allowance is already zero are skipped.
+
+Examples:
+  eth-cli allowances revoke -p google -n myWallet --pair 0xUSDC:0xRouter --pair 0xDAI:0xRouter
+  eth-cli allowances revoke -f ./wallet.json --all --from-block 18000000 --dry-run`,
+		RunE: runAllowancesRevoke,
+	}
+
+	cmd.Flags().StringArray("pair", nil, "Token/spender pair to revoke as TOKEN:SPENDER (repeatable)")
+	cmd.Flags().Bool("all", false, "Revoke every non-zero allowance found by scanning Approval events")
+	addAllowanceScanFlags(cmd)
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().Bool("dry-run", false, "Only build the unsigned transactions, do not sign or broadcast")
+	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the batch")
+	addFeeFlags(cmd)
+	cmd.Flags().Uint64("gas-limit", 0, "Gas limit for every transaction (default: estimated per transaction)")
+	cmd.Flags().Bool("no-wait", false, "Do not wait for the transactions to be confirmed")
+	cmd.Flags().Duration("wait-timeout", DefaultBatchWaitTimeout, "How long to wait for confirmations before giving up")
+
+	return cmd
+}
+
+// addAllowanceScanFlags adds the flags selecting the blocks and tokens scanned for Approval events
+func addAllowanceScanFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64("from-block", 0, "First block to scan for Approval events")
+	cmd.Flags().String("to-block", "latest", "Last block to scan for Approval events, or latest")
+	cmd.Flags().Uint64("chunk-size", DefaultAllowanceScanChunk, "Blocks per eth_getLogs request")
+	cmd.Flags().StringArray("token", nil, "Only scan this token contract (repeatable)")
+}
+
+func runAllowances(cmd *cobra.Command, args []string) error {
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	owner, _ := cmd.Flags().GetString("owner")
+	showZero, _ := cmd.Flags().GetBool("show-zero")
+
+	if owner != "" && (provider != "" || name != "" || filePath != "") {
+		return fmt.Errorf("--owner cannot be combined with --provider/--name or --file")
+	}
+	if owner == "" {
+		if (provider != "" || name != "") && filePath != "" {
+			return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+		}
+		if provider == "" && filePath == "" {
+			return fmt.Errorf("either --owner, --provider or --file must be specified")
+		}
+
+		selector, err := getAccountSelector(cmd)
+		if err != nil {
+			return err
+		}
+		owner, err = getWalletAddress(filePath, provider, name, selector)
+		if err != nil {
+			return fmt.Errorf("failed to get wallet address: %v", err)
+		}
+	}
+	if !common.IsHexAddress(owner) {
+		return fmt.Errorf("invalid owner address: %s", owner)
+	}
+	ownerAddr := common.HexToAddress(owner)
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+
+	pairs, err := scanAllowancePairs(cmd, client, ownerAddr)
+	if err != nil {
+		return err
+	}
+	if err := fetchAllowances(client, ownerAddr, pairs); err != nil {
+		return err
+	}
+
+	var shown []*allowancePair
+	for _, pair := range pairs {
+		if pair.ReadErr != nil || showZero || pair.Allowance.Sign() > 0 {
+			shown = append(shown, pair)
+		}
+	}
+
+	fmt.Printf("Owner: %s\n", ownerAddr.Hex())
+	if len(shown) == 0 {
+		fmt.Printf("No allowances found (%d token/spender pair(s) approved, all zero now).\n", len(pairs))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tSYMBOL\tSPENDER\tALLOWANCE\tLAST APPROVAL BLOCK")
+	for _, pair := range shown {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", pair.Token.Hex(), pair.Symbol, pair.Spender.Hex(), formatAllowance(pair), pair.LastBlock)
+	}
+	w.Flush()
+	return nil
+}
+
+func runAllowancesRevoke(cmd *cobra.Command, args []string) error {
+	pairSpecs, _ := cmd.Flags().GetStringArray("pair")
+	all, _ := cmd.Flags().GetBool("all")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	if all == (len(pairSpecs) > 0) {
+		return fmt.Errorf("exactly one of --pair and --all must be specified")
+	}
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	// Determine which account to derive
+	selector, err := getAccountSelector(cmd)
+	if err != nil {
+		return err
+	}
+
+	pairs, err := parseAllowancePairs(pairSpecs)
+	if err != nil {
+		return err
+	}
+
+	// Get RPC URL from config, the current allowances are needed even in dry-run mode
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	// Unlock the wallet once
+	var privateKey string
+	var fromAddress string
+	if dryRun {
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+	fromAddr := common.HexToAddress(fromAddress)
+
+	if all {
+		if pairs, err = scanAllowancePairs(cmd, client, fromAddr); err != nil {
+			return err
+		}
+	}
+	if err := fetchAllowances(client, fromAddr, pairs); err != nil {
+		return err
+	}
+
+	var pending []*allowancePair
+	for _, pair := range pairs {
+		if pair.ReadErr != nil {
+			fmt.Printf("Skipping %s %s: %v\n", pair.Token.Hex(), pair.Spender.Hex(), pair.ReadErr)
+			continue
+		}
+		if pair.Allowance.Sign() == 0 {
+			if !all {
+				fmt.Printf("Skipping %s %s: the allowance is already zero\n", pair.Token.Hex(), pair.Spender.Hex())
+			}
+			continue
+		}
+		pending = append(pending, pair)
+	}
+	if len(pending) == 0 {
+		fmt.Println("Nothing to revoke.")
+		return nil
+	}
+
+	// Get chain ID and the first nonce
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	nonce, err := util.GetNonce(client, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	// Get transaction type and fees, shared by all transactions
+	txParams, err := resolveTxParams(cmd, client, false)
+	if err != nil {
+		return err
+	}
+
+	gasLimits := make([]uint64, len(pending))
+	for i, pair := range pending {
+		gasLimits[i] = gasLimit
+		if gasLimit == 0 {
+			estimated, err := util.EstimateGas(client, fromAddr, &pair.Token, big.NewInt(0), util.EncodeERC20Approve(pair.Spender.Hex(), big.NewInt(0)))
+			if err != nil {
+				return fmt.Errorf("failed to estimate gas of revoking %s for %s: %v", pair.Symbol, pair.Spender.Hex(), err)
+			}
+			gasLimits[i] = uint64(float64(estimated) * GasEstimationBuffer)
+		}
+	}
+
+	// Build every transaction before asking for confirmation
+	rawTxs := make([]string, len(pending))
+	for i, pair := range pending {
+		rawTxs[i], err = util.CreateERC20ApproveTx(fromAddress, pair.Token.Hex(), pair.Spender.Hex(), big.NewInt(0), nonce+uint64(i), txParams, gasLimits[i], chainID)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %v", err)
+		}
+	}
+
+	if dryRun {
+		printRevokeSummary(fromAddress, pending, gasLimits, txParams, nonce)
+		for i, rawTx := range rawTxs {
+			fmt.Printf("\n\033[1;36mRaw Transaction (nonce %d):\033[0m %s\n", nonce+uint64(i), rawTx)
+		}
+		return nil
+	}
+
+	// Simulate every revocation before signing
+	sims := make([]*txSimulation, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		if sims[i], err = simulateRawTx(client, fromAddress, rawTx); err != nil {
+			return fmt.Errorf("revoking %s for %s: %v", pending[i].Symbol, pending[i].Spender.Hex(), err)
+		}
+	}
+
+	printRevokeSummary(fromAddress, pending, gasLimits, txParams, nonce)
+	for i, sim := range sims {
+		fmt.Printf("Nonce %d: ", nonce+uint64(i))
+		printSimulation(sim)
+	}
+
+	// Ask for confirmation
+	if !autoConfirm {
+		fmt.Printf("Send %d revocation(s)? (y/N): ", len(pending))
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Revocation cancelled.")
+			return nil
+		}
+	}
+
+	// Sign every transaction before broadcasting, so a signing error sends nothing
+	signedTxs := make([]string, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		if err := checkRawTxNetwork(rawTx); err != nil {
+			return err
+		}
+		signedTxs[i], err = util.SignTransactionWithChainID(rawTx, privateKey, chainID)
+		if err != nil {
+			return fmt.Errorf("failed to sign transaction: %v", err)
+		}
+	}
+
+	var txHashes []common.Hash
+	for i, signedTx := range signedTxs {
+		txHash, err := util.BroadcastTransaction(signedTx, rpcURL)
+		if err != nil {
+			return fmt.Errorf("failed to broadcast the revocation of %s for %s: %v", pending[i].Symbol, pending[i].Spender.Hex(), err)
+		}
+		fmt.Printf("Revoking %s for %s: %s\n", pending[i].Symbol, pending[i].Spender.Hex(), txHash)
+		txHashes = append(txHashes, common.HexToHash(txHash))
+	}
+
+	if noWait {
+		return nil
+	}
+	return waitForRevokes(client, pending, txHashes, waitTimeout)
+}
+
+// parseAllowancePairs parses --pair TOKEN:SPENDER values, dropping duplicates
+func parseAllowancePairs(specs []string) ([]*allowancePair, error) {
+	var pairs []*allowancePair
+	seen := make(map[[2]common.Address]bool)
+	for _, spec := range specs {
+		token, spender, ok := strings.Cut(spec, ":")
+		if !ok || !common.IsHexAddress(strings.TrimSpace(token)) || !common.IsHexAddress(strings.TrimSpace(spender)) {
+			return nil, fmt.Errorf("invalid --pair %q, expected TOKEN:SPENDER addresses", spec)
+		}
+
+		pair := &allowancePair{Token: common.HexToAddress(strings.TrimSpace(token)), Spender: common.HexToAddress(strings.TrimSpace(spender))}
+		key := [2]common.Address{pair.Token, pair.Spender}
+		if !seen[key] {
+			seen[key] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// scanAllowancePairs finds the token/spender pairs approved by owner in the block range of the scan flags
+func scanAllowancePairs(cmd *cobra.Command, client *ethclient.Client, owner common.Address) ([]*allowancePair, error) {
+	fromBlock, _ := cmd.Flags().GetUint64("from-block")
+	toBlockStr, _ := cmd.Flags().GetString("to-block")
+	chunkSize, _ := cmd.Flags().GetUint64("chunk-size")
+	tokenSpecs, _ := cmd.Flags().GetStringArray("token")
+
+	var tokens []common.Address
+	for _, token := range tokenSpecs {
+		if !common.IsHexAddress(token) {
+			return nil, fmt.Errorf("invalid token address: %s", token)
+		}
+		tokens = append(tokens, common.HexToAddress(token))
+	}
+	if chunkSize == 0 {
+		return nil, fmt.Errorf("--chunk-size must be greater than zero")
+	}
+
+	var toBlock uint64
+	if strings.EqualFold(strings.TrimSpace(toBlockStr), "latest") {
+		latest, err := client.BlockNumber(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %v", err)
+		}
+		toBlock = latest
+	} else {
+		parsed, ok := new(big.Int).SetString(strings.TrimSpace(toBlockStr), 0)
+		if !ok || !parsed.IsUint64() {
+			return nil, fmt.Errorf("invalid --to-block: %s", toBlockStr)
+		}
+		toBlock = parsed.Uint64()
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("--from-block %d is after --to-block %d", fromBlock, toBlock)
+	}
+
+	fmt.Fprintf(os.Stderr, "Scanning Approval events of %s in blocks %d-%d...\n", owner.Hex(), fromBlock, toBlock)
+	logs, err := filterApprovalLogs(client, owner, tokens, fromBlock, toBlock, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	return approvalPairsFromLogs(logs), nil
+}
+
+// filterApprovalLogs fetches the ERC20 Approval logs of owner in chunks, halving the chunk
+// when the node rejects a range (too many results or a block range limit)
+func filterApprovalLogs(client *ethclient.Client, owner common.Address, tokens []common.Address, fromBlock, toBlock, chunkSize uint64) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		Addresses: tokens,
+		Topics:    [][]common.Hash{{util.ApprovalEventTopic}, {common.BytesToHash(owner.Bytes())}},
+	}
+
+	var logs []types.Log
+	for start := fromBlock; start <= toBlock; {
+		end := toBlock
+		if end-start >= chunkSize {
+			end = start + chunkSize - 1
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		chunkLogs, err := client.FilterLogs(context.Background(), query)
+		if err != nil {
+			if chunkSize == 1 {
+				return nil, fmt.Errorf("failed to get logs of block %d: %v", start, err)
+			}
+			chunkSize /= 2
+			continue
+		}
+
+		logs = append(logs, chunkLogs...)
+		if end == toBlock {
+			break
+		}
+		start = end + 1
+	}
+	return logs, nil
+}
+
+// approvalPairsFromLogs returns the token/spender pairs of ERC20 Approval logs in order of first
+// approval, with the block of the last one. ERC721 Approval logs (four topics) are skipped.
+func approvalPairsFromLogs(logs []types.Log) []*allowancePair {
+	var pairs []*allowancePair
+	index := make(map[[2]common.Address]*allowancePair)
+	for _, log := range logs {
+		if len(log.Topics) != 3 || log.Topics[0] != util.ApprovalEventTopic || log.Removed {
+			continue
+		}
+
+		spender := common.BytesToAddress(log.Topics[2].Bytes())
+		key := [2]common.Address{log.Address, spender}
+		pair, ok := index[key]
+		if !ok {
+			pair = &allowancePair{Token: log.Address, Spender: spender}
+			index[key] = pair
+			pairs = append(pairs, pair)
+		}
+		if log.BlockNumber > pair.LastBlock {
+			pair.LastBlock = log.BlockNumber
+		}
+	}
+	return pairs
+}
+
+// fetchAllowances sets the current allowance, symbol and decimals of each pair. The allowances go
+// out as one Multicall3 eth_call, falling back to one call per pair without Multicall3. A pair whose
+// allowance cannot be read gets a ReadErr instead of failing the others.
+func fetchAllowances(client *ethclient.Client, owner common.Address, pairs []*allowancePair) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	calls := make([]util.Call3, len(pairs))
+	for i, pair := range pairs {
+		data, err := util.EncodeArgumentValues(ERC20AllowanceSignature, owner, pair.Spender)
+		if err != nil {
+			return err
+		}
+		calls[i] = util.Call3{Target: pair.Token, AllowFailure: true, CallData: data}
+	}
+
+	results, err := util.Aggregate3(client, calls, nil)
+	if err != nil {
+		results = make([]util.MulticallResult, len(calls))
+		for i, call := range calls {
+			output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &call.Target, Data: call.CallData}, nil)
+			results[i] = util.MulticallResult{Success: err == nil, ReturnData: output}
+		}
+	}
+
+	tokens, tokenIndex := setAllowances(pairs, results)
+	infos, err := fetchTokenInfo(client, tokens, nil, nil)
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if pair.ReadErr != nil {
+			continue
+		}
+		info := infos[tokenIndex[pair.Token]]
+		pair.Symbol = info.Symbol
+		pair.Decimals = info.Decimals
+	}
+	return nil
+}
+
+// setAllowances decodes the allowance() results into the pairs and returns the tokens of the
+// readable pairs, with the index of each token
+func setAllowances(pairs []*allowancePair, results []util.MulticallResult) ([]common.Address, map[common.Address]int) {
+	var tokens []common.Address
+	tokenIndex := make(map[common.Address]int)
+	for i, pair := range pairs {
+		if !results[i].Success {
+			pair.ReadErr = fmt.Errorf("allowance call reverted")
+			continue
+		}
+		allowance, err := decodeERC20Balance(results[i].ReturnData)
+		if err != nil {
+			pair.ReadErr = fmt.Errorf("invalid allowance: %v", err)
+			continue
+		}
+		pair.Allowance = allowance
+
+		if _, ok := tokenIndex[pair.Token]; !ok {
+			tokenIndex[pair.Token] = len(tokens)
+			tokens = append(tokens, pair.Token)
+		}
+	}
+	return tokens, tokenIndex
+}
+
+// formatAllowance formats an allowance in token units, or as unlimited. An allowance that cannot
+// be read is shown as unreadable.
+func formatAllowance(pair *allowancePair) string {
+	if pair.ReadErr != nil {
+		return "unreadable"
+	}
+	if pair.Allowance.Sign() == 0 {
+		return "0"
+	}
+	if pair.Allowance.Cmp(unlimitedAllowance) >= 0 {
+		return "unlimited"
+	}
+	return util.FormatTokenAmount(pair.Allowance, pair.Decimals)
+}
+
+// printRevokeSummary prints the revocations to be sent with their nonces and the maximum fee
+func printRevokeSummary(from string, pairs []*allowancePair, gasLimits []uint64, txParams util.TxParams, nonce uint64) {
+	fmt.Println("Revocation Details:")
+	fmt.Printf("From: %s\n", from)
+	fmt.Printf("Transaction Type: %s\n", util.TxTypeName(txParams.Type))
+
+	var totalGas uint64
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NONCE\tTOKEN\tSYMBOL\tSPENDER\tCURRENT ALLOWANCE\tGAS LIMIT")
+	for i, pair := range pairs {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\n", nonce+uint64(i), pair.Token.Hex(), pair.Symbol, pair.Spender.Hex(), formatAllowance(pair), gasLimits[i])
+		totalGas += gasLimits[i]
+	}
+	w.Flush()
+
+	fmt.Printf("Max Gas Fee: %s %s\n", formatEther(new(big.Int).Mul(txParams.Fees.MaxFeePerGas, new(big.Int).SetUint64(totalGas))), nativeSymbol())
+}
+
+// waitForRevokes waits for the receipts of the revocations until the timeout
+func waitForRevokes(client *ethclient.Client, pairs []*allowancePair, txHashes []common.Hash, timeout time.Duration) error {
+	fmt.Println("Waiting for transaction confirmations...")
+	deadline := time.Now().Add(timeout)
+	failed := 0
+	for i, txHash := range txHashes {
+		receipt, err := waitForReceipt(client, txHash.Hex(), time.Until(deadline))
+		if err != nil {
+			fmt.Printf("\033[1;31mRevocation of %s for %s not confirmed:\033[0m %v\n", pairs[i].Symbol, pairs[i].Spender.Hex(), err)
+			failed++
+			continue
+		}
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			fmt.Printf("Revoked %s for %s in block %d\n", pairs[i].Symbol, pairs[i].Spender.Hex(), receipt.BlockNumber)
+		} else {
+			fmt.Printf("\033[1;31mRevocation of %s for %s failed\033[0m in block %d (%s)\n", pairs[i].Symbol, pairs[i].Spender.Hex(), receipt.BlockNumber, txHash.Hex())
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d revocation(s) failed or were not confirmed, check them with the allowances command", failed)
+	}
+	fmt.Println("All revocations confirmed successfully!")
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestApprovalPairsFromLogs(t *testing.T) {
+	owner := common.BytesToHash(common.HexToAddress("0x00000000000000000000000000000000000000aa").Bytes())
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	nft := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	router := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	spender := func(address common.Address) common.Hash { return common.BytesToHash(address.Bytes()) }
+
+	logs := []types.Log{
+		{Address: usdc, BlockNumber: 10, Topics: []common.Hash{util.ApprovalEventTopic, owner, spender(router)}},
+		// ERC721 Approval has the token ID as a fourth topic
+		{Address: nft, BlockNumber: 11, Topics: []common.Hash{util.ApprovalEventTopic, owner, spender(router), common.BigToHash(big.NewInt(1))}},
+		{Address: usdc, BlockNumber: 12, Topics: []common.Hash{util.ApprovalEventTopic, owner, spender(usdc)}},
+		{Address: usdc, BlockNumber: 15, Topics: []common.Hash{util.ApprovalEventTopic, owner, spender(router)}},
+		{Address: usdc, BlockNumber: 16, Topics: []common.Hash{util.ApprovalEventTopic, owner, spender(nft)}, Removed: true},
+	}
+
+	pairs := approvalPairsFromLogs(logs)
+	if len(pairs) != 2 {
+		t.Fatalf("Expected 2 pairs, got %d", len(pairs))
+	}
+	if pairs[0].Token != usdc || pairs[0].Spender != router || pairs[0].LastBlock != 15 {
+		t.Errorf("Unexpected first pair: %+v", pairs[0])
+	}
+	if pairs[1].Token != usdc || pairs[1].Spender != usdc || pairs[1].LastBlock != 12 {
+		t.Errorf("Unexpected second pair: %+v", pairs[1])
+	}
+}
+
+func TestParseAllowancePairs(t *testing.T) {
+	pairs, err := parseAllowancePairs([]string{
+		"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48:0x00000000000000000000000000000000000000bb",
+		"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48 : 0x00000000000000000000000000000000000000BB",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].Spender != common.HexToAddress("0xbb") {
+		t.Errorf("Expected one deduplicated pair, got %+v", pairs)
+	}
+
+	for _, invalid := range []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "0x1234:0x00000000000000000000000000000000000000bb", ""} {
+		if _, err := parseAllowancePairs([]string{invalid}); err == nil {
+			t.Errorf("Expected error for %q, but got none", invalid)
+		}
+	}
+}
+
+func TestFormatAllowance(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	spent := new(big.Int).Sub(maxUint256, big.NewInt(1000000))
+
+	tests := []struct {
+		allowance *big.Int
+		decimals  uint8
+		expected  string
+	}{
+		{big.NewInt(1500000), 6, "1.500000"},
+		{big.NewInt(0), 18, "0"},
+		{maxUint256, 18, "unlimited"},
+		{spent, 6, "unlimited"},
+	}
+	for _, test := range tests {
+		if got := formatAllowance(&allowancePair{Allowance: test.allowance, Decimals: test.decimals}); got != test.expected {
+			t.Errorf("Unexpected allowance for %s: got %s, expected %s", test.allowance, got, test.expected)
+		}
+	}
+}
+
+func TestSetAllowances(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	spam := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	router := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	pairs := []*allowancePair{
+		{Token: spam, Spender: router},
+		{Token: usdc, Spender: router},
+		{Token: spam, Spender: usdc},
+		{Token: usdc, Spender: usdc},
+	}
+	results := []util.MulticallResult{
+		{Success: false},
+		{Success: true, ReturnData: common.BigToHash(big.NewInt(5)).Bytes()},
+		// A spam token answering with garbage does not stop the other pairs
+		{Success: true, ReturnData: []byte{0x01}},
+		{Success: true, ReturnData: common.BigToHash(big.NewInt(0)).Bytes()},
+	}
+
+	tokens, tokenIndex := setAllowances(pairs, results)
+	if len(tokens) != 1 || tokens[0] != usdc || tokenIndex[usdc] != 0 {
+		t.Errorf("Unexpected tokens: %v", tokens)
+	}
+	for i, readable := range []bool{false, true, false, true} {
+		if (pairs[i].ReadErr == nil) != readable || (pairs[i].Allowance != nil) != readable {
+			t.Errorf("Unexpected pair %d: %+v", i, pairs[i])
+		}
+	}
+	if pairs[1].Allowance.Int64() != 5 {
+		t.Errorf("Unexpected allowance: %s", pairs[1].Allowance)
+	}
+	if got := formatAllowance(pairs[0]); got != "unreadable" {
+		t.Errorf("Unexpected allowance: got %s, expected unreadable", got)
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// IsApprovedForAllSignature is the function signature for the ERC721/ERC1155 isApprovedForAll function
+const IsApprovedForAllSignature = "isApprovedForAll(address,address)"
+
+// ApproveERC1155Cmd creates the ERC1155 setApprovalForAll command
+func ApproveERC1155Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approveERC1155",
+		Short: "Approve or revoke an operator for all ERC1155 tokens of a contract",
+		Long: `Approve (or with --revoke, revoke) an operator for all your tokens of an ERC1155
+contract with setApprovalForAll. ERC1155 has no per-token approvals.
+
+The current approval is read with isApprovedForAll before signing; nothing is sent if it
+already has the requested state.
+
+Examples:
+  eth-cli approveERC1155 -p google -n myWallet --token 0xGame --operator 0xMarketplace
+  eth-cli approveERC1155 -f ./wallet.json --token 0xGame --operator 0xMarketplace --revoke`,
+		RunE: runApproveERC1155,
+	}
+
+	cmd.Flags().String("token", "", "ERC1155 token contract address")
+	cmd.Flags().String("operator", "", "Operator address to approve or revoke")
+	cmd.Flags().Bool("revoke", false, "Revoke the operator's approval")
+	addContractTxFlags(cmd)
+
+	cmd.MarkFlagRequired("token")
+	cmd.MarkFlagRequired("operator")
+
+	return cmd
+}
+
+func runApproveERC1155(cmd *cobra.Command, args []string) error {
+	tokenAddress, _ := cmd.Flags().GetString("token")
+	operator, _ := cmd.Flags().GetString("operator")
+	revoke, _ := cmd.Flags().GetBool("revoke")
+
+	if !common.IsHexAddress(tokenAddress) {
+		return fmt.Errorf("invalid token address format: %s", tokenAddress)
+	}
+	if !common.IsHexAddress(operator) {
+		return fmt.Errorf("invalid operator address format: %s", operator)
+	}
+	tokenAddr := common.HexToAddress(tokenAddress)
+	operatorAddr := common.HexToAddress(operator)
+
+	_, err := runContractTx(cmd, func(txCtx contractTxContext) (contractTxRequest, error) {
+		req := contractTxRequest{
+			To:              &tokenAddr,
+			Data:            util.EncodeSetApprovalForAll(operatorAddr.Hex(), !revoke),
+			DefaultGasLimit: 60000,
+		}
+		req.Details = append(req.Details, [2]string{"Type", setApprovalForAllType(revoke)})
+		req.Details = append(req.Details, [2]string{"Token Contract", tokenAddr.Hex() + " (ERC1155)"})
+		req.Details = append(req.Details, [2]string{"Operator", operatorAddr.Hex()})
+
+		if txCtx.Client != nil {
+			approved, err := queryIsApprovedForAll(txCtx.Client, tokenAddr, txCtx.From, operatorAddr)
+			if err != nil {
+				return req, fmt.Errorf("failed to query isApprovedForAll: %v", err)
+			}
+			fmt.Printf("Current approval of %s: %s\n", operatorAddr.Hex(), approvalState(approved))
+			if approved != revoke {
+				return req, errApprovalUnchanged
+			}
+		}
+		if !revoke {
+			fmt.Printf("\033[33mWARNING: %s will be able to transfer ALL your tokens of %s.\033[0m\n", operatorAddr.Hex(), tokenAddr.Hex())
+		}
+		return req, nil
+	})
+	if errors.Is(err, errApprovalUnchanged) {
+		fmt.Println("Nothing to do, the approval already has the requested state.")
+		return nil
+	}
+	return err
+}
+
+// errApprovalUnchanged stops runContractTx when an approval already has the requested state
+var errApprovalUnchanged = errors.New("approval unchanged")
+
+// setApprovalForAllType describes a setApprovalForAll transaction
+func setApprovalForAllType(revoke bool) string {
+	if revoke {
+		return "Revocation of operator approval (setApprovalForAll false)"
+	}
+	return "Operator approval for all tokens (setApprovalForAll true)"
+}
+
+// approvalState formats an isApprovedForAll result
+func approvalState(approved bool) string {
+	if approved {
+		return "approved for all"
+	}
+	return "not approved"
+}
+
+// queryIsApprovedForAll calls isApprovedForAll(owner, operator) on an ERC721/ERC1155 contract
+func queryIsApprovedForAll(client *ethclient.Client, token, owner, operator common.Address) (bool, error) {
+	data, err := util.EncodeArgumentValues(IsApprovedForAllSignature, owner, operator)
+	if err != nil {
+		return false, err
+	}
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return false, err
+	}
+	return decodeABIBool(result)
+}
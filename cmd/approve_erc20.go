@@ -30,10 +30,11 @@ func ApproveERC20Cmd() *cobra.Command {
 	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., googledrive)")
 	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
 	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
 	cmd.Flags().Bool("dry-run", false, "Only encode the transaction, do not broadcast")
 	cmd.Flags().Bool("estimate-only", false, "Only display gas estimation")
 	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the transaction")
-	cmd.Flags().String("gas-price", "", "Gas price (e.g., 3gwei)")
+	addFeeFlags(cmd)
 	cmd.Flags().Uint64("gas-limit", 0, "Gas limit")
 	cmd.Flags().Uint64("chain-id", 1, "Chain ID to use in dry-run mode (default: 1)")
 	cmd.Flags().Uint64("nonce", 0, "Nonce to use in dry-run mode (required when chain-id is specified)")
@@ -57,7 +58,6 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	estimateOnly, _ := cmd.Flags().GetBool("estimate-only")
 	autoConfirm, _ := cmd.Flags().GetBool("yes")
-	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
 	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
 	sync, _ := cmd.Flags().GetBool("sync")
 
@@ -71,9 +71,21 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --provider or --file must be specified")
 	}
 
-	// Get RPC URL from config
-	rpcURL, err := initTxConfig()
-	if err != nil && !dryRun {
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Get RPC URL from config; dry runs do not need a reachable RPC
+	var rpcURL string
+	var err error
+	if dryRun {
+		rpcURL, err = initDryRunTxConfig()
+	} else {
+		rpcURL, err = initTxConfig()
+	}
+	if err != nil {
 		return err
 	}
 
@@ -142,12 +154,15 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 	// Get private key from provider or file
 	var privateKey string
 	var fromAddress string
-	if filePath != "" {
+	if dryRun {
+		// Dry runs only need the address, so watch-only wallets work without a password
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
 		// Use local file
-		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath)
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
 	} else {
 		// Use provider
-		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name)
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get private key: %v", err)
@@ -158,7 +173,7 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 	var nonce uint64
 	if !dryRun {
 		var chainErr error
-		chainID, chainErr = client.NetworkID(context.Background())
+		chainID, chainErr = client.ChainID(context.Background())
 		if chainErr != nil {
 			return fmt.Errorf("failed to get chain ID: %v", chainErr)
 		}
@@ -168,7 +183,10 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get nonce: %v", err)
 		}
 	} else {
-		chainIDValue, _ := cmd.Flags().GetUint64("chain-id")
+		chainIDValue, err := dryRunChainID(cmd)
+		if err != nil {
+			return err
+		}
 		chainID = big.NewInt(int64(chainIDValue))
 		nonceValue, _ := cmd.Flags().GetUint64("nonce")
 
@@ -180,22 +198,10 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\033[33mWARNING: Using chain ID %d and nonce %d for dry run.\033[0m\n", chainIDValue, nonce)
 	}
 
-	// Get gas price
-	var gasPrice *big.Int
-	if gasPriceStr != "" {
-		var gasPriceErr error
-		gasPrice, gasPriceErr = parseEthAmount(gasPriceStr)
-		if gasPriceErr != nil {
-			return gasPriceErr
-		}
-	} else if !dryRun {
-		var suggestErr error
-		gasPrice, suggestErr = client.SuggestGasPrice(context.Background())
-		if suggestErr != nil {
-			return fmt.Errorf("failed to get suggested gas price: %v", suggestErr)
-		}
-	} else {
-		gasPrice = big.NewInt(1000000000) // Default 1 Gwei if dry run
+	// Get transaction type and fees
+	txParams, err := resolveTxParams(cmd, client, dryRun)
+	if err != nil {
+		return err
 	}
 
 	// Get gas limit
@@ -236,7 +242,7 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		to,
 		amount,
 		nonce,
-		gasPrice,
+		txParams,
 		gasLimit,
 		chainID,
 	)
@@ -247,14 +253,7 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 	// If gas only, just display and exit
 	if estimateOnly {
 		fmt.Printf("Estimated Gas Limit: %d\n", gasLimit)
-		fmt.Printf("Suggested Gas Price: %s Gwei\n", new(big.Float).Quo(
-			new(big.Float).SetInt(gasPrice),
-			new(big.Float).SetInt(big.NewInt(1000000000)),
-		).Text('f', 9))
-		fmt.Printf("Estimated Gas Fee: %s ETH\n", new(big.Float).Quo(
-			new(big.Float).SetInt(new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))),
-			new(big.Float).SetInt(big.NewInt(1000000000000000000)),
-		).Text('f', 18))
+		printFeeDetails(txParams, gasLimit)
 		return nil
 	}
 
@@ -264,6 +263,17 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Simulate the transaction before signing it
+	sim, err := simulateRawTx(client, fromAddress, rawTx)
+	if err != nil {
+		return err
+	}
+
+	// Check the chain ID it is signed for against the selected network
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		return err
+	}
+
 	// Sign the transaction
 	var signErr error
 	signedTx, signErr := util.SignTransaction(rawTx, privateKey)
@@ -279,17 +289,6 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		amountRemainder := new(big.Int).Mod(amount, decimalDivisor)
 		displayAmount := fmt.Sprintf("%d.%0*d", amountInt, tokenDecimals, amountRemainder)
 
-		// Convert gas price to Gwei
-		gasPriceGwei := new(big.Int).Div(gasPrice, big.NewInt(1e9))
-		gasPriceRemainder := new(big.Int).Mod(gasPrice, big.NewInt(1e9))
-		displayGasPrice := fmt.Sprintf("%d.%09d", gasPriceGwei, gasPriceRemainder)
-
-		// Calculate gas fee in Wei
-		gasFee := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
-		gasFeeEth := new(big.Int).Div(gasFee, big.NewInt(1e18))
-		gasFeeRemainder := new(big.Int).Mod(gasFee, big.NewInt(1e18))
-		displayGasFee := fmt.Sprintf("%d.%018d", gasFeeEth, gasFeeRemainder)
-
 		approveType := "Approval"
 		if amount.Cmp(big.NewInt(0)) == 0 {
 			approveType = "Revocation of approval"
@@ -302,9 +301,9 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Token: %s (%s)\n", tokenAddress, tokenSymbol)
 		fmt.Printf("Amount: %s %s\n", displayAmount, tokenSymbol)
 		fmt.Printf("Gas Limit: %d\n", gasLimit)
-		fmt.Printf("Gas Price: %s Gwei\n", displayGasPrice)
-		fmt.Printf("Gas Fee: %s ETH\n", displayGasFee)
+		printFeeDetails(txParams, gasLimit)
 		fmt.Printf("Nonce: %d\n", nonce)
+		printSimulation(sim)
 
 		// Ask for confirmation
 		fmt.Print("Confirm transaction? (y/N): ")
@@ -324,6 +323,7 @@ func runApproveERC20(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Transaction submitted: %s\n", txHash)
+	printExplorerLink(txHash)
 
 	// Wait for confirmation if requested
 	if sync {
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDecodeABIAddress(t *testing.T) {
+	address := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	got, err := decodeABIAddress(common.LeftPadBytes(address.Bytes(), 32))
+	if err != nil || got != address {
+		t.Errorf("Unexpected address: %s, %v", got.Hex(), err)
+	}
+
+	for _, invalid := range [][]byte{nil, address.Bytes(), bytes.Repeat([]byte{0xff}, 32)} {
+		if _, err := decodeABIAddress(invalid); err == nil {
+			t.Errorf("Expected error for %x, but got none", invalid)
+		}
+	}
+}
+
+func TestERC721ApprovalRequests(t *testing.T) {
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	operator := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	tokenID := big.NewInt(42)
+
+	// Offline builds skip the pre-flight queries
+	req, err := erc721TokenApproval(token, tokenID, common.Address{})(contractTxContext{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(req.Data, util.EncodeERC721Approve(common.Address{}.Hex(), tokenID)) || req.Details[0][1] != "Revocation of approval" {
+		t.Errorf("Unexpected revocation request: %x %v", req.Data, req.Details)
+	}
+
+	req, err = erc721OperatorApproval(token, operator, false)(contractTxContext{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(req.Data, util.EncodeSetApprovalForAll(operator.Hex(), true)) || *req.To != token {
+		t.Errorf("Unexpected operator request: %x", req.Data)
+	}
+
+	req, err = erc721OperatorApproval(token, operator, true)(contractTxContext{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(req.Data, util.EncodeSetApprovalForAll(operator.Hex(), false)) {
+		t.Errorf("Unexpected operator revocation request: %x", req.Data)
+	}
+}
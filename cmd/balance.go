@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// TokensConfigKey is the config key holding the default comma-separated list of ERC20 contracts
+const TokensConfigKey = "tokens"
+
+// TokenBalance is the balance of one asset held by an address
+type TokenBalance struct {
+	Symbol   string `json:"symbol"`
+	Contract string `json:"contract,omitempty"`
+	Decimals uint8  `json:"decimals"`
+	Balance  string `json:"balance"`
+	Raw      string `json:"raw"`
+}
+
+// BalanceReport is the ETH and token balances of an address at a block
+type BalanceReport struct {
+	Address string         `json:"address"`
+	Block   uint64         `json:"block"`
+	Assets  []TokenBalance `json:"assets"`
+}
+
+// BalanceCmd creates the balance command
+func BalanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balance",
+		Short: "Show the ETH and ERC20 token balances of a wallet",
+		Long: `Show the ETH balance of a wallet plus the balances of a list of ERC20 tokens.
+
+The wallet is loaded with --provider/--name or --file, or given as a bare --address.
+Watch-only wallets and bare addresses do not need a password. Tokens are taken from --tokens,
+or from the "tokens" config value (a comma-separated list of contract addresses).
+
+Examples:
+  eth-cli balance --address 0xYourAddress --tokens 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48
+  eth-cli balance -p google -n myWallet --account treasury --json
+  eth-cli balance --address 0xYourAddress --block 17000000`,
+		RunE: runBalance,
+	}
+
+	cmd.Flags().StringP("address", "a", "", "Address to query (watch-only, no wallet needed)")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().String("tokens", "", "Comma-separated ERC20 contract addresses (default: the \"tokens\" config value)")
+	cmd.Flags().String("block", "latest", "Block number to query (decimal or 0x-prefixed hex, or 'latest')")
+	cmd.Flags().Bool("json", false, "Output the balances as JSON")
+
+	return cmd
+}
+
+func runBalance(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	addressStr, _ := cmd.Flags().GetString("address")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	tokensStr, _ := cmd.Flags().GetString("tokens")
+	blockStr, _ := cmd.Flags().GetString("block")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	// Exactly one source for the address
+	sources := 0
+	if addressStr != "" {
+		sources++
+	}
+	if provider != "" || name != "" {
+		sources++
+	}
+	if filePath != "" {
+		sources++
+	}
+	if sources != 1 || (name != "" && provider == "") {
+		return fmt.Errorf("specify exactly one of --address, --provider/--name or --file")
+	}
+
+	blockNumber, err := parseBlockNumber(blockStr)
+	if err != nil {
+		return err
+	}
+
+	// Get RPC URL from config (this also loads the token list config)
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+
+	if tokensStr == "" {
+		tokensStr = viper.GetString(TokensConfigKey)
+	}
+	tokens, err := parseTokenList(tokensStr)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the address to query
+	var address common.Address
+	if addressStr != "" {
+		if !common.IsHexAddress(addressStr) {
+			return fmt.Errorf("invalid address: %s", addressStr)
+		}
+		address = common.HexToAddress(addressStr)
+	} else {
+		selector, err := getAccountSelector(cmd)
+		if err != nil {
+			return err
+		}
+
+		fromAddress, err := getWalletAddress(filePath, provider, name, selector)
+		if err != nil {
+			return fmt.Errorf("failed to load wallet: %v", err)
+		}
+		address = common.HexToAddress(fromAddress)
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	if !jsonOutput {
+		fmt.Printf("Using RPC: %s\n", rpcURL)
+	}
+
+	report, err := queryBalances(client, address, tokens, blockNumber)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode balances: %v", err)
+		}
+		fmt.Println(string(reportJSON))
+		return nil
+	}
+
+	fmt.Printf("Address: %s\n", report.Address)
+	fmt.Printf("Block: %d\n", report.Block)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSET\tBALANCE\tCONTRACT")
+	for _, asset := range report.Assets {
+		contract := asset.Contract
+		if contract == "" {
+			contract = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", asset.Symbol, asset.Balance, contract)
+	}
+	return w.Flush()
+}
+
+// queryBalances gets the ETH and token balances of an address. All queries are pinned to
+// the same block so the report is consistent; nil means the current head.
+func queryBalances(client *ethclient.Client, address common.Address, tokens []common.Address, blockNumber *big.Int) (BalanceReport, error) {
+	ctx := context.Background()
+	report := BalanceReport{Address: address.Hex()}
+
+	if blockNumber == nil {
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to get block number: %v", err)
+		}
+		blockNumber = new(big.Int).SetUint64(head)
+	}
+	report.Block = blockNumber.Uint64()
+
+	ethBalance, infos, err := fetchBalances(client, address, tokens, blockNumber)
+	if err != nil {
+		return report, err
+	}
+
+	report.Assets = append(report.Assets, TokenBalance{
+		Symbol:   nativeSymbol(),
+		Decimals: 18,
+		Balance:  formatEther(ethBalance),
+		Raw:      ethBalance.String(),
+	})
+	for i, token := range tokens {
+		report.Assets = append(report.Assets, TokenBalance{
+			Symbol:   infos[i].Symbol,
+			Contract: token.Hex(),
+			Decimals: infos[i].Decimals,
+			Balance:  util.FormatTokenAmount(infos[i].Balance, infos[i].Decimals),
+			Raw:      infos[i].Balance.String(),
+		})
+	}
+
+	return report, nil
+}
+
+// fetchBalances gets the ETH balance and token info of an address in one Multicall3 eth_call,
+// falling back to separate calls when Multicall3 is unavailable (e.g. at blocks before its deployment)
+func fetchBalances(client *ethclient.Client, address common.Address, tokens []common.Address, blockNumber *big.Int) (*big.Int, []tokenInfo, error) {
+	calls := append([]util.Call3{{Target: util.Multicall3Address, CallData: util.EncodeGetEthBalance(address)}}, tokenInfoCalls(tokens, &address)...)
+	results, err := util.Aggregate3(client, calls, blockNumber)
+	if err == nil {
+		if !results[0].Success {
+			return nil, nil, fmt.Errorf("getEthBalance call to Multicall3 reverted")
+		}
+		ethBalance := new(big.Int).SetBytes(results[0].ReturnData)
+		infos, err := decodeTokenInfoResults(tokens, &address, results[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		warnTokenDecimals(tokens, infos)
+		return ethBalance, infos, nil
+	}
+
+	// Multicall3 is unavailable, query each value separately
+	ethBalance, err := client.BalanceAt(context.Background(), address, blockNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get ETH balance: %v", err)
+	}
+	infos, err := fetchTokenInfoDirect(client, tokens, &address, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ethBalance, infos, nil
+}
+
+// parseBlockNumber parses a decimal or 0x-prefixed hex block number; "latest" or "" returns nil
+func parseBlockNumber(blockStr string) (*big.Int, error) {
+	blockStr = strings.TrimSpace(blockStr)
+	if blockStr == "" || strings.EqualFold(blockStr, "latest") {
+		return nil, nil
+	}
+
+	var number uint64
+	var err error
+	if strings.HasPrefix(blockStr, "0x") || strings.HasPrefix(blockStr, "0X") {
+		number, err = strconv.ParseUint(blockStr[2:], 16, 64)
+	} else {
+		number, err = strconv.ParseUint(blockStr, 10, 64)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %s", blockStr)
+	}
+
+	return new(big.Int).SetUint64(number), nil
+}
+
+// parseTokenList parses a comma-separated list of token contract addresses, skipping duplicates
+func parseTokenList(tokensStr string) ([]common.Address, error) {
+	var tokens []common.Address
+	seen := make(map[common.Address]bool)
+	for _, tokenStr := range strings.Split(tokensStr, ",") {
+		tokenStr = strings.TrimSpace(tokenStr)
+		if tokenStr == "" {
+			continue
+		}
+		if !common.IsHexAddress(tokenStr) {
+			return nil, fmt.Errorf("invalid token address: %s", tokenStr)
+		}
+		token := common.HexToAddress(tokenStr)
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseBlockNumber(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64 // -1 for latest
+		wantErr bool
+	}{
+		{"", -1, false},
+		{"latest", -1, false},
+		{"17000000", 17000000, false},
+		{"0x10", 16, false},
+		{"0xzz", 0, true},
+		{"-5", 0, true},
+	}
+
+	for _, tc := range tests {
+		got, err := parseBlockNumber(tc.input)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %q, but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.input, err)
+			continue
+		}
+		if tc.want < 0 {
+			if got != nil {
+				t.Errorf("Expected nil block for %q, got %s", tc.input, got)
+			}
+		} else if got == nil || got.Int64() != tc.want {
+			t.Errorf("Unexpected block for %q: %v", tc.input, got)
+		}
+	}
+}
+
+func TestParseTokenList(t *testing.T) {
+	usdc := "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	dai := "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+
+	tokens, err := parseTokenList(" " + usdc + ", ," + dai + "," + common.HexToAddress(usdc).Hex())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != common.HexToAddress(usdc) || tokens[1] != common.HexToAddress(dai) {
+		t.Errorf("Unexpected tokens: %v", tokens)
+	}
+
+	if tokens, err := parseTokenList(""); err != nil || len(tokens) != 0 {
+		t.Errorf("Expected no tokens for an empty list, got %v (%v)", tokens, err)
+	}
+
+	if _, err := parseTokenList("0x1234"); err == nil {
+		t.Error("Expected error for an invalid address")
+	}
+}
@@ -0,0 +1,811 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// Batch row states recorded in the state file
+const (
+	BatchStatusSigned    = "signed" // recorded before broadcast, may not have reached the network
+	BatchStatusSent      = "sent"
+	BatchStatusConfirmed = "confirmed"
+	BatchStatusFailed    = "failed"
+	BatchStatusReplaced  = "replaced" // the nonce was used by another transaction without a receipt for this one
+)
+
+// DefaultBatchWaitTimeout is how long the batch waits for confirmations before giving up
+const DefaultBatchWaitTimeout = 10 * time.Minute
+
+// BatchRowState records a row of a batch that has been signed. RawTx keeps the signed
+// transaction so that a resumed batch rebroadcasts it instead of signing the row again.
+type BatchRowState struct {
+	Line   int    `json:"line"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+	Token  string `json:"token,omitempty"`
+	Nonce  uint64 `json:"nonce"`
+	TxHash string `json:"tx_hash"`
+	RawTx  string `json:"raw_tx,omitempty"`
+	Status string `json:"status"`
+}
+
+// BatchState is the resumable state of a batch transfer. CSVHash is the SHA-256 of the
+// CSV file the batch was started with.
+type BatchState struct {
+	From    string          `json:"from"`
+	CSVHash string          `json:"csv_hash,omitempty"`
+	Rows    []BatchRowState `json:"rows"`
+}
+
+// batchRow is one payout parsed from the CSV file
+type batchRow struct {
+	Line      int
+	To        string
+	AmountStr string
+	Token     string // empty for ETH
+	Amount    *big.Int
+	Symbol    string
+	Decimals  uint8
+	GasLimit  uint64
+}
+
+// BatchTransferCmd creates the batch transfer command
+func BatchTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-transfer",
+		Short: "Send ETH and ERC20 payouts listed in a CSV file",
+		Long: `Send ETH and ERC20 payouts listed in a CSV file, unlocking the wallet once.
+
+Each CSV row is: to,amount[,token]. Rows without a token send ETH and take an amount
+with a unit like the transfer command (e.g. 0.5eth); rows with a token contract address
+take an amount in token units (e.g. 100.5). A header row starting with "to" is skipped.
+
+Every row is validated before anything is sent. Transactions use sequential nonces and
+each signed transaction is recorded in a state file (default: <csv>.state.json) before it
+is broadcast, so an interrupted batch can be resumed by running the same command again.
+Resuming rebroadcasts recorded transactions instead of signing them again, and refuses
+to continue if the CSV file changed.
+
+Examples:
+  eth-cli batch-transfer --csv payouts.csv -p google -n myWallet
+  eth-cli batch-transfer --csv payouts.csv -f ./wallet.json --dry-run`,
+		RunE: runBatchTransfer,
+	}
+
+	cmd.Flags().String("csv", "", "CSV file with to,amount[,token] rows")
+	cmd.Flags().String("state", "", "State file recording sent rows (default: <csv>.state.json)")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().Bool("dry-run", false, "Only validate the rows and show the summary, do not sign or broadcast")
+	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the batch")
+	addFeeFlags(cmd)
+	cmd.Flags().Uint64("gas-limit", 0, "Gas limit for every row (default: estimated per row)")
+	cmd.Flags().Bool("no-wait", false, "Do not wait for the transactions to be confirmed")
+	cmd.Flags().Duration("wait-timeout", DefaultBatchWaitTimeout, "How long to wait for confirmations before giving up")
+
+	cmd.MarkFlagRequired("csv")
+
+	return cmd
+}
+
+func runBatchTransfer(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	csvPath, _ := cmd.Flags().GetString("csv")
+	statePath, _ := cmd.Flags().GetString("state")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	if statePath == "" {
+		statePath = csvPath + ".state.json"
+	}
+
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Parse and validate the CSV before touching the network or the wallet
+	csvData, err := os.ReadFile(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CSV file: %v", err)
+	}
+	rows, err := parseBatchCSV(bytes.NewReader(csvData))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no rows found in %s", csvPath)
+	}
+
+	state, err := loadBatchState(statePath)
+	if err != nil {
+		return err
+	}
+	if err := checkBatchCSVHash(&state, batchCSVHash(csvData), statePath); err != nil {
+		return err
+	}
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if row.Token == "" {
+			row.Symbol = nativeSymbol()
+		}
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	// Convert token amounts using each token's decimals
+	if err := resolveBatchTokens(client, rows); err != nil {
+		return err
+	}
+
+	// Unlock the wallet once
+	var privateKey string
+	var fromAddress string
+	if dryRun {
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+	fromAddr := common.HexToAddress(fromAddress)
+
+	// Skip rows that already went out in a previous run
+	if state.From != "" && !strings.EqualFold(state.From, fromAddress) {
+		return fmt.Errorf("state file %s belongs to %s, not %s", statePath, state.From, fromAddress)
+	}
+	state.From = fromAddress
+	pending, err := pendingBatchRows(rows, state)
+	if err != nil {
+		return fmt.Errorf("%v (state file: %s)", err, statePath)
+	}
+	if skipped := len(rows) - len(pending); skipped > 0 {
+		fmt.Printf("Skipping %d row(s) already signed according to %s\n", skipped, statePath)
+	}
+
+	// Rebroadcast rows of a previous run that did not reach the network or were dropped from it
+	if !dryRun {
+		if err := resumeBatchRows(client, fromAddr, &state, statePath); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("All rows have already been sent.")
+		return trackBatch(client, fromAddr, state, statePath, noWait, waitTimeout)
+	}
+
+	// Get chain ID and the first nonce
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	nonce, err := util.GetNonce(client, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+	nonce = nextBatchNonce(nonce, state)
+
+	// Get transaction type and fees, shared by all rows
+	txParams, err := resolveTxParams(cmd, client, false)
+	if err != nil {
+		return err
+	}
+
+	// Estimate the gas of every row
+	for _, row := range pending {
+		row.GasLimit = gasLimit
+		if row.GasLimit == 0 {
+			if row.GasLimit, err = estimateBatchRowGas(client, fromAddr, row); err != nil {
+				return fmt.Errorf("line %d: %v", row.Line, err)
+			}
+		}
+	}
+
+	// Check that the wallet can cover the whole batch
+	if err := checkBatchBalances(client, fromAddr, pending, txParams); err != nil {
+		return err
+	}
+
+	if dryRun {
+		printBatchSummary(fromAddress, pending, txParams, nonce)
+		return nil
+	}
+
+	// Simulate the first row of each asset before signing
+	simulated, err := simulateBatchRows(client, fromAddr, pending)
+	if err != nil {
+		return err
+	}
+
+	printBatchSummary(fromAddress, pending, txParams, nonce)
+	for _, sim := range simulated {
+		fmt.Printf("Line %d: ", sim.row.Line)
+		printSimulation(sim.sim)
+	}
+
+	// Ask for confirmation
+	if !autoConfirm {
+		fmt.Printf("Send %d transaction(s)? (y/N): ", len(pending))
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Batch cancelled.")
+			return nil
+		}
+	}
+
+	// Sign each row with sequential nonces and record it before it is broadcast
+	for i, row := range pending {
+		rowNonce := nonce + uint64(i)
+
+		var rawTx string
+		if row.Token == "" {
+			rawTx, err = util.CreateEthTransferTx(fromAddress, row.To, row.Amount, rowNonce, txParams, row.GasLimit, chainID)
+		} else {
+			rawTx, err = util.CreateERC20TransferTx(fromAddress, row.Token, row.To, row.Amount, rowNonce, txParams, row.GasLimit, chainID)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: failed to create transaction: %v", row.Line, err)
+		}
+
+		if err := checkRawTxNetwork(rawTx); err != nil {
+			return fmt.Errorf("line %d: %v", row.Line, err)
+		}
+		signedTx, err := util.SignTransaction(rawTx, privateKey)
+		if err != nil {
+			return fmt.Errorf("line %d: failed to sign transaction: %v", row.Line, err)
+		}
+		tx, err := util.DecodeSignedTransaction(signedTx)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", row.Line, err)
+		}
+
+		state.Rows = append(state.Rows, BatchRowState{
+			Line:   row.Line,
+			To:     row.To,
+			Amount: row.AmountStr,
+			Token:  row.Token,
+			Nonce:  rowNonce,
+			TxHash: tx.Hash().Hex(),
+			RawTx:  signedTx,
+			Status: BatchStatusSigned,
+		})
+		if err := saveBatchState(statePath, state); err != nil {
+			return err
+		}
+
+		txHash, err := util.BroadcastTransaction(signedTx, rpcURL)
+		if err != nil {
+			return fmt.Errorf("line %d: failed to broadcast transaction: %v (rerun the command to rebroadcast it)", row.Line, err)
+		}
+		fmt.Printf("Line %d: sent %s %s to %s: %s\n", row.Line, formatBatchAmount(row), row.Symbol, row.To, txHash)
+
+		state.Rows[len(state.Rows)-1].Status = BatchStatusSent
+		if err := saveBatchState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	return trackBatch(client, fromAddr, state, statePath, noWait, waitTimeout)
+}
+
+// batchCSVHash returns the hex-encoded SHA-256 of the CSV file
+func batchCSVHash(csvData []byte) string {
+	sum := sha256.Sum256(csvData)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkBatchCSVHash refuses to resume a batch whose CSV file changed and records the hash of a new batch
+func checkBatchCSVHash(state *BatchState, csvHash string, statePath string) error {
+	if state.CSVHash != "" && state.CSVHash != csvHash {
+		return fmt.Errorf("the CSV file changed since the batch in %s was started, refusing to resume (use a new --state file to start a new batch)", statePath)
+	}
+	state.CSVHash = csvHash
+	return nil
+}
+
+// parseBatchCSV parses to,amount[,token] rows, validating addresses and ETH amounts.
+// Token amounts are converted later, once the token decimals are known.
+func parseBatchCSV(r io.Reader) ([]*batchRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []*batchRow
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		// Skip an optional header row
+		if first {
+			first = false
+			if strings.EqualFold(strings.TrimSpace(record[0]), "to") {
+				continue
+			}
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("line %d: expected to,amount[,token], got %d field(s)", line, len(record))
+		}
+
+		row := &batchRow{
+			Line:      line,
+			To:        strings.TrimSpace(record[0]),
+			AmountStr: strings.TrimSpace(record[1]),
+		}
+		if len(record) == 3 {
+			row.Token = strings.TrimSpace(record[2])
+		}
+
+		if !common.IsHexAddress(row.To) {
+			return nil, fmt.Errorf("line %d: invalid recipient address: %s", line, row.To)
+		}
+		row.To = common.HexToAddress(row.To).Hex()
+
+		if row.Token == "" {
+			row.Amount, err = parseEthAmount(row.AmountStr)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid ETH amount: %v", line, err)
+			}
+			if row.Amount.Sign() == 0 {
+				return nil, fmt.Errorf("line %d: amount must be greater than zero", line)
+			}
+			row.Symbol = "ETH"
+			row.Decimals = 18
+		} else {
+			if !common.IsHexAddress(row.Token) {
+				return nil, fmt.Errorf("line %d: invalid token address: %s", line, row.Token)
+			}
+			row.Token = common.HexToAddress(row.Token).Hex()
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// resolveBatchTokens fetches the symbol and decimals of each token once and converts the token amounts
+func resolveBatchTokens(client *ethclient.Client, rows []*batchRow) error {
+	// Look up every distinct token in one batch
+	var tokens []common.Address
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if row.Token != "" && !seen[row.Token] {
+			seen[row.Token] = true
+			tokens = append(tokens, common.HexToAddress(row.Token))
+		}
+	}
+	infos, err := fetchTokenInfo(client, tokens, nil, nil)
+	if err != nil {
+		return err
+	}
+	tokenInfos := make(map[string]tokenInfo)
+	for i, token := range tokens {
+		tokenInfos[token.Hex()] = infos[i]
+	}
+
+	for _, row := range rows {
+		if row.Token == "" {
+			continue
+		}
+
+		info := tokenInfos[row.Token]
+		amount, err := util.ParseTokenAmount(row.AmountStr, info.Decimals)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid token amount: %v", row.Line, err)
+		}
+		if amount.Sign() == 0 {
+			return fmt.Errorf("line %d: amount must be greater than zero", row.Line)
+		}
+		row.Amount = amount
+		row.Symbol = info.Symbol
+		row.Decimals = info.Decimals
+	}
+
+	return nil
+}
+
+// pendingBatchRows returns the rows that have not been signed yet. Rows recorded in the state
+// must still match the CSV, otherwise resuming could pay the wrong recipient or amount.
+func pendingBatchRows(rows []*batchRow, state BatchState) ([]*batchRow, error) {
+	sent := make(map[int]BatchRowState)
+	for _, rowState := range state.Rows {
+		sent[rowState.Line] = rowState
+	}
+
+	var pending []*batchRow
+	for _, row := range rows {
+		rowState, ok := sent[row.Line]
+		if !ok {
+			pending = append(pending, row)
+			continue
+		}
+		if !strings.EqualFold(rowState.To, row.To) || rowState.Amount != row.AmountStr || !strings.EqualFold(rowState.Token, row.Token) {
+			return nil, fmt.Errorf("line %d changed since it was sent in %s", row.Line, rowState.TxHash)
+		}
+	}
+
+	return pending, nil
+}
+
+// batchRowCall returns the recipient, value and calldata of the transaction of a row
+func batchRowCall(row *batchRow) (common.Address, *big.Int, []byte) {
+	if row.Token == "" {
+		return common.HexToAddress(row.To), row.Amount, nil
+	}
+	return common.HexToAddress(row.Token), big.NewInt(0), util.EncodeERC20Transfer(row.To, row.Amount)
+}
+
+// estimateBatchRowGas estimates the gas limit of a row, adding a buffer for token transfers
+func estimateBatchRowGas(client *ethclient.Client, from common.Address, row *batchRow) (uint64, error) {
+	to, value, data := batchRowCall(row)
+	gasLimit, err := util.EstimateGas(client, from, &to, value, data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %v", err)
+	}
+	if row.Token == "" {
+		return gasLimit, nil
+	}
+	return uint64(float64(gasLimit) * GasEstimationBuffer), nil
+}
+
+// batchRowSimulation is the simulation of a row standing for the rows of its asset
+type batchRowSimulation struct {
+	row *batchRow
+	sim *txSimulation
+}
+
+// simulateBatchRows simulates the first row of each asset. The other rows of an asset only differ
+// in recipient and amount, and estimating their gas already checked that none of them reverts.
+func simulateBatchRows(client *ethclient.Client, from common.Address, rows []*batchRow) ([]batchRowSimulation, error) {
+	var simulated []batchRowSimulation
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if seen[row.Token] {
+			continue
+		}
+		seen[row.Token] = true
+
+		to, value, data := batchRowCall(row)
+		sim, err := simulateTx(client, from, &to, value, data, row.GasLimit)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", row.Line, err)
+		}
+		simulated = append(simulated, batchRowSimulation{row: row, sim: sim})
+	}
+	return simulated, nil
+}
+
+// checkBatchBalances verifies the wallet holds enough ETH (amounts plus max gas fees) and tokens for the batch
+func checkBatchBalances(client *ethclient.Client, from common.Address, rows []*batchRow, txParams util.TxParams) error {
+	ethNeeded := new(big.Int)
+	tokensNeeded := make(map[string]*big.Int)
+	for _, row := range rows {
+		gasFee := new(big.Int).Mul(txParams.Fees.MaxFeePerGas, new(big.Int).SetUint64(row.GasLimit))
+		ethNeeded.Add(ethNeeded, gasFee)
+		if row.Token == "" {
+			ethNeeded.Add(ethNeeded, row.Amount)
+			continue
+		}
+		if tokensNeeded[row.Token] == nil {
+			tokensNeeded[row.Token] = new(big.Int)
+		}
+		tokensNeeded[row.Token].Add(tokensNeeded[row.Token], row.Amount)
+	}
+
+	balance, err := client.BalanceAt(context.Background(), from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get ETH balance: %v", err)
+	}
+	if balance.Cmp(ethNeeded) < 0 {
+		symbol := nativeSymbol()
+		return fmt.Errorf("insufficient %s balance: %s %s needed (amounts plus max gas fees), %s %s available", symbol, formatEther(ethNeeded), symbol, formatEther(balance), symbol)
+	}
+
+	for token, needed := range tokensNeeded {
+		tokenBalance, err := NewERC20Contract(client, common.HexToAddress(token)).BalanceOf(context.Background(), from, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get balance of token %s: %v", token, err)
+		}
+		if tokenBalance.Cmp(needed) < 0 {
+			return fmt.Errorf("insufficient balance of token %s: %s needed, %s available", token, needed, tokenBalance)
+		}
+	}
+
+	return nil
+}
+
+// printBatchSummary prints the rows to be sent with their nonces and the totals per asset
+func printBatchSummary(from string, rows []*batchRow, txParams util.TxParams, nonce uint64) {
+	fmt.Println("Batch Details:")
+	fmt.Printf("From: %s\n", from)
+	fmt.Printf("Transaction Type: %s\n", util.TxTypeName(txParams.Type))
+	if txParams.Type == util.TxTypeDynamicFee {
+		fmt.Printf("Max Fee: %s Gwei\n", formatGwei(txParams.Fees.MaxFeePerGas))
+		fmt.Printf("Priority Fee: %s Gwei\n", formatGwei(txParams.Fees.MaxPriorityFeePerGas))
+	} else {
+		fmt.Printf("Gas Price: %s Gwei\n", formatGwei(txParams.Fees.MaxFeePerGas))
+	}
+
+	type assetTotal struct {
+		symbol   string
+		decimals uint8
+		amount   *big.Int
+	}
+	totals := make(map[string]*assetTotal)
+	var order []string
+	var totalGas uint64
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tNONCE\tTO\tAMOUNT\tASSET\tGAS LIMIT")
+	for i, row := range rows {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%d\n", row.Line, nonce+uint64(i), row.To, formatBatchAmount(row), row.Symbol, row.GasLimit)
+
+		total, ok := totals[row.Token]
+		if !ok {
+			total = &assetTotal{symbol: row.Symbol, decimals: row.Decimals, amount: new(big.Int)}
+			totals[row.Token] = total
+			order = append(order, row.Token)
+		}
+		total.amount.Add(total.amount, row.Amount)
+		totalGas += row.GasLimit
+	}
+	w.Flush()
+
+	fmt.Println("Totals:")
+	for _, token := range order {
+		fmt.Printf("  %s %s\n", util.FormatTokenAmount(totals[token].amount, totals[token].decimals), totals[token].symbol)
+	}
+	fmt.Printf("  Max Gas Fee: %s %s\n", formatEther(new(big.Int).Mul(txParams.Fees.MaxFeePerGas, new(big.Int).SetUint64(totalGas))), nativeSymbol())
+}
+
+// formatBatchAmount formats the amount of a row in its asset's units
+func formatBatchAmount(row *batchRow) string {
+	return util.FormatTokenAmount(row.Amount, row.Decimals)
+}
+
+// resumeBatchRows checks the rows left signed or sent by a previous run, rebroadcasting the
+// recorded transactions the node does not know (never sent, or dropped from the mempool). Rows
+// are never signed again, so each nonce is only used once.
+func resumeBatchRows(client *ethclient.Client, from common.Address, state *BatchState, statePath string) error {
+	for i := range state.Rows {
+		rowState := &state.Rows[i]
+		if rowState.Status != BatchStatusSigned && rowState.Status != BatchStatusSent {
+			continue
+		}
+
+		if err := updateBatchRow(client, from, rowState); err != nil {
+			return err
+		}
+		if err := saveBatchState(statePath, *state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateBatchRow advances a signed or sent row: it records the receipt once mined, marks the row
+// replaced when the account nonce passed it without a receipt, and rebroadcasts the recorded
+// transaction when the node no longer knows it (e.g. it was dropped from the mempool).
+func updateBatchRow(client *ethclient.Client, from common.Address, rowState *BatchRowState) error {
+	txHash := common.HexToHash(rowState.TxHash)
+
+	receipt, err := batchRowReceipt(client, txHash)
+	if err != nil || receipt != nil {
+		if receipt != nil {
+			recordBatchReceipt(rowState, receipt)
+		}
+		return err
+	}
+
+	minedNonce, err := client.NonceAt(context.Background(), from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if minedNonce > rowState.Nonce {
+		// The transaction may have been mined between the two calls
+		receipt, err := batchRowReceipt(client, txHash)
+		if err != nil {
+			return err
+		}
+		if receipt != nil {
+			recordBatchReceipt(rowState, receipt)
+			return nil
+		}
+		rowState.Status = BatchStatusReplaced
+		fmt.Printf("Line %d: \033[1;31mnonce %d was used by another transaction\033[0m, %s was replaced or dropped. Check whether %s was paid before sending it again.\n", rowState.Line, rowState.Nonce, rowState.TxHash, rowState.To)
+		return nil
+	}
+
+	_, _, err = client.TransactionByHash(context.Background(), txHash)
+	if err == nil {
+		rowState.Status = BatchStatusSent
+		return nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("failed to get transaction %s: %v", rowState.TxHash, err)
+	}
+
+	// The node does not know the transaction, broadcast the recorded one again
+	if rowState.RawTx == "" {
+		return fmt.Errorf("line %d: transaction %s is unknown to the node and the state file has no signed transaction to rebroadcast", rowState.Line, rowState.TxHash)
+	}
+	tx, err := util.DecodeSignedTransaction(rowState.RawTx)
+	if err != nil {
+		return fmt.Errorf("line %d: %v", rowState.Line, err)
+	}
+	if tx.Hash() != txHash {
+		return fmt.Errorf("line %d: the recorded signed transaction does not match %s", rowState.Line, rowState.TxHash)
+	}
+	if err := client.SendTransaction(context.Background(), tx); err != nil {
+		return fmt.Errorf("line %d: failed to rebroadcast %s: %v", rowState.Line, rowState.TxHash, err)
+	}
+	rowState.Status = BatchStatusSent
+	fmt.Printf("Line %d: rebroadcast %s\n", rowState.Line, rowState.TxHash)
+	return nil
+}
+
+// nextBatchNonce returns the first nonce of new rows: the pending nonce of the account, but never
+// a nonce recorded for an earlier row, which the node may have forgotten if it dropped the row
+func nextBatchNonce(pendingNonce uint64, state BatchState) uint64 {
+	nonce := pendingNonce
+	for _, rowState := range state.Rows {
+		if rowState.Nonce+1 > nonce {
+			nonce = rowState.Nonce + 1
+		}
+	}
+	return nonce
+}
+
+// batchRowReceipt returns the receipt of a transaction, or nil if it has not been mined
+func batchRowReceipt(client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of %s: %v", txHash.Hex(), err)
+	}
+	return receipt, nil
+}
+
+// recordBatchReceipt sets the final status of a mined row
+func recordBatchReceipt(rowState *BatchRowState, receipt *types.Receipt) {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		rowState.Status = BatchStatusConfirmed
+		fmt.Printf("Line %d: confirmed in block %d\n", rowState.Line, receipt.BlockNumber)
+	} else {
+		rowState.Status = BatchStatusFailed
+		fmt.Printf("Line %d: \033[1;31mfailed\033[0m in block %d (%s)\n", rowState.Line, receipt.BlockNumber, rowState.TxHash)
+	}
+}
+
+// trackBatch waits for the receipts of the sent rows and records their final status
+func trackBatch(client *ethclient.Client, from common.Address, state BatchState, statePath string, noWait bool, timeout time.Duration) error {
+	if noWait {
+		fmt.Printf("Transactions recorded in %s\n", statePath)
+		return nil
+	}
+
+	fmt.Println("Waiting for transaction confirmations...")
+	deadline := time.Now().Add(timeout)
+	failed := 0
+	for i := range state.Rows {
+		rowState := &state.Rows[i]
+		for rowState.Status == BatchStatusSigned || rowState.Status == BatchStatusSent {
+			status := rowState.Status
+			if err := updateBatchRow(client, from, rowState); err != nil {
+				return err
+			}
+			if rowState.Status != status {
+				if err := saveBatchState(statePath, state); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out after %s waiting for line %d (%s), rerun the command to keep tracking the batch", timeout, rowState.Line, rowState.TxHash)
+			}
+			time.Sleep(2 * time.Second)
+		}
+
+		if rowState.Status == BatchStatusFailed || rowState.Status == BatchStatusReplaced {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d transaction(s) failed or were replaced, see %s", failed, statePath)
+	}
+	fmt.Println("All transactions confirmed successfully!")
+	return nil
+}
+
+// loadBatchState reads the state file, returning an empty state if it does not exist yet
+func loadBatchState(path string) (BatchState, error) {
+	var state BatchState
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state file: %v", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse state file %s: %v", path, err)
+	}
+	return state, nil
+}
+
+// saveBatchState writes the state file atomically
+func saveBatchState(path string, state BatchState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %v", err)
+	}
+	if err := util.SaveToFileSystem(data, path); err != nil {
+		return fmt.Errorf("failed to save state file: %v", err)
+	}
+	return nil
+}
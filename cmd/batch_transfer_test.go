@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseBatchCSV(t *testing.T) {
+	input := `to,amount,token
+0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,0.5eth
+# monthly USDC payout
+0x9858EfFD232B4033E47d90003D41EC34EcaEda94, 100.25, 0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48
+`
+	rows, err := parseBatchCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	if rows[0].Line != 2 || rows[0].To != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" || rows[0].Token != "" {
+		t.Errorf("Unexpected ETH row: %+v", rows[0])
+	}
+	if rows[0].Amount.String() != "500000000000000000" || rows[0].Symbol != "ETH" {
+		t.Errorf("Unexpected ETH amount: %s %s", rows[0].Amount, rows[0].Symbol)
+	}
+
+	if rows[1].Line != 4 || rows[1].Token != "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48" || rows[1].AmountStr != "100.25" {
+		t.Errorf("Unexpected token row: %+v", rows[1])
+	}
+	if rows[1].Amount != nil {
+		t.Errorf("Token amounts should be converted once the decimals are known, got %s", rows[1].Amount)
+	}
+
+	invalid := []string{
+		"0x1234,1eth\n",
+		"0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,abc\n",
+		"0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,0eth\n",
+		"0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,1,0xnottoken\n",
+		"0x2c7536e3605d9c16a7a3d7b1898e529396a65c23\n",
+	}
+	for _, input := range invalid {
+		if _, err := parseBatchCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %q, but got none", input)
+		}
+	}
+}
+
+func TestPendingBatchRows(t *testing.T) {
+	rows, err := parseBatchCSV(strings.NewReader("0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,1eth\n0x9858EfFD232B4033E47d90003D41EC34EcaEda94,2eth\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	state := BatchState{Rows: []BatchRowState{
+		{Line: 1, To: "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23", Amount: "1eth", TxHash: "0x01", Status: BatchStatusSent},
+	}}
+	pending, err := pendingBatchRows(rows, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Line != 2 {
+		t.Errorf("Expected only line 2 to be pending, got %+v", pending)
+	}
+
+	state.Rows[0].Amount = "10eth"
+	if _, err := pendingBatchRows(rows, state); err == nil {
+		t.Error("Expected error for a row that changed since it was sent, but got none")
+	}
+}
+
+func TestPendingBatchRowsSigned(t *testing.T) {
+	rows, err := parseBatchCSV(strings.NewReader("0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,1eth\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	// A row signed but not broadcast must be rebroadcast, never signed again
+	state := BatchState{Rows: []BatchRowState{
+		{Line: 1, To: "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23", Amount: "1eth", TxHash: "0x01", RawTx: "0x02", Status: BatchStatusSigned},
+	}}
+	pending, err := pendingBatchRows(rows, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending rows, got %+v", pending)
+	}
+}
+
+func TestNextBatchNonce(t *testing.T) {
+	state := BatchState{Rows: []BatchRowState{
+		{Line: 1, Nonce: 7, Status: BatchStatusConfirmed},
+		{Line: 2, Nonce: 8, Status: BatchStatusSent},
+	}}
+
+	tests := []struct {
+		pending  uint64
+		state    BatchState
+		expected uint64
+	}{
+		{5, BatchState{}, 5},
+		// A dropped row is forgotten by the node, its nonce must not be reused
+		{8, state, 9},
+		{12, state, 12},
+	}
+	for _, test := range tests {
+		if got := nextBatchNonce(test.pending, test.state); got != test.expected {
+			t.Errorf("Unexpected nonce for pending nonce %d: got %d, want %d", test.pending, got, test.expected)
+		}
+	}
+}
+
+func TestCheckBatchCSVHash(t *testing.T) {
+	csvHash := batchCSVHash([]byte("0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,1eth\n"))
+	if len(csvHash) != 64 {
+		t.Fatalf("Unexpected CSV hash: %s", csvHash)
+	}
+
+	// A new batch records the hash
+	var state BatchState
+	if err := checkBatchCSVHash(&state, csvHash, "payouts.csv.state.json"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if state.CSVHash != csvHash {
+		t.Errorf("Expected the CSV hash to be recorded, got %q", state.CSVHash)
+	}
+
+	// Resuming with the same CSV is allowed, a changed CSV is refused
+	if err := checkBatchCSVHash(&state, csvHash, "payouts.csv.state.json"); err != nil {
+		t.Errorf("Unexpected error for an unchanged CSV: %v", err)
+	}
+	changed := batchCSVHash([]byte("0x2c7536e3605d9c16a7a3d7b1898e529396a65c23,2eth\n"))
+	if err := checkBatchCSVHash(&state, changed, "payouts.csv.state.json"); err == nil {
+		t.Error("Expected error for a changed CSV, but got none")
+	}
+	if state.CSVHash != csvHash {
+		t.Errorf("A refused resume must not overwrite the recorded hash, got %q", state.CSVHash)
+	}
+}
+
+func TestBatchRowCall(t *testing.T) {
+	recipient := "0x00000000000000000000000000000000000000bb"
+	token := "0x00000000000000000000000000000000000000cc"
+
+	to, value, data := batchRowCall(&batchRow{To: recipient, Amount: big.NewInt(5)})
+	if to != common.HexToAddress(recipient) || value.Int64() != 5 || data != nil {
+		t.Errorf("Unexpected ETH call: %s %s %x", to.Hex(), value, data)
+	}
+
+	to, value, data = batchRowCall(&batchRow{To: recipient, Token: token, Amount: big.NewInt(5)})
+	if to != common.HexToAddress(token) || value.Sign() != 0 || !bytes.Equal(data, util.EncodeERC20Transfer(recipient, big.NewInt(5))) {
+		t.Errorf("Unexpected token call: %s %s %x", to.Hex(), value, data)
+	}
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/term"
+)
+
+// CombineCmd 返回 combine 命令，用于从 Shamir 分片重建钱包
+func CombineCmd() *cobra.Command {
+	var inputLocations string
+	var shareName string
+	var outputLocations string
+	var walletName string
+	var fsPath string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "combine",
+		Short: "Rebuild a wallet from Shamir secret shares",
+		Long: `Collect Shamir secret shares created by the split command and rebuild a normal wallet file.
+
+--input takes a comma-separated list of share locations: cloud providers (the share is read
+as <name>-share) or local share file paths. At least the threshold number of shares is required.
+The rebuilt wallet is encrypted with a new AES password and saved like the create command does.
+
+Examples:
+  eth-cli combine -i google,dropbox,s3 -n myWallet -o fs -p /tmp/restored.json
+  eth-cli combine -i dropbox,/mnt/usb1/myWallet-share-2.json -n myWallet -o google --wallet-name restored`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocations == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if walletName == "" {
+				walletName = shareName
+			}
+			if outputLocations != "fs" && walletName == "" {
+				fmt.Println("Error: --wallet-name parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			// 读取分片文件
+			var shareFiles []ShareFile
+			for _, location := range strings.Split(inputLocations, ",") {
+				location = strings.TrimSpace(location)
+				if location == "" {
+					continue
+				}
+
+				shareData, err := getWalletData(location, shareName+"-share")
+				if err != nil {
+					fmt.Printf("Error loading share from %s: %v\n", location, err)
+					os.Exit(1)
+				}
+
+				var shareFile ShareFile
+				if err := json.Unmarshal(shareData, &shareFile); err != nil || shareFile.Type != ShareFileType {
+					fmt.Printf("Error: %s does not contain a wallet share\n", location)
+					os.Exit(1)
+				}
+				fmt.Printf("Loaded share %d of %d from %s\n", shareFile.Index, shareFile.Shares, location)
+				shareFiles = append(shareFiles, shareFile)
+			}
+
+			if err := validateShareSet(shareFiles); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 解密分片，密码错误时针对该分片重新输入
+			var sharePassword string
+			shamirShares := make([]util.ShamirShare, len(shareFiles))
+			for i, shareFile := range shareFiles {
+				var shareHex string
+				var err error
+				if sharePassword != "" {
+					shareHex, err = util.DecryptMnemonic(shareFile.EncryptedShare, sharePassword)
+				}
+				if sharePassword == "" || err != nil {
+					fmt.Printf("Please Enter \033[1;31mShare Password\033[0m for share %d: ", shareFile.Index)
+					passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+					if err != nil {
+						fmt.Printf("\nError reading password: %v\n", err)
+						os.Exit(1)
+					}
+					fmt.Println()
+					sharePassword = string(passwordBytes)
+
+					shareHex, err = util.DecryptMnemonic(shareFile.EncryptedShare, sharePassword)
+					if err != nil {
+						fmt.Printf("Error decrypting share %d: %v\n", shareFile.Index, err)
+						os.Exit(1)
+					}
+				}
+
+				value, err := hex.DecodeString(shareHex)
+				if err != nil {
+					fmt.Printf("Error decoding share %d: %v\n", shareFile.Index, err)
+					os.Exit(1)
+				}
+				shamirShares[i] = util.ShamirShare{Index: shareFile.Index, Value: value}
+			}
+
+			// 合并分片
+			combined, err := util.CombineShares(shamirShares)
+			if err != nil {
+				fmt.Printf("Error combining shares: %v\n", err)
+				os.Exit(1)
+			}
+			secretBytes, err := openShareSecret(combined)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			first := shareFiles[0]
+			var wallet WalletFile
+			var secret string
+			var addressHex string
+			if first.WalletType == WalletTypePrivateKey {
+				secret = hex.EncodeToString(secretBytes)
+				addressHex, _, err = getAddressFromPrivateKey(secret)
+				if err != nil {
+					fmt.Printf("Error generating address: %v\n", err)
+					os.Exit(1)
+				}
+				wallet = WalletFile{Version: 1, Type: WalletTypePrivateKey}
+			} else {
+				secret, err = bip39.NewMnemonic(secretBytes)
+				if err != nil {
+					fmt.Printf("Error rebuilding mnemonic: %v\n", err)
+					os.Exit(1)
+				}
+				wallet = WalletFile{
+					Version:        1,
+					HDPath:         first.HDPath,
+					DerivationPath: first.DerivationPath,
+					TestNet:        first.TestNet,
+					Accounts:       first.Accounts,
+				}
+
+				passphrase, err := promptBIP39Passphrase()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				addressHex, err = walletAddressFromSecret(wallet, secret, passphrase)
+				if err != nil {
+					fmt.Printf("Error generating address: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			fmt.Printf("Wallet Address: \033[1;32m%s\033[0m\n", addressHex)
+
+			// 获取AES加密密码
+			fmt.Println("\nPlease enter \033[1;31mAES Encryption Password\033[0m for the rebuilt wallet.")
+			fmt.Println("It is recommended to use a strong password: \033[1;31m8 characters or more, including uppercase, lowercase, numbers, and special characters\033[0m.")
+			password, err := promptNewAESPassword()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			wallet.EncryptedMnemonic, err = util.EncryptMnemonic(secret, password)
+			if err != nil {
+				fmt.Printf("Error encrypting wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			walletJSON, err := json.MarshalIndent(wallet, "", "  ")
+			if err != nil {
+				fmt.Printf("Error serializing wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 保存到指定位置
+			verifyCommands, err := saveWalletToOutputs(outputLocations, fsPath, walletName, walletJSON, force)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println("\nBefore using this wallet, please test it with the getAddress command:")
+			for _, verifyCommand := range verifyCommands {
+				fmt.Printf("  %s\n", verifyCommand)
+			}
+
+			fmt.Println("\n\033[1;32mSuccess: Wallet rebuilt successfully.\033[0m")
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVarP(&inputLocations, "input", "i", "", "Comma-separated list of share locations (cloud providers or local share file paths)")
+	cmd.Flags().StringVarP(&shareName, "name", "n", "", "Base name of the share files (required for cloud storage)")
+	cmd.Flags().StringVarP(&outputLocations, "output", "o", "", "Output location: 'fs' for local file, or comma-separated list of cloud providers (supported: google, dropbox, s3, box, keychain)")
+	cmd.Flags().StringVar(&walletName, "wallet-name", "", "Name of the rebuilt wallet file (default: the share name)")
+	cmd.Flags().StringVarP(&fsPath, "path", "p", "", "File path for wallet when using --output fs")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite if wallet file already exists")
+
+	cmd.MarkFlagRequired("input")
+	cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+// validateShareSet checks that shares belong to the same split and reach its threshold
+func validateShareSet(shareFiles []ShareFile) error {
+	if len(shareFiles) == 0 {
+		return fmt.Errorf("no shares provided")
+	}
+
+	first := shareFiles[0]
+	if first.Scheme != ShareScheme {
+		return fmt.Errorf("unsupported share scheme: %s", first.Scheme)
+	}
+
+	if first.Version != ShareFileVersion {
+		return fmt.Errorf("unsupported share file version: %d", first.Version)
+	}
+
+	seen := make(map[uint8]bool)
+	for _, shareFile := range shareFiles {
+		if shareFile.SetID != first.SetID || shareFile.Version != first.Version {
+			return fmt.Errorf("share %d belongs to a different split (set %s, expected %s)", shareFile.Index, shareFile.SetID, first.SetID)
+		}
+		if seen[shareFile.Index] {
+			return fmt.Errorf("share %d was provided more than once", shareFile.Index)
+		}
+		seen[shareFile.Index] = true
+	}
+
+	if len(shareFiles) < first.Threshold {
+		return fmt.Errorf("%d shares provided, but %d are required", len(shareFiles), first.Threshold)
+	}
+	return nil
+}
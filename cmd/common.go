@@ -3,27 +3,139 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/term"
 
+	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
 	"github.com/tyler-smith/go-bip39"
 )
 
+// Wallet file types
+const (
+	WalletTypeMnemonic   = "mnemonic"
+	WalletTypePrivateKey = "private_key"
+	WalletTypeWatchOnly  = "watch_only"
+)
+
 // WalletFile 钱包文件结构
+// For private key wallets (Type == WalletTypePrivateKey) the encrypted envelope holds the
+// hex-encoded private key instead of a mnemonic and no HD paths are set.
+// Watch-only wallets (Type == WalletTypeWatchOnly) have no encrypted envelope and hold either
+// a plain address or an account-level xpub together with the path it was derived at.
 type WalletFile struct {
 	Version           int                    `json:"version"`
+	Type              string                 `json:"type,omitempty"`
 	EncryptedMnemonic util.EncryptedMnemonic `json:"encrypted_mnemonic"`
 	HDPath            string                 `json:"hd_path"`
 	DerivationPath    string                 `json:"derivation_path"`
 	TestNet           bool                   `json:"testnet"`
+	Accounts          map[string]uint32      `json:"accounts,omitempty"`
+	Address           string                 `json:"address,omitempty"`
+	Xpub              string                 `json:"xpub,omitempty"`
+	XpubPath          string                 `json:"xpub_path,omitempty"`
+}
+
+// isPrivateKeyWallet reports whether the wallet file holds a raw private key instead of a mnemonic
+func isPrivateKeyWallet(wallet WalletFile) bool {
+	return wallet.Type == WalletTypePrivateKey
+}
+
+// isWatchOnlyWallet reports whether the wallet file holds only an address or xpub and no secret
+func isWatchOnlyWallet(wallet WalletFile) bool {
+	return wallet.Type == WalletTypeWatchOnly
+}
+
+// DefaultHDPath is the standard Ethereum HD path that account indexes are appended to
+const DefaultHDPath = "m/44'/60'/0'/0"
+
+// accountSelector selects which account to derive from a wallet file
+type accountSelector struct {
+	index    uint32
+	hasIndex bool
+	account  string
+}
+
+// addAccountFlags adds the --index and --account flags to a command that loads a key
+func addAccountFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint32("index", 0, "Address index under the wallet's HD path (default: the wallet's derivation path)")
+	cmd.Flags().String("account", "", "Named account label stored in the wallet file")
+}
+
+// getAccountSelector reads the --index and --account flags
+func getAccountSelector(cmd *cobra.Command) (accountSelector, error) {
+	index, _ := cmd.Flags().GetUint32("index")
+	account, _ := cmd.Flags().GetString("account")
+	hasIndex := cmd.Flags().Changed("index")
+
+	if hasIndex && account != "" {
+		return accountSelector{}, fmt.Errorf("--index and --account are mutually exclusive, use one or the other")
+	}
+
+	return accountSelector{index: index, hasIndex: hasIndex, account: account}, nil
+}
+
+// accountPath returns the derivation path of the account at index under the wallet's HD path
+func accountPath(wallet WalletFile, index uint32) string {
+	hdPath := wallet.HDPath
+	if hdPath == "" {
+		hdPath = DefaultHDPath
+	}
+	return fmt.Sprintf("%s/%d", strings.TrimSuffix(hdPath, "/"), index)
+}
+
+// resolveDerivationPath determines the derivation path for the selected account
+func resolveDerivationPath(wallet WalletFile, selector accountSelector) (string, error) {
+	if selector.account != "" {
+		index, ok := wallet.Accounts[selector.account]
+		if !ok {
+			return "", fmt.Errorf("account '%s' not found in wallet file", selector.account)
+		}
+		return accountPath(wallet, index), nil
+	}
+
+	if selector.hasIndex {
+		return accountPath(wallet, selector.index), nil
+	}
+
+	// Fall back to the path recorded in the wallet file
+	if wallet.DerivationPath != "" {
+		return wallet.DerivationPath, nil
+	}
+	return wallet.HDPath, nil
+}
+
+// parseIndexRange parses an inclusive address index range such as "0-49"
+func parseIndexRange(indexRange string) (uint32, uint32, error) {
+	parts := strings.SplitN(strings.TrimSpace(indexRange), "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid range format: %s (expected start-end, e.g. 0-49)", indexRange)
+	}
+
+	start, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range start: %s", parts[0])
+	}
+	end, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range end: %s", parts[1])
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("invalid range: end %d is before start %d", end, start)
+	}
+
+	return uint32(start), uint32(end), nil
 }
 
 // initTxConfig initializes the configuration for transaction commands
@@ -31,15 +143,50 @@ func initTxConfig() (string, error) {
 	// Initialize config
 	initConfig()
 
+	// Use the selected network profile, checking that its RPC serves its chain
+	network, err := activeNetwork()
+	if err != nil {
+		return "", err
+	}
+	if network != nil {
+		if err := verifyNetworkChainID(network); err != nil {
+			return "", err
+		}
+		currentNetwork = network
+		return network.RPC, nil
+	}
+
 	// Get RPC URL from config
 	rpcURL := viper.GetString("rpc")
 	if rpcURL == "" {
-		return "", fmt.Errorf("RPC URL not configured. Please run 'eth-cli config set rpc YOUR_RPC_URL'")
+		return "", fmt.Errorf("RPC URL not configured. Please run 'eth-cli config set rpc YOUR_RPC_URL' or 'eth-cli config network add'")
 	}
 
 	return rpcURL, nil
 }
 
+// initDryRunTxConfig initializes the configuration of a dry run, which must work without the
+// RPC. The network profile is loaded like in initTxConfig, but an unreachable RPC is not an
+// error; an RPC serving another chain still is. The RPC URL may be empty.
+func initDryRunTxConfig() (string, error) {
+	initConfig()
+
+	network, err := activeNetwork()
+	if err != nil {
+		return "", err
+	}
+	if network == nil {
+		return viper.GetString("rpc"), nil
+	}
+	if err := verifyNetworkChainID(network); err != nil {
+		if _, ok := err.(*chainIDMismatchError); ok {
+			return "", err
+		}
+	}
+	currentNetwork = network
+	return network.RPC, nil
+}
+
 // getAddressFromMnemonic derives Ethereum address from mnemonic and passphrase
 func getAddressFromMnemonic(mnemonic, passphrase string, derivationPath string) (string, []byte, error) {
 	// Generate seed from mnemonic
@@ -79,13 +226,90 @@ func getAddressFromMnemonic(mnemonic, passphrase string, derivationPath string)
 	return address, crypto.FromECDSA(privateKey), nil
 }
 
+// getAddressFromPrivateKey derives the Ethereum address of a hex-encoded private key
+func getAddressFromPrivateKey(privateKeyHex string) (string, []byte, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid private key: %v", err)
+	}
+
+	return crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), crypto.FromECDSA(privateKey), nil
+}
+
+// getWatchOnlyAddress returns the address and derivation path of the selected account of a
+// watch-only wallet. Address wallets have a single account; xpub wallets derive the account
+// from the xpub, so the path must lie below the xpub path with no hardened steps.
+func getWatchOnlyAddress(wallet WalletFile, selector accountSelector) (string, string, error) {
+	if wallet.Xpub == "" {
+		if selector.hasIndex || selector.account != "" {
+			return "", "", fmt.Errorf("--index and --account are not supported for address-only watch wallets")
+		}
+		if !common.IsHexAddress(wallet.Address) {
+			return "", "", fmt.Errorf("watch-only wallet has no valid address or xpub")
+		}
+		return common.HexToAddress(wallet.Address).Hex(), "", nil
+	}
+
+	derivationPath, err := resolveDerivationPath(wallet, selector)
+	if err != nil {
+		return "", "", err
+	}
+	address, err := deriveXpubAddress(wallet.Xpub, wallet.XpubPath, derivationPath)
+	if err != nil {
+		return "", "", err
+	}
+	return address, derivationPath, nil
+}
+
+// deriveXpubAddress derives the address at derivationPath from an xpub exported at xpubPath
+func deriveXpubAddress(xpub, xpubPath, derivationPath string) (string, error) {
+	base, err := hdwallet.ParseDerivationPath(xpubPath)
+	if err != nil {
+		return "", fmt.Errorf("error parsing xpub path: %v", err)
+	}
+	path, err := hdwallet.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return "", fmt.Errorf("error parsing derivation path: %v", err)
+	}
+	if len(path) < len(base) {
+		return "", fmt.Errorf("derivation path %s is not below the xpub path %s", derivationPath, xpubPath)
+	}
+	for i := range base {
+		if path[i] != base[i] {
+			return "", fmt.Errorf("derivation path %s is not below the xpub path %s", derivationPath, xpubPath)
+		}
+	}
+
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return "", fmt.Errorf("invalid xpub: %v", err)
+	}
+	for _, n := range path[len(base):] {
+		if n >= hdkeychain.HardenedKeyStart {
+			return "", fmt.Errorf("cannot derive hardened path %s from an xpub", derivationPath)
+		}
+		if key, err = key.Derive(n); err != nil {
+			return "", fmt.Errorf("error deriving account: %v", err)
+		}
+	}
+
+	publicKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("error getting public key: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey.ToECDSA()).Hex(), nil
+}
+
 // processWalletData processes wallet data to extract private key and address
-func processWalletData(walletData []byte) (string, string, error) {
+func processWalletData(walletData []byte, selector accountSelector) (string, string, error) {
 	// Parse wallet file
 	var wallet WalletFile
 	if err := json.Unmarshal(walletData, &wallet); err != nil {
 		return "", "", fmt.Errorf("error parsing wallet file: %v", err)
 	}
+	if isWatchOnlyWallet(wallet) {
+		return "", "", fmt.Errorf("this is a watch-only wallet and has no private key")
+	}
 
 	// Get password
 	fmt.Print("Please Enter \033[1;31mAES\033[0m Password: ")
@@ -96,6 +320,24 @@ func processWalletData(walletData []byte) (string, string, error) {
 	fmt.Println()
 	password := string(passwordBytes)
 
+	// Private key wallets have no mnemonic to derive from
+	if isPrivateKeyWallet(wallet) {
+		if selector.hasIndex || selector.account != "" {
+			return "", "", fmt.Errorf("--index and --account are not supported for private key wallets")
+		}
+
+		privateKeyHex, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
+		if err != nil {
+			return "", "", fmt.Errorf("error decrypting private key: %v", err)
+		}
+
+		address, _, err := getAddressFromPrivateKey(privateKeyHex)
+		if err != nil {
+			return "", "", err
+		}
+		return privateKeyHex, address, nil
+	}
+
 	// Decrypt mnemonic
 	mnemonic, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
 	if err != nil {
@@ -103,27 +345,15 @@ func processWalletData(walletData []byte) (string, string, error) {
 	}
 
 	// Ask if a passphrase was used
-	fmt.Print("Did you use a BIP39 passphrase for this wallet? (y/n): ")
-	var answer string
-	fmt.Scanln(&answer)
-
-	var passphrase string
-	if strings.ToLower(answer) == "y" || strings.ToLower(answer) == "yes" {
-		fmt.Print("Please Enter \033[1;31mBIP39\033[0m Passphrase: ")
-		passphraseBytes, err := term.ReadPassword(int(syscall.Stdin))
-		if err != nil {
-			return "", "", fmt.Errorf("error reading passphrase: %v", err)
-		}
-		fmt.Println()
-		passphrase = string(passphraseBytes)
+	passphrase, err := promptBIP39Passphrase()
+	if err != nil {
+		return "", "", err
 	}
 
 	// Determine which derivation path to use
-	derivationPath := ""
-	if wallet.DerivationPath != "" {
-		derivationPath = wallet.DerivationPath
-	} else if wallet.HDPath != "" {
-		derivationPath = wallet.HDPath
+	derivationPath, err := resolveDerivationPath(wallet, selector)
+	if err != nil {
+		return "", "", err
 	}
 
 	address, privateKeyBytes, err := getAddressFromMnemonic(mnemonic, passphrase, derivationPath)
@@ -137,19 +367,65 @@ func processWalletData(walletData []byte) (string, string, error) {
 	return privateKeyHex, address, nil
 }
 
+// promptBIP39Passphrase asks whether a BIP39 passphrase was used and reads it if so
+func promptBIP39Passphrase() (string, error) {
+	fmt.Print("Did you use a BIP39 passphrase for this wallet? (y/n): ")
+	var answer string
+	fmt.Scanln(&answer)
+
+	if strings.ToLower(answer) != "y" && strings.ToLower(answer) != "yes" {
+		return "", nil
+	}
+
+	fmt.Print("Please Enter \033[1;31mBIP39\033[0m Passphrase: ")
+	passphraseBytes, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return "", fmt.Errorf("error reading passphrase: %v", err)
+	}
+	fmt.Println()
+
+	return string(passphraseBytes), nil
+}
+
+// promptNewAESPassword reads a new AES password twice and checks its strength
+func promptNewAESPassword() (string, error) {
+	fmt.Print("Please Enter \033[1;31mAES Encryption Password\033[0m: ")
+	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return "", fmt.Errorf("error reading password: %v", err)
+	}
+	fmt.Print("\nPlease Re-Enter \033[1;31mAES Encryption Password\033[0m: ")
+	confirmPasswordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return "", fmt.Errorf("error reading password confirmation: %v", err)
+	}
+	fmt.Println()
+
+	if string(passwordBytes) != string(confirmPasswordBytes) {
+		return "", fmt.Errorf("passwords do not match")
+	}
+
+	password := string(passwordBytes)
+	if !isStrongPassword(password) {
+		return "", fmt.Errorf("password is not strong enough. It must be at least 8 characters and include uppercase, lowercase, numbers, and special characters")
+	}
+
+	return password, nil
+}
+
 // getPrivateKeyFromLocalFile retrieves a private key from a local wallet file
-func getPrivateKeyFromLocalFile(filePath string) (string, string, error) {
+func getPrivateKeyFromLocalFile(filePath string, selector accountSelector) (string, string, error) {
 	// Load from local file system using the wrapper function
 	walletData, err := getWalletDataFromLocalFile(filePath)
 	if err != nil {
 		return "", "", fmt.Errorf("error loading wallet from local file: %v", err)
 	}
 
-	return processWalletData(walletData)
+	return processWalletData(walletData, selector)
 }
 
 // getPrivateKeyFromProvider retrieves a private key from a provider
-func getPrivateKeyFromProvider(provider string, name string) (string, string, error) {
+func getPrivateKeyFromProvider(provider string, name string, selector accountSelector) (string, string, error) {
 	// Check if the provider is a cloud provider
 	isCloudProvider := false
 	for _, p := range util.CLOUD_PROVIDERS {
@@ -176,7 +452,34 @@ func getPrivateKeyFromProvider(provider string, name string) (string, string, er
 		}
 	}
 
-	return processWalletData(walletData)
+	return processWalletData(walletData, selector)
+}
+
+// getWalletAddress retrieves the address of the selected account from a local file or provider.
+// Watch-only wallets are resolved without a password; other wallets are decrypted as usual.
+func getWalletAddress(filePath, provider, name string, selector accountSelector) (string, error) {
+	var walletData []byte
+	var err error
+	if filePath != "" {
+		walletData, err = getWalletDataFromLocalFile(filePath)
+	} else {
+		walletData, err = getWalletData(provider, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error loading wallet: %v", err)
+	}
+
+	var wallet WalletFile
+	if err := json.Unmarshal(walletData, &wallet); err != nil {
+		return "", fmt.Errorf("error parsing wallet file: %v", err)
+	}
+	if isWatchOnlyWallet(wallet) {
+		address, _, err := getWatchOnlyAddress(wallet, selector)
+		return address, err
+	}
+
+	_, address, err := processWalletData(walletData, selector)
+	return address, err
 }
 
 // getWalletDataFromLocalFile retrieves wallet data from a local file
@@ -191,3 +494,111 @@ func getWalletDataFromCloudProvider(provider string, name string) ([]byte, error
 	cloudPath := filepath.Join(util.GetWalletDir(), name+".json")
 	return util.Get(provider, cloudPath)
 }
+
+// isCloudProvider reports whether location names a cloud provider rather than a local path
+func isCloudProvider(location string) bool {
+	for _, p := range util.CLOUD_PROVIDERS {
+		if location == p {
+			return true
+		}
+	}
+	return false
+}
+
+// getWalletData retrieves wallet data from a cloud provider (by name) or a local file
+func getWalletData(location string, name string) ([]byte, error) {
+	if isCloudProvider(location) {
+		if name == "" {
+			return nil, fmt.Errorf("--name parameter is required when using cloud storage")
+		}
+		return getWalletDataFromCloudProvider(location, name)
+	}
+	return getWalletDataFromLocalFile(location)
+}
+
+// putWalletData writes wallet data to a cloud provider (by name) or a local file
+func putWalletData(location string, name string, data []byte, withForce bool) (string, error) {
+	if isCloudProvider(location) {
+		cloudPath := filepath.Join(util.GetWalletDir(), name+".json")
+		return util.Put(location, data, cloudPath, withForce)
+	}
+	return util.Put(location, data, location, withForce)
+}
+
+// saveWalletToOutputs saves wallet JSON to the locations selected with --output/--path/--name
+// the same way the create command does, and returns the commands that can be used to test it
+func saveWalletToOutputs(outputLocations, fsPath, walletName string, walletJSON []byte, force bool) ([]string, error) {
+	// 处理fs模式
+	if outputLocations == "fs" {
+		if fsPath == "" {
+			return nil, fmt.Errorf("--path parameter is required when using --output fs")
+		}
+	} else if walletName == "" {
+		return nil, fmt.Errorf("--name parameter is required")
+	}
+
+	// 解析输出位置
+	var localPaths []string
+	var cloudProviders []string
+	if outputLocations == "fs" {
+		localPaths = append(localPaths, fsPath)
+	} else {
+		for _, output := range strings.Split(outputLocations, ",") {
+			output = strings.TrimSpace(output)
+			if isCloudProvider(output) {
+				cloudProviders = append(cloudProviders, output)
+			} else {
+				localPaths = append(localPaths, output)
+			}
+		}
+	}
+
+	// 计算本地文件的完整路径
+	fullPaths := make([]string, len(localPaths))
+	for i, path := range localPaths {
+		fullPaths[i] = path
+		if outputLocations != "fs" && !strings.HasSuffix(path, ".json") {
+			fullPaths[i] = filepath.Join(path, walletName+".json")
+		}
+	}
+
+	// 检查是否已存在同名文件
+	if !force {
+		for _, fullPath := range fullPaths {
+			if _, err := os.Stat(fullPath); err == nil {
+				return nil, fmt.Errorf("wallet file already exists at %s. Use -f or --force to overwrite", fullPath)
+			}
+		}
+	}
+
+	var verifyCommands []string
+
+	// 保存到本地文件系统
+	for i, path := range localPaths {
+		result, err := util.Put(path, walletJSON, fullPaths[i], force)
+		if err != nil {
+			fmt.Printf("Error saving wallet to %s: %v\n", fullPaths[i], err)
+			continue
+		}
+		fmt.Println(result)
+		verifyCommands = append(verifyCommands, fmt.Sprintf("eth-cli get -i %s", fullPaths[i]))
+	}
+
+	// 保存到云存储
+	for _, provider := range cloudProviders {
+		cloudPath := filepath.Join(util.GetWalletDir(), walletName+".json")
+		result, err := util.Put(provider, walletJSON, cloudPath, force)
+		if err != nil {
+			fmt.Printf("Error saving wallet to %s: %v\n", provider, err)
+			continue
+		}
+		fmt.Println(result)
+		verifyCommands = append(verifyCommands, fmt.Sprintf("eth-cli get -i %s -n %s", provider, walletName))
+	}
+
+	if len(verifyCommands) == 0 {
+		return nil, fmt.Errorf("wallet was not saved to any location")
+	}
+
+	return verifyCommands, nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import "testing"
+
+func TestParseIndexRange(t *testing.T) {
+	testCases := []struct {
+		input     string
+		start     uint32
+		end       uint32
+		expectErr bool
+	}{
+		{"0-49", 0, 49, false},
+		{"5-5", 5, 5, false},
+		{" 10 - 20 ", 10, 20, false},
+		{"49-0", 0, 0, true}, // End before start
+		{"10", 0, 0, true},   // Missing end
+		{"a-b", 0, 0, true},  // Not numbers
+		{"-1-5", 0, 0, true}, // Negative start
+	}
+
+	for _, tc := range testCases {
+		start, end, err := parseIndexRange(tc.input)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("parseIndexRange(%q): expected error, got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseIndexRange(%q): unexpected error: %v", tc.input, err)
+			continue
+		}
+		if start != tc.start || end != tc.end {
+			t.Errorf("parseIndexRange(%q): expected %d-%d, got %d-%d", tc.input, tc.start, tc.end, start, end)
+		}
+	}
+}
+
+func TestResolveDerivationPath(t *testing.T) {
+	wallet := WalletFile{
+		HDPath:         "m/44'/60'/0'/0",
+		DerivationPath: "m/44'/60'/0'/0/0",
+		Accounts:       map[string]uint32{"treasury": 7},
+	}
+
+	testCases := []struct {
+		selector  accountSelector
+		expected  string
+		expectErr bool
+	}{
+		{accountSelector{}, "m/44'/60'/0'/0/0", false},
+		{accountSelector{index: 3, hasIndex: true}, "m/44'/60'/0'/0/3", false},
+		{accountSelector{account: "treasury"}, "m/44'/60'/0'/0/7", false},
+		{accountSelector{account: "missing"}, "", true},
+	}
+
+	for _, tc := range testCases {
+		path, err := resolveDerivationPath(wallet, tc.selector)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("resolveDerivationPath(%+v): expected error, got none", tc.selector)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveDerivationPath(%+v): unexpected error: %v", tc.selector, err)
+			continue
+		}
+		if path != tc.expected {
+			t.Errorf("resolveDerivationPath(%+v): expected %s, got %s", tc.selector, tc.expected, path)
+		}
+	}
+
+	// Wallets without an HD path fall back to the default one
+	if path := accountPath(WalletFile{}, 2); path != "m/44'/60'/0'/0/2" {
+		t.Errorf("accountPath with empty HD path: expected m/44'/60'/0'/0/2, got %s", path)
+	}
+}
@@ -66,6 +66,7 @@ func ConfigCmd() *cobra.Command {
 	cmd.AddCommand(configSetCmd())
 	cmd.AddCommand(configDeleteCmd())
 	cmd.AddCommand(configListCmd())
+	cmd.AddCommand(configNetworkCmd())
 
 	return cmd
 }
@@ -122,19 +123,7 @@ func configDeleteCmd() *cobra.Command {
 				return
 			}
 
-			// 删除键
-			allSettings := viper.AllSettings()
-			deleteNestedKey(allSettings, strings.Split(key, "."))
-
-			// 清除当前配置并重新设置
-			viper.Reset()
-			viper.SetConfigFile(configFile)
-
-			for k, v := range allSettings {
-				viper.Set(k, v)
-			}
-
-			if err := viper.WriteConfig(); err != nil {
+			if err := deleteConfigKey(key); err != nil {
 				fmt.Println("Error writing config:", err)
 				return
 			}
@@ -161,6 +150,23 @@ func configListCmd() *cobra.Command {
 	}
 }
 
+// 删除配置键并写回配置文件
+func deleteConfigKey(key string) error {
+	allSettings := viper.AllSettings()
+	deleteNestedKey(allSettings, strings.Split(key, "."))
+
+	// 清除当前配置并重新设置
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	viper.SetConfigType("json")
+
+	for k, v := range allSettings {
+		viper.Set(k, v)
+	}
+
+	return viper.WriteConfig()
+}
+
 // 打印配置设置
 func printSettings(settings map[string]interface{}, prefix string) {
 	for k, v := range settings {
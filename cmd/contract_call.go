@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// CallCmd creates the command that calls a contract function without sending a transaction
+func CallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call [args...]",
+		Short: "Call a contract function (read-only eth_call) and decode its return values",
+		Long: `Call a contract function with eth_call and decode its return values. Nothing is signed or sent.
+
+--function takes a signature such as "balanceOf(address)(uint256)", where the optional
+second list gives the return types, or a function name (or signature) of the --abi file.
+The ABI file may be a plain ABI array or a Foundry/Hardhat artifact.
+
+Arguments follow the flags in order. Integers are decimal or 0x-prefixed hex, bytes are
+0x-prefixed hex, and arrays and tuples are written as [a,b,...].
+
+Examples:
+  eth-cli call --contract 0xToken --function "balanceOf(address)(uint256)" 0xOwner
+  eth-cli call --contract 0xPool --abi ./Pool.json --function getReserves
+  eth-cli call --contract 0xVault --function "previewDeposit(uint256)(uint256)" 1000000 --block 19000000`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runCall,
+	}
+
+	cmd.Flags().String("contract", "", "Contract address")
+	cmd.Flags().String("function", "", "Function signature, or function name of the --abi file")
+	cmd.Flags().String("abi", "", "ABI JSON file (ABI array or Foundry/Hardhat artifact)")
+	cmd.Flags().String("from", "", "Address used as msg.sender")
+	cmd.Flags().String("value", "0", "ETH value sent with the call (e.g., 0.1eth)")
+	cmd.Flags().String("block", "latest", "Block number to call at, or latest/pending")
+
+	cmd.MarkFlagRequired("contract")
+	cmd.MarkFlagRequired("function")
+
+	return cmd
+}
+
+// SendCmd creates the command that sends a transaction calling a contract function
+func SendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send [args...]",
+		Short: "Send a transaction calling a contract function",
+		Long: `Send a transaction calling a contract function, with the arguments ABI-encoded.
+
+--function takes a signature such as "deposit(uint256,address)", or a function name (or
+signature) of the --abi file. The ABI file may be a plain ABI array or a Foundry/Hardhat artifact.
+
+Arguments follow the flags in order. Integers are decimal or 0x-prefixed hex, bytes are
+0x-prefixed hex, and arrays and tuples are written as [a,b,...].
+
+Examples:
+  eth-cli send -p google -n myWallet --contract 0xVault --function "deposit(uint256,address)" 1000000 0xReceiver
+  eth-cli send -f ./wallet.json --contract 0xWETH --abi ./WETH.json --function deposit --value 0.5eth --sync
+  eth-cli send -f ./wallet.json --contract 0xRouter --function "submit((address,uint256)[])" "[(0xA,1),(0xB,2)]" --dry-run`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runSend,
+	}
+
+	cmd.Flags().String("contract", "", "Contract address")
+	cmd.Flags().String("function", "", "Function signature, or function name of the --abi file")
+	cmd.Flags().String("abi", "", "ABI JSON file (ABI array or Foundry/Hardhat artifact)")
+	cmd.Flags().String("value", "0", "ETH value sent with the transaction (e.g., 0.1eth)")
+	addContractTxFlags(cmd)
+
+	cmd.MarkFlagRequired("contract")
+	cmd.MarkFlagRequired("function")
+
+	return cmd
+}
+
+func runCall(cmd *cobra.Command, args []string) error {
+	contract, _ := cmd.Flags().GetString("contract")
+	function, _ := cmd.Flags().GetString("function")
+	abiPath, _ := cmd.Flags().GetString("abi")
+	from, _ := cmd.Flags().GetString("from")
+	valueStr, _ := cmd.Flags().GetString("value")
+	block, _ := cmd.Flags().GetString("block")
+
+	if !common.IsHexAddress(contract) {
+		return fmt.Errorf("invalid contract address: %s", contract)
+	}
+	contractAddr := common.HexToAddress(contract)
+
+	method, err := resolveContractMethod(function, abiPath)
+	if err != nil {
+		return err
+	}
+	data, err := util.EncodeArguments(method.ID, method.Inputs, args)
+	if err != nil {
+		return err
+	}
+
+	value, err := parseEthAmount(valueStr)
+	if err != nil {
+		return fmt.Errorf("invalid value: %v", err)
+	}
+
+	msg := ethereum.CallMsg{To: &contractAddr, Value: value, Data: data}
+	if from != "" {
+		if !common.IsHexAddress(from) {
+			return fmt.Errorf("invalid from address: %s", from)
+		}
+		msg.From = common.HexToAddress(from)
+	}
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+
+	output, err := callContractAt(client, msg, block)
+	if err != nil {
+		if reason := util.DecodeRevertReason(err); reason != "" {
+			return fmt.Errorf("call reverted: %s", reason)
+		}
+		return fmt.Errorf("call failed: %v", err)
+	}
+
+	fmt.Printf("Function: %s\n", method.Sig)
+	printCallOutput(method, output)
+	return nil
+}
+
+func runSend(cmd *cobra.Command, args []string) error {
+	contract, _ := cmd.Flags().GetString("contract")
+	function, _ := cmd.Flags().GetString("function")
+	abiPath, _ := cmd.Flags().GetString("abi")
+	valueStr, _ := cmd.Flags().GetString("value")
+
+	if !common.IsHexAddress(contract) {
+		return fmt.Errorf("invalid contract address: %s", contract)
+	}
+	contractAddr := common.HexToAddress(contract)
+
+	method, err := resolveContractMethod(function, abiPath)
+	if err != nil {
+		return err
+	}
+	data, err := util.EncodeArguments(method.ID, method.Inputs, args)
+	if err != nil {
+		return err
+	}
+
+	value, err := parseEthAmount(valueStr)
+	if err != nil {
+		return fmt.Errorf("invalid value: %v", err)
+	}
+
+	// The ABI tells whether the function accepts ETH and whether it changes state
+	if abiPath != "" {
+		if value.Sign() > 0 && !method.IsPayable() {
+			return fmt.Errorf("%s is not payable, it cannot receive %s %s", method.Sig, formatEther(value), nativeSymbol())
+		}
+		if method.IsConstant() {
+			fmt.Printf("\033[33mWARNING: %s is a %s function, use the call command to read it without a transaction.\033[0m\n", method.Sig, method.StateMutability)
+		}
+	}
+
+	_, err = runContractTx(cmd, func(txCtx contractTxContext) (contractTxRequest, error) {
+		req := contractTxRequest{
+			To:    &contractAddr,
+			Value: value,
+			Data:  data,
+		}
+		req.Details = append(req.Details, [2]string{"Function", method.Sig})
+		req.Details = append(req.Details, describeCallArguments(method, data)...)
+		return req, nil
+	})
+	return err
+}
+
+// resolveContractMethod returns the method given by --function, looked up in the --abi file if any
+func resolveContractMethod(function, abiPath string) (abi.Method, error) {
+	if abiPath == "" {
+		return util.ParseFunctionSignature(function)
+	}
+
+	contractABI, err := util.LoadABI(abiPath)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	return util.FindABIMethod(contractABI, function)
+}
+
+// callContractAt runs eth_call at a block number, "latest" or "pending"
+func callContractAt(client *ethclient.Client, msg ethereum.CallMsg, block string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(block)) {
+	case "", "latest":
+		return client.CallContract(context.Background(), msg, nil)
+	case "pending":
+		return client.PendingCallContract(context.Background(), msg)
+	}
+
+	blockNumber, ok := new(big.Int).SetString(strings.TrimSpace(block), 0)
+	if !ok || blockNumber.Sign() < 0 {
+		return nil, fmt.Errorf("invalid block: %s (expected a block number, latest or pending)", block)
+	}
+	return client.CallContract(context.Background(), msg, blockNumber)
+}
+
+// printCallOutput prints the decoded return values of a call, or the raw output if the
+// return types are unknown or do not match
+func printCallOutput(method abi.Method, output []byte) {
+	if len(method.Outputs) == 0 {
+		if len(output) > 0 {
+			fmt.Printf("Result: %s\n", hexutil.Encode(output))
+		} else {
+			fmt.Println("Result: (empty)")
+		}
+		return
+	}
+
+	values, err := method.Outputs.Unpack(output)
+	if err != nil {
+		fmt.Printf("\033[33mWARNING: failed to decode the return values: %v\033[0m\n", err)
+		fmt.Printf("Result: %s\n", hexutil.Encode(output))
+		return
+	}
+
+	fmt.Println("Result:")
+	for i, value := range values {
+		fmt.Printf("  %s: %s\n", argumentLabel(method.Outputs[i], i), util.FormatABIValue(value))
+	}
+}
+
+// describeCallArguments decodes the encoded arguments of data back into detail lines,
+// so the confirmation shows exactly what is sent
+func describeCallArguments(method abi.Method, data []byte) [][2]string {
+	values, err := method.Inputs.Unpack(data[len(method.ID):])
+	if err != nil {
+		return [][2]string{{"Arguments", fmt.Sprintf("failed to decode: %v", err)}}
+	}
+
+	details := make([][2]string, len(values))
+	for i, value := range values {
+		details[i] = [2]string{argumentLabel(method.Inputs[i], i), util.FormatABIValue(value)}
+	}
+	return details
+}
+
+// argumentLabel names an argument by its ABI name (if any) and type
+func argumentLabel(arg abi.Argument, index int) string {
+	if arg.Name != "" && arg.Name != fmt.Sprintf("arg%d", index) {
+		return fmt.Sprintf("%s (%s)", arg.Name, arg.Type.String())
+	}
+	return fmt.Sprintf("[%d] %s", index, arg.Type.String())
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+)
+
+func TestResolveContractMethod(t *testing.T) {
+	method, err := resolveContractMethod("deposit(uint256,address)", "")
+	if err != nil || method.Sig != "deposit(uint256,address)" {
+		t.Errorf("Unexpected method from signature: %s, %v", method.Sig, err)
+	}
+
+	abiPath := filepath.Join(t.TempDir(), "Vault.json")
+	abiJSON := `[{"type":"function","name":"deposit","inputs":[{"name":"assets","type":"uint256"},{"name":"receiver","type":"address"}],"outputs":[{"name":"shares","type":"uint256"}],"stateMutability":"nonpayable"}]`
+	if err := os.WriteFile(abiPath, []byte(abiJSON), 0600); err != nil {
+		t.Fatalf("Failed to write ABI file: %v", err)
+	}
+	method, err = resolveContractMethod("deposit", abiPath)
+	if err != nil || method.Sig != "deposit(uint256,address)" || method.IsPayable() {
+		t.Errorf("Unexpected method from ABI: %s, %v", method.Sig, err)
+	}
+
+	if _, err := resolveContractMethod("withdraw", abiPath); err == nil {
+		t.Error("Expected error for a function missing from the ABI, but got none")
+	}
+}
+
+func TestDescribeCallArguments(t *testing.T) {
+	method, err := util.ParseFunctionSignature("deposit(uint256,address)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := util.EncodeArguments(method.ID, method.Inputs, []string{"0x10", "0x000000000000000000000000000000000000dEaD"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	details := describeCallArguments(method, data)
+	if len(details) != 2 {
+		t.Fatalf("Expected 2 details, got %d", len(details))
+	}
+	if details[0] != [2]string{"[0] uint256", "16"} || details[1] != [2]string{"[1] address", "0x000000000000000000000000000000000000dEaD"} {
+		t.Errorf("Unexpected details: %v", details)
+	}
+}
+
+func TestCallContractAtInvalidBlock(t *testing.T) {
+	for _, block := range []string{"latest-1", "-5", "abc"} {
+		if _, err := callContractAt(nil, ethereum.CallMsg{}, block); err == nil || !strings.Contains(err.Error(), "invalid block") {
+			t.Errorf("Expected invalid block error for %q, got %v", block, err)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// DefaultGasLimitContractCall is the gas limit of contract calls in dry-run mode
+const DefaultGasLimitContractCall = 200000
+
+// DefaultReceiptWaitTimeout is how long commands wait for a sent transaction to be mined
+const DefaultReceiptWaitTimeout = 10 * time.Minute
+
+// droppedTxGracePeriod is how long a sent transaction may be unknown to the node before it is
+// considered dropped; load-balanced RPCs do not see a new transaction on every backend at once
+const droppedTxGracePeriod = time.Minute
+
+// contractTxContext is what a command knows about the transaction before building it
+type contractTxContext struct {
+	Client  *ethclient.Client // nil in dry-run mode
+	From    common.Address
+	Nonce   uint64
+	ChainID *big.Int
+}
+
+// contractTxRequest is a transaction built by a command and sent through runContractTx
+type contractTxRequest struct {
+	To              *common.Address // nil for contract creation
+	Value           *big.Int
+	Data            []byte
+	DefaultGasLimit uint64      // gas limit in dry-run mode when --gas-limit is not set
+	Details         [][2]string // command-specific lines of the transaction details
+	Wait            bool        // wait for the receipt even without --sync
+}
+
+// contractTxResult is the outcome of runContractTx
+type contractTxResult struct {
+	TxHash  string         // empty if the transaction was not broadcast
+	Receipt *types.Receipt // set if the command waited for confirmation
+	Client  *ethclient.Client
+}
+
+// addContractTxFlags adds the wallet, fee and broadcast flags of commands that use runContractTx
+func addContractTxFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().Bool("dry-run", false, "Only encode the transaction, do not broadcast")
+	cmd.Flags().Bool("estimate-only", false, "Only display gas estimation")
+	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the transaction")
+	addFeeFlags(cmd)
+	cmd.Flags().Uint64("gas-limit", 0, "Gas limit")
+	cmd.Flags().Uint64("chain-id", 1, "Chain ID to use in dry-run mode (default: 1)")
+	cmd.Flags().Uint64("nonce", 0, "Nonce to use in dry-run mode (required when chain-id is specified)")
+	cmd.Flags().Bool("sync", false, "Wait for transaction confirmation")
+	cmd.Flags().Duration("wait-timeout", DefaultReceiptWaitTimeout, "How long to wait for confirmation before giving up")
+}
+
+// runContractTx loads the wallet, builds the transaction with build and then estimates gas,
+// shows the details, signs, asks for confirmation and broadcasts it, like the transfer commands
+func runContractTx(cmd *cobra.Command, build func(txCtx contractTxContext) (contractTxRequest, error)) (contractTxResult, error) {
+	var result contractTxResult
+
+	// Parse flags
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	estimateOnly, _ := cmd.Flags().GetBool("estimate-only")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
+	sync, _ := cmd.Flags().GetBool("sync")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return result, fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return result, fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	// Determine which account to derive
+	selector, err := getAccountSelector(cmd)
+	if err != nil {
+		return result, err
+	}
+
+	// Get RPC URL from config; dry runs do not need a reachable RPC
+	var rpcURL string
+	if dryRun {
+		rpcURL, err = initDryRunTxConfig()
+	} else {
+		rpcURL, err = initTxConfig()
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// Print provider or file info
+	if provider != "" {
+		fmt.Printf("Using provider: %s\n", provider)
+	} else {
+		fmt.Printf("Using wallet file: %s\n", filePath)
+	}
+
+	// Connect to Ethereum client if needed
+	var client *ethclient.Client
+	if !dryRun {
+		client, err = ethclient.Dial(rpcURL)
+		if err != nil {
+			return result, fmt.Errorf("failed to connect to Ethereum node: %v", err)
+		}
+		fmt.Printf("Using RPC: %s\n", rpcURL)
+	}
+	result.Client = client
+
+	// Get private key from provider or file
+	var privateKey string
+	var fromAddress string
+	if dryRun {
+		// Dry runs only need the address, so watch-only wallets work without a password
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to get private key: %v", err)
+	}
+	fromAddr := common.HexToAddress(fromAddress)
+
+	// Get chain ID and nonce
+	var chainID *big.Int
+	var nonce uint64
+	if !dryRun {
+		chainID, err = client.ChainID(context.Background())
+		if err != nil {
+			return result, fmt.Errorf("failed to get chain ID: %v", err)
+		}
+		nonce, err = util.GetNonce(client, fromAddr)
+		if err != nil {
+			return result, fmt.Errorf("failed to get nonce: %v", err)
+		}
+	} else {
+		chainIDValue, err := dryRunChainID(cmd)
+		if err != nil {
+			return result, err
+		}
+		chainID = new(big.Int).SetUint64(chainIDValue)
+		nonceValue, _ := cmd.Flags().GetUint64("nonce")
+
+		if chainIDValue != 1 && nonceValue == 0 {
+			return result, fmt.Errorf("--nonce is required when --chain-id is specified")
+		}
+
+		nonce = nonceValue
+		fmt.Printf("\033[33mWARNING: Using chain ID %d and nonce %d for dry run.\033[0m\n", chainIDValue, nonce)
+	}
+
+	// Build the command-specific part of the transaction
+	req, err := build(contractTxContext{Client: client, From: fromAddr, Nonce: nonce, ChainID: chainID})
+	if err != nil {
+		return result, err
+	}
+	if req.Value == nil {
+		req.Value = big.NewInt(0)
+	}
+
+	// Get transaction type and fees
+	txParams, err := resolveTxParams(cmd, client, dryRun)
+	if err != nil {
+		return result, err
+	}
+
+	// Get gas limit
+	if gasLimit == 0 && !dryRun {
+		estimated, err := util.EstimateGas(client, fromAddr, req.To, req.Value, req.Data)
+		if err != nil {
+			return result, fmt.Errorf("failed to estimate gas: %v", err)
+		}
+		gasLimit = uint64(float64(estimated) * GasEstimationBuffer)
+	} else if gasLimit == 0 {
+		gasLimit = req.DefaultGasLimit
+		if gasLimit == 0 {
+			gasLimit = DefaultGasLimitContractCall
+		}
+	}
+
+	// Create raw transaction
+	rawTx, err := util.CreateRawTx(req.To, req.Value, req.Data, nonce, txParams, gasLimit, chainID)
+	if err != nil {
+		return result, fmt.Errorf("failed to create transaction: %v", err)
+	}
+
+	// If gas only, just display and exit
+	if estimateOnly {
+		printContractTxDetails(fromAddress, req, gasLimit, txParams, nonce, chainID)
+		return result, nil
+	}
+
+	// If dry run, just display the raw transaction and exit
+	if dryRun {
+		printContractTxDetails(fromAddress, req, gasLimit, txParams, nonce, chainID)
+		fmt.Printf("\n\033[1;36mRaw Transaction:\033[0m %s\n", rawTx)
+		return result, nil
+	}
+
+	// Simulate the transaction before signing it
+	sim, err := simulateTx(client, fromAddr, req.To, req.Value, req.Data, gasLimit)
+	if err != nil {
+		return result, err
+	}
+
+	// Check the chain ID it is signed for against the selected network
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		return result, err
+	}
+
+	// Sign the transaction
+	signedTx, err := util.SignTransactionWithChainID(rawTx, privateKey, chainID)
+	if err != nil {
+		return result, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+
+	// Display transaction details for confirmation
+	if !autoConfirm {
+		printContractTxDetails(fromAddress, req, gasLimit, txParams, nonce, chainID)
+		printSimulation(sim)
+
+		// Ask for confirmation
+		fmt.Print("Confirm transaction? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Transaction cancelled.")
+			return result, nil
+		}
+	}
+
+	// Broadcast the transaction
+	txHash, err := util.BroadcastTransaction(signedTx, rpcURL)
+	if err != nil {
+		return result, fmt.Errorf("failed to broadcast transaction: %v", err)
+	}
+	result.TxHash = txHash
+
+	fmt.Printf("Transaction submitted: %s\n", txHash)
+	printExplorerLink(txHash)
+
+	// Wait for confirmation if requested
+	if sync || req.Wait {
+		fmt.Println("Waiting for transaction confirmation...")
+		receipt, err := waitForReceipt(client, txHash, waitTimeout)
+		if err != nil {
+			return result, err
+		}
+		result.Receipt = receipt
+		printReceiptSummary(receipt)
+	}
+
+	return result, nil
+}
+
+// printContractTxDetails prints the details of a transaction built by runContractTx
+func printContractTxDetails(from string, req contractTxRequest, gasLimit uint64, txParams util.TxParams, nonce uint64, chainID *big.Int) {
+	fmt.Println("Transaction Details:")
+	fmt.Printf("From: %s\n", from)
+	if req.To != nil {
+		fmt.Printf("To: %s\n", req.To.Hex())
+	} else {
+		fmt.Println("To: (contract creation)")
+	}
+	fmt.Printf("Value: %s %s\n", formatEther(req.Value), nativeSymbol())
+	for _, detail := range req.Details {
+		fmt.Printf("%s: %s\n", detail[0], detail[1])
+	}
+	fmt.Printf("Data: %d bytes\n", len(req.Data))
+	fmt.Printf("Gas Limit: %d\n", gasLimit)
+	printFeeDetails(txParams, gasLimit)
+	fmt.Printf("Nonce: %d\n", nonce)
+	fmt.Printf("Chain ID: %d\n", chainID)
+}
+
+// waitForReceipt polls for the receipt of a transaction until it is mined. It gives up when
+// timeout expires, when the nonce of the transaction is used by another one (replaced), or
+// when the node has not known the transaction for droppedTxGracePeriod (dropped).
+func waitForReceipt(client *ethclient.Client, txHash string, timeout time.Duration) (*types.Receipt, error) {
+	hash := common.HexToHash(txHash)
+	deadline := time.Now().Add(timeout)
+	lastSeen := time.Now()
+	var tx *types.Transaction
+	for {
+		receipt, err := client.TransactionReceipt(context.Background(), hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+		}
+
+		pendingTx, _, err := client.TransactionByHash(context.Background(), hash)
+		switch {
+		case err == nil:
+			tx = pendingTx
+			lastSeen = time.Now()
+		case !errors.Is(err, ethereum.NotFound):
+			return nil, fmt.Errorf("failed to get transaction %s: %v", txHash, err)
+		case tx != nil:
+			// A replaced transaction leaves the mempool once its nonce is mined
+			replaced, err := nonceUsedByOther(client, hash, tx)
+			if err != nil {
+				return nil, err
+			}
+			if replaced {
+				return nil, fmt.Errorf("nonce %d was used by another transaction, %s was replaced or dropped", tx.Nonce(), txHash)
+			}
+			fallthrough
+		default:
+			if time.Since(lastSeen) > droppedTxGracePeriod {
+				return nil, fmt.Errorf("transaction %s was dropped: the node has not known it for %s, check it with tx-status and send it again", txHash, droppedTxGracePeriod)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for transaction %s, check it later with tx-status", timeout, txHash)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// nonceUsedByOther reports whether the nonce of an unmined transaction was used by another
+// mined transaction of its sender
+func nonceUsedByOther(client *ethclient.Client, hash common.Hash, tx *types.Transaction) (bool, error) {
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction sender: %v", err)
+	}
+	minedNonce, err := client.NonceAt(context.Background(), sender, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if minedNonce <= tx.Nonce() {
+		return false, nil
+	}
+
+	// The transaction itself may have been mined between the two calls
+	receipt, err := batchRowReceipt(client, hash)
+	if err != nil {
+		return false, err
+	}
+	return receipt == nil, nil
+}
+
+// printReceiptSummary prints the status, block and gas used of a mined transaction
+func printReceiptSummary(receipt *types.Receipt) {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Println("Transaction confirmed successfully!")
+	} else {
+		fmt.Println("Transaction failed!")
+	}
+	fmt.Printf("Block Number: %d\n", receipt.BlockNumber)
+	fmt.Printf("Gas Used: %d\n", receipt.GasUsed)
+}
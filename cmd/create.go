@@ -37,30 +37,26 @@ Examples:
   eth-cli create --output fs --path /tmp/wallet.json
   eth-cli create --output google,dropbox --name myWallet
   eth-cli create --output /home/user/wallets,google --name myWallet`,
-		Run: func(cmd *cobra.Command, args []string) {
-			// 初始化配置
-			initConfig()
-
-			// 检查必要参数
+		// 检查必要参数，在创建钱包之前返回错误
+		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if outputLocations == "" {
-				fmt.Println("Error: --output parameter is required")
-				cmd.Usage()
-				os.Exit(1)
+				return fmt.Errorf("--output parameter is required")
 			}
 
 			// 处理新的fs模式
 			if outputLocations == "fs" {
 				if fsPath == "" {
-					fmt.Println("Error: --path parameter is required when using --output fs")
-					cmd.Usage()
-					os.Exit(1)
+					return fmt.Errorf("--path parameter is required when using --output fs")
 				}
 			} else if walletName == "" {
 				// 对于非fs模式，仍然需要name参数
-				fmt.Println("Error: --name parameter is required")
-				cmd.Usage()
-				os.Exit(1)
+				return fmt.Errorf("--name parameter is required")
 			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
 
 			// 解析输出位置
 			outputs := strings.Split(outputLocations, ",")
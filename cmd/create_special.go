@@ -164,7 +164,7 @@ Warning: Generating vanity addresses can take a very long time depending on the
 			fmt.Printf("\n\033[1;33mSearching for vanity address matching pattern: %s\033[0m\n", pattern)
 			fmt.Println("This may take a while depending on the complexity of your pattern...")
 			fmt.Printf("\033[1;31mNote: Using %s passphrase for vanity address generation.\033[0m\n", map[bool]string{true: "set", false: "empty"}[passphrase != ""])
-			fmt.Println("Press Ctrl+C to cancel at any time.\n")
+			fmt.Print("Press Ctrl+C to cancel at any time.\n\n")
 
 			var mnemonic string
 			var addressHex string
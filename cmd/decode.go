@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+// DecodeCmd creates the command that decodes a transaction or calldata without network access
+func DecodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode <hex|file>",
+		Short: "Decode a raw or signed transaction, or calldata",
+		Long: `Decode a raw (unsigned) or signed transaction without network access: its type, chain ID,
+nonce, fees, recipient, value and, for signed transactions, the recovered sender.
+
+The calldata is decoded against the functions of the --abi files, the ERC20/ERC721/ERC1155
+functions and a bundled database of common function signatures, in that order. A signature
+only matches if the arguments encode back to exactly the same calldata.
+
+The argument is hex, or a file containing it. With --calldata it is decoded as calldata
+instead of a transaction.
+
+Examples:
+  eth-cli decode 0x02f8b1...
+  eth-cli decode ./raw_tx.txt --abi ./Vault.json
+  eth-cli decode --calldata 0xa9059cbb000000000000000000000000...`,
+		Args: cobra.ExactArgs(1),
+		RunE: runDecode,
+	}
+
+	cmd.Flags().Bool("calldata", false, "Decode the argument as calldata instead of a transaction")
+	cmd.Flags().StringArray("abi", nil, "ABI or Foundry/Hardhat artifact JSON file to decode calldata with (repeatable)")
+
+	return cmd
+}
+
+func runDecode(cmd *cobra.Command, args []string) error {
+	calldataOnly, _ := cmd.Flags().GetBool("calldata")
+	abiPaths, _ := cmd.Flags().GetStringArray("abi")
+
+	abis, err := loadABIs(abiPaths)
+	if err != nil {
+		return err
+	}
+	input, err := readHexOrFile(args[0], "the argument")
+	if err != nil {
+		return err
+	}
+
+	if calldataOnly {
+		data, _ := hexutil.Decode(input)
+		printCalldata(data, abis)
+		return nil
+	}
+
+	tx, err := util.DecodeSignedTransaction(input)
+	if err != nil {
+		return fmt.Errorf("%v (use --calldata to decode calldata)", err)
+	}
+	printTxPreview(tx, nil, abis)
+	return nil
+}
+
+// loadABIs reads the --abi files
+func loadABIs(paths []string) ([]abi.ABI, error) {
+	var abis []abi.ABI
+	for _, path := range paths {
+		contractABI, err := util.LoadABI(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		abis = append(abis, contractABI)
+	}
+	return abis, nil
+}
+
+// printTxPreview prints what a raw or signed transaction does. chainID, from --chain-id or
+// --network, is used for legacy transactions that do not carry one.
+func printTxPreview(tx *types.Transaction, chainID *big.Int, abis []abi.ABI) {
+	fmt.Println("\033[1;36mTransaction Preview:\033[0m")
+	if txChainID := util.TransactionChainID(tx); txChainID != nil {
+		fmt.Printf("Chain ID: %s\n", txChainID)
+	} else if chainID != nil {
+		fmt.Printf("Chain ID: %s (from --chain-id or --network)\n", chainID)
+	} else {
+		fmt.Println("Chain ID: \033[33mnone (valid on every chain)\033[0m")
+	}
+	fmt.Printf("Nonce: %d\n", tx.Nonce())
+
+	if util.IsSignedTransaction(tx) {
+		sender, err := util.TransactionSender(tx)
+		if err != nil {
+			fmt.Printf("From: \033[1;31minvalid signature (%v)\033[0m\n", err)
+		} else {
+			fmt.Printf("From: %s (recovered from the signature)\n", sender.Hex())
+		}
+	} else {
+		fmt.Println("From: (unsigned)")
+	}
+
+	if tx.To() != nil {
+		fmt.Printf("To: %s\n", tx.To().Hex())
+	} else {
+		fmt.Println("To: (contract creation)")
+	}
+	fmt.Printf("Value: %s %s\n", formatEther(tx.Value()), nativeSymbol())
+	fmt.Printf("Gas Limit: %d\n", tx.Gas())
+	printFeeDetails(txParamsFromTransaction(tx), tx.Gas())
+
+	if tx.To() == nil {
+		fmt.Printf("Data: %d bytes of contract creation code\n", len(tx.Data()))
+		return
+	}
+	printCalldata(tx.Data(), abis)
+}
+
+// printCalldata prints the function and arguments of calldata, or its selector if unknown
+func printCalldata(data []byte, abis []abi.ABI) {
+	if len(data) == 0 {
+		fmt.Println("Data: none")
+		return
+	}
+	fmt.Printf("Data: %d bytes\n", len(data))
+
+	decoded := util.DecodeCalldata(data, abis)
+	if decoded == nil {
+		if len(data) >= 4 {
+			fmt.Printf("Function: \033[33munknown (selector %s)\033[0m\n", hexutil.Encode(data[:4]))
+		} else {
+			fmt.Println("Function: \033[33mnone (calldata shorter than a selector)\033[0m")
+		}
+		return
+	}
+
+	fmt.Printf("Function: %s (%s)\n", decoded.Signature, describeCallSource(decoded))
+	for _, param := range decoded.Params {
+		fmt.Printf("  %s (%s): %s\n", param.Name, param.Type, param.Value)
+	}
+	if len(decoded.Alternatives) > 0 {
+		fmt.Printf("\033[33mWARNING: the calldata also decodes as %s\033[0m\n", strings.Join(decoded.Alternatives, ", "))
+	}
+}
+
+// describeCallSource says where the signature of a decoded call came from
+func describeCallSource(decoded *util.DecodedCall) string {
+	switch decoded.Source {
+	case util.CallSourceABI:
+		return "from --abi"
+	case util.CallSourceStandard:
+		return decoded.Standard
+	default:
+		return "guessed from the 4-byte signature database"
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCommandErrors(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"0xzz"}, "neither 0x-prefixed hex nor a readable file"},
+		{[]string{"0xa9059cbb"}, "use --calldata"},
+		{[]string{"0x01", "--abi", filepath.Join(t.TempDir(), "missing.json")}, "missing.json"},
+	}
+	for _, test := range tests {
+		_, err := executeCommand(t, DecodeCmd(), test.args...)
+		if err == nil {
+			t.Errorf("Expected error for %q, but got none", test.args)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Unexpected error for %q: %v", test.args, err)
+		}
+	}
+}
+
+func TestLoadABIs(t *testing.T) {
+	dir := t.TempDir()
+	abiPath := filepath.Join(dir, "Vault.json")
+	if err := os.WriteFile(abiPath, []byte(`{"abi":[{"name":"deposit","type":"function","inputs":[{"name":"assets","type":"uint256"}]}]}`), 0600); err != nil {
+		t.Fatalf("Failed to write ABI: %v", err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidPath, []byte(`{"bytecode":"0x00"}`), 0600); err != nil {
+		t.Fatalf("Failed to write ABI: %v", err)
+	}
+
+	abis, err := loadABIs([]string{abiPath})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(abis) != 1 || abis[0].Methods["deposit"].Sig != "deposit(uint256)" {
+		t.Errorf("Unexpected ABIs: %+v", abis)
+	}
+
+	if _, err := loadABIs([]string{abiPath, invalidPath}); err == nil || !strings.Contains(err.Error(), "invalid.json") {
+		t.Errorf("Expected an error naming the invalid file, got %v", err)
+	}
+}
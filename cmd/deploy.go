@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+// DefaultGasLimitDeploy is the gas limit of contract deployments in dry-run mode
+const DefaultGasLimitDeploy = 3000000
+
+// DeployCmd creates the contract deployment command
+func DeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy [constructor args...]",
+		Short: "Deploy a contract from bytecode or a Foundry/Hardhat artifact",
+		Long: `Deploy a contract with a contract creation transaction.
+
+The contract is given either as --artifact, a Foundry (out/<File>.sol/<Contract>.json) or
+Hardhat (artifacts/.../<Contract>.json) artifact whose ABI describes the constructor, or as
+--bytecode (hex, or a file holding hex) with the constructor types given by --constructor.
+
+Constructor arguments follow the flags in order, in the same formats as the send command.
+The contract address is predicted from the sender and nonce before signing, and the command
+waits for the receipt and checks that code was deployed at that address.
+
+Examples:
+  eth-cli deploy -p google -n myWallet --artifact out/Token.sol/Token.json "My Token" MTK 1000000
+  eth-cli deploy -f ./wallet.json --bytecode 0x6080... --constructor "(uint256,address)" 42 0xOwner
+  eth-cli deploy -f ./wallet.json --artifact artifacts/contracts/Vault.sol/Vault.json --dry-run`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runDeploy,
+	}
+
+	cmd.Flags().String("artifact", "", "Foundry/Hardhat artifact JSON file")
+	cmd.Flags().String("bytecode", "", "Contract creation bytecode as hex, or a file holding it")
+	cmd.Flags().String("constructor", "", "Constructor argument types for --bytecode, e.g. \"(uint256,address)\"")
+	cmd.Flags().String("value", "0", "ETH sent to a payable constructor (e.g., 0.1eth)")
+	addContractTxFlags(cmd)
+
+	return cmd
+}
+
+func runDeploy(cmd *cobra.Command, args []string) error {
+	artifactPath, _ := cmd.Flags().GetString("artifact")
+	bytecodeInput, _ := cmd.Flags().GetString("bytecode")
+	constructorTypes, _ := cmd.Flags().GetString("constructor")
+	valueStr, _ := cmd.Flags().GetString("value")
+
+	if (artifactPath == "") == (bytecodeInput == "") {
+		return fmt.Errorf("exactly one of --artifact and --bytecode must be specified")
+	}
+	if artifactPath != "" && constructorTypes != "" {
+		return fmt.Errorf("--constructor is only used with --bytecode, the artifact ABI describes the constructor")
+	}
+
+	value, err := parseEthAmount(valueStr)
+	if err != nil {
+		return fmt.Errorf("invalid value: %v", err)
+	}
+
+	bytecode, constructor, err := loadDeployBytecode(artifactPath, bytecodeInput, constructorTypes)
+	if err != nil {
+		return err
+	}
+	if artifactPath != "" && value.Sign() > 0 && !constructor.IsPayable() {
+		return fmt.Errorf("the constructor is not payable, it cannot receive %s %s", formatEther(value), nativeSymbol())
+	}
+
+	data, err := util.EncodeArguments(bytecode, constructor.Inputs, args)
+	if err != nil {
+		return fmt.Errorf("invalid constructor arguments: %v", err)
+	}
+
+	var contractAddress string
+	result, err := runContractTx(cmd, func(txCtx contractTxContext) (contractTxRequest, error) {
+		contractAddress = crypto.CreateAddress(txCtx.From, txCtx.Nonce).Hex()
+
+		req := contractTxRequest{
+			To:              nil,
+			Value:           value,
+			Data:            data,
+			DefaultGasLimit: DefaultGasLimitDeploy,
+			Wait:            true,
+		}
+		req.Details = append(req.Details, [2]string{"Contract Address", contractAddress + " (predicted from sender and nonce)"})
+		req.Details = append(req.Details, [2]string{"Bytecode", fmt.Sprintf("%d bytes", len(bytecode))})
+		if len(constructor.Inputs) > 0 {
+			req.Details = append(req.Details, describeConstructorArguments(constructor, data[len(bytecode):])...)
+		}
+		return req, nil
+	})
+	if err != nil || result.Receipt == nil {
+		return err
+	}
+
+	return checkDeployment(result, contractAddress)
+}
+
+// loadDeployBytecode returns the creation bytecode and the constructor of the contract to deploy
+func loadDeployBytecode(artifactPath, bytecodeInput, constructorTypes string) ([]byte, abi.Method, error) {
+	if artifactPath != "" {
+		artifact, err := util.LoadContractArtifact(artifactPath)
+		if err != nil {
+			return nil, abi.Method{}, err
+		}
+		return artifact.Bytecode, artifact.ABI.Constructor, nil
+	}
+
+	bytecodeHex := bytecodeInput
+	if !strings.HasPrefix(bytecodeInput, "0x") && !strings.HasPrefix(bytecodeInput, "0X") {
+		data, err := os.ReadFile(bytecodeInput)
+		if err != nil {
+			return nil, abi.Method{}, fmt.Errorf("--bytecode is neither 0x-prefixed hex nor a readable file: %v", err)
+		}
+		bytecodeHex = string(data)
+	}
+	bytecode, err := util.ParseBytecode(bytecodeHex)
+	if err != nil {
+		return nil, abi.Method{}, err
+	}
+
+	var constructor abi.Method
+	if constructorTypes != "" {
+		signature := constructorTypes
+		if strings.HasPrefix(strings.TrimSpace(signature), "(") {
+			signature = "constructor" + strings.TrimSpace(signature)
+		}
+		parsed, err := util.ParseFunctionSignature(signature)
+		if err != nil {
+			return nil, abi.Method{}, fmt.Errorf("invalid --constructor: %v", err)
+		}
+		constructor = abi.NewMethod("", "", abi.Constructor, "", false, false, parsed.Inputs, nil)
+	}
+	return bytecode, constructor, nil
+}
+
+// describeConstructorArguments decodes the encoded constructor arguments back into detail lines
+func describeConstructorArguments(constructor abi.Method, encoded []byte) [][2]string {
+	values, err := constructor.Inputs.Unpack(encoded)
+	if err != nil {
+		return [][2]string{{"Constructor Arguments", fmt.Sprintf("failed to decode: %v", err)}}
+	}
+
+	details := make([][2]string, len(values))
+	for i, value := range values {
+		details[i] = [2]string{"Constructor " + argumentLabel(constructor.Inputs[i], i), util.FormatABIValue(value)}
+	}
+	return details
+}
+
+// checkDeployment checks that the deployment succeeded at the predicted address and left code there
+func checkDeployment(result contractTxResult, contractAddress string) error {
+	receipt := result.Receipt
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("deployment failed in block %d: %s", receipt.BlockNumber, result.TxHash)
+	}
+	if receipt.ContractAddress.Hex() != contractAddress {
+		return fmt.Errorf("contract deployed at %s, not at the predicted address %s", receipt.ContractAddress.Hex(), contractAddress)
+	}
+
+	code, err := result.Client.CodeAt(context.Background(), receipt.ContractAddress, receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s: %v", contractAddress, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no code was deployed at %s (the constructor returned empty runtime code)", contractAddress)
+	}
+
+	fmt.Printf("\033[1;32mContract deployed at: %s\033[0m (%d bytes of code)\n", contractAddress, len(code))
+	return nil
+}
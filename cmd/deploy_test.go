@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+)
+
+func TestLoadDeployBytecode(t *testing.T) {
+	dir := t.TempDir()
+	artifactPath := filepath.Join(dir, "Token.json")
+	artifact := `{"abi":[{"type":"constructor","inputs":[{"name":"name","type":"string"},{"name":"supply","type":"uint256"}],"stateMutability":"payable"}],"bytecode":{"object":"0x6080604052"}}`
+	if err := os.WriteFile(artifactPath, []byte(artifact), 0600); err != nil {
+		t.Fatalf("Failed to write artifact: %v", err)
+	}
+	bytecodePath := filepath.Join(dir, "Token.bin")
+	if err := os.WriteFile(bytecodePath, []byte("6080604052\n"), 0600); err != nil {
+		t.Fatalf("Failed to write bytecode: %v", err)
+	}
+	want := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+
+	bytecode, constructor, err := loadDeployBytecode(artifactPath, "", "")
+	if err != nil || !bytes.Equal(bytecode, want) || len(constructor.Inputs) != 2 || !constructor.IsPayable() {
+		t.Errorf("Unexpected artifact result: %x, %v, %v", bytecode, constructor.Inputs, err)
+	}
+
+	bytecode, constructor, err = loadDeployBytecode("", bytecodePath, "(string name, uint256 supply)")
+	if err != nil || !bytes.Equal(bytecode, want) || len(constructor.Inputs) != 2 {
+		t.Errorf("Unexpected bytecode file result: %x, %v, %v", bytecode, constructor.Inputs, err)
+	}
+
+	bytecode, constructor, err = loadDeployBytecode("", "0x6080604052", "")
+	if err != nil || !bytes.Equal(bytecode, want) || len(constructor.Inputs) != 0 {
+		t.Errorf("Unexpected hex bytecode result: %x, %v, %v", bytecode, constructor.Inputs, err)
+	}
+
+	if _, _, err := loadDeployBytecode("", "0x6080604052", "(uint7)"); err == nil {
+		t.Error("Expected error for an invalid constructor type, but got none")
+	}
+	if _, _, err := loadDeployBytecode("", filepath.Join(dir, "missing.bin"), ""); err == nil {
+		t.Error("Expected error for a missing bytecode file, but got none")
+	}
+
+	// Constructor arguments are appended to the bytecode and decode back for the confirmation
+	_, constructor, _ = loadDeployBytecode(artifactPath, "", "")
+	data, err := util.EncodeArguments(want, constructor.Inputs, []string{"Token", "1000"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	details := describeConstructorArguments(constructor, data[len(want):])
+	if len(details) != 2 || details[0][1] != `"Token"` || details[1][1] != "1000" {
+		t.Errorf("Unexpected constructor details: %v", details)
+	}
+}
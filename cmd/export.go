@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ExportFormatKeystoreV3 is the go-ethereum keystore V3 / Web3 Secret Storage export format
+const ExportFormatKeystoreV3 = "keystore-v3"
+
+// ExportCmd 返回 export 命令，用于导出 keystore V3 文件
+func ExportCmd() *cobra.Command {
+	var inputLocation string
+	var walletName string
+	var format string
+	var kdf string
+	var lightKDF bool
+	var outputPath string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a wallet account to a keystore V3 file",
+		Long: `Export one account of a wallet to a go-ethereum keystore V3 (Web3 Secret Storage) JSON file,
+which can be imported by geth, Foundry (cast --keystore) and MetaMask without exposing the mnemonic.
+
+The keystore is encrypted with a separately prompted keystore password.
+
+Examples:
+  eth-cli export -i google -n myWallet --format keystore-v3
+  eth-cli export -i /path/to/wallet.json --format keystore-v3 --account treasury --kdf pbkdf2 --path ./treasury.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocation == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if format != ExportFormatKeystoreV3 {
+				fmt.Printf("Error: unsupported export format '%s' (supported: %s)\n", format, ExportFormatKeystoreV3)
+				os.Exit(1)
+			}
+
+			if kdf != util.KeystoreKDFScrypt && kdf != util.KeystoreKDFPBKDF2 {
+				fmt.Printf("Error: --kdf must be one of: %s, %s\n", util.KeystoreKDFScrypt, util.KeystoreKDFPBKDF2)
+				os.Exit(1)
+			}
+
+			selector, err := getAccountSelector(cmd)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 读取并解密钱包
+			walletData, err := getWalletData(inputLocation, walletName)
+			if err != nil {
+				fmt.Printf("Error loading wallet from %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+
+			privateKeyHex, address, err := processWalletData(walletData, selector)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Wallet Address: \033[1;32m%s\033[0m\n", address)
+
+			privateKey, err := crypto.HexToECDSA(privateKeyHex)
+			if err != nil {
+				fmt.Printf("Error parsing private key: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 默认使用 geth 的文件命名方式
+			if outputPath == "" {
+				timestamp := strings.ReplaceAll(time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), ".", "-")
+				outputPath = fmt.Sprintf("UTC--%s--%s", timestamp, strings.ToLower(strings.TrimPrefix(address, "0x")))
+			}
+
+			if !force {
+				if _, err := os.Stat(outputPath); err == nil {
+					fmt.Printf("Error: File already exists at %s. Use -f or --force to overwrite.\n", outputPath)
+					os.Exit(1)
+				}
+			}
+
+			// 获取 keystore 密码
+			fmt.Println("\nPlease enter a \033[1;31mKeystore Password\033[0m for the exported file.")
+			fmt.Println("This password is independent of your AES password and BIP39 passphrase.")
+			fmt.Print("Please Enter \033[1;31mKeystore Password\033[0m: ")
+			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				fmt.Printf("\nError reading password: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print("\nPlease Re-Enter \033[1;31mKeystore Password\033[0m: ")
+			confirmPasswordBytes, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				fmt.Printf("\nError reading password confirmation: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+
+			if string(passwordBytes) != string(confirmPasswordBytes) {
+				fmt.Println("Error: Passwords do not match")
+				os.Exit(1)
+			}
+			if len(passwordBytes) == 0 {
+				fmt.Println("Error: Keystore password cannot be empty")
+				os.Exit(1)
+			}
+			if !isStrongPassword(string(passwordBytes)) {
+				fmt.Println("\033[33mWARNING: The keystore password is weak. Anyone with the file can try to brute-force it.\033[0m")
+			}
+
+			// 生成 keystore JSON
+			keystoreJSON, err := util.EncryptKeystoreV3(privateKey, string(passwordBytes), kdf, lightKDF)
+			if err != nil {
+				fmt.Printf("Error encrypting keystore: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := util.SaveToFileSystem(keystoreJSON, outputPath); err != nil {
+				fmt.Printf("Error saving keystore: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Keystore saved to: %s\n", outputPath)
+			fmt.Println("\n\033[1;31mIMPORTANT: The keystore file controls this account. Store it as carefully as the wallet file.\033[0m")
+			fmt.Println("\n\033[1;32mSuccess: Keystore exported successfully.\033[0m")
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVarP(&inputLocation, "input", "i", "", "Input location (local file path or cloud provider)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
+	cmd.Flags().StringVar(&format, "format", ExportFormatKeystoreV3, "Export format (supported: keystore-v3)")
+	cmd.Flags().StringVar(&kdf, "kdf", util.KeystoreKDFScrypt, "Keystore key derivation function: scrypt or pbkdf2")
+	cmd.Flags().BoolVar(&lightKDF, "light-kdf", false, "Use lighter KDF parameters (faster, less secure)")
+	cmd.Flags().StringVarP(&outputPath, "path", "p", "", "Output file path (default: UTC--<timestamp>--<address> in the current directory)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite if the output file already exists")
+	addAccountFlags(cmd)
+
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
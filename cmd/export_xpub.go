@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/term"
+)
+
+// hdwalletFixIssue179Env is the environment variable that switches go-ethereum-hdwallet to
+// standard BIP32 derivation for keys affected by btcutil issue 172
+const hdwalletFixIssue179Env = "GO_ETHEREUM_HDWALLET_FIX_ISSUE_179"
+
+// ExportXpubCmd 返回 export-xpub 命令，用于导出账户级 xpub
+func ExportXpubCmd() *cobra.Command {
+	var inputLocation string
+	var walletName string
+	var xpubPath string
+
+	cmd := &cobra.Command{
+		Use:   "export-xpub",
+		Short: "Export the account-level xpub of a mnemonic wallet",
+		Long: `Export the account-level extended public key (xpub) of a mnemonic wallet.
+
+The xpub lets a watch-only wallet derive every address below it (e.g. m/44'/60'/0'/0/N)
+without the mnemonic. It reveals all of these addresses, so only share it where that is acceptable.
+
+Examples:
+  eth-cli export-xpub -i google -n myWallet
+  eth-cli import --type xpub --xpub xpub6... --output fs --path ./watch.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocation == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			walletData, err := getWalletData(inputLocation, walletName)
+			if err != nil {
+				fmt.Printf("Error loading wallet from %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+
+			var wallet WalletFile
+			if err := json.Unmarshal(walletData, &wallet); err != nil {
+				fmt.Printf("Error parsing wallet file: %v\n", err)
+				os.Exit(1)
+			}
+			if isPrivateKeyWallet(wallet) || isWatchOnlyWallet(wallet) {
+				fmt.Println("Error: only mnemonic wallets have an xpub")
+				os.Exit(1)
+			}
+
+			// 默认使用 HD 路径的上一级（账户级）
+			if xpubPath == "" {
+				hdPath := wallet.HDPath
+				if hdPath == "" {
+					hdPath = DefaultHDPath
+				}
+				xpubPath = parentPath(hdPath)
+			}
+
+			// 获取密码
+			fmt.Print("Please Enter \033[1;31mAES\033[0m Password: ")
+			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				fmt.Printf("\nError reading password: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+
+			mnemonic, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, string(passwordBytes))
+			if err != nil {
+				fmt.Printf("Error decrypting mnemonic: %v\n", err)
+				os.Exit(1)
+			}
+
+			passphrase, err := promptBIP39Passphrase()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			xpub, err := accountXpub(mnemonic, passphrase, xpubPath)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 校验 xpub 派生出的地址与钱包地址一致
+			derivationPath, err := resolveDerivationPath(wallet, accountSelector{})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			expected, _, err := getAddressFromMnemonic(mnemonic, passphrase, derivationPath)
+			if err != nil {
+				fmt.Printf("Error generating address: %v\n", err)
+				os.Exit(1)
+			}
+			derived, err := deriveXpubAddress(xpub, xpubPath, derivationPath)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if derived != expected {
+				fmt.Printf("Error: address %s derived from the xpub does not match the wallet address %s\n", derived, expected)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Xpub Path: %s\n", xpubPath)
+			fmt.Printf("Xpub: \033[1;32m%s\033[0m\n", xpub)
+			fmt.Printf("Wallet Address: %s (%s)\n", derived, derivationPath)
+			fmt.Println("\nCreate a watch-only wallet from it with:")
+			fmt.Printf("  eth-cli import --type xpub --xpub %s --xpub-path \"%s\" --derivation-path \"%s\" --output fs --path ./watch.json\n", xpub, xpubPath, derivationPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputLocation, "input", "i", "", "Input location (local file path or cloud provider)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
+	cmd.Flags().StringVar(&xpubPath, "xpub-path", "", "Account-level path to export (default: the parent of the wallet's HD path, e.g. m/44'/60'/0')")
+
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// accountXpub derives the extended public key at xpubPath, deriving private keys the same
+// way go-ethereum-hdwallet does so addresses match those of getAddressFromMnemonic
+func accountXpub(mnemonic, passphrase, xpubPath string) (string, error) {
+	path, err := hdwallet.ParseDerivationPath(xpubPath)
+	if err != nil {
+		return "", fmt.Errorf("error parsing xpub path: %v", err)
+	}
+
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", fmt.Errorf("error creating master key: %v", err)
+	}
+
+	fixIssue172 := os.Getenv(hdwalletFixIssue179Env) != ""
+	for _, n := range path {
+		if fixIssue172 && key.IsAffectedByIssue172() {
+			key, err = key.Derive(n)
+		} else {
+			key, err = key.DeriveNonStandard(n)
+		}
+		if err != nil {
+			return "", fmt.Errorf("error deriving xpub: %v", err)
+		}
+	}
+
+	publicKey, err := key.Neuter()
+	if err != nil {
+		return "", fmt.Errorf("error deriving xpub: %v", err)
+	}
+	return publicKey.String(), nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAccountXpubDerivesWalletAddresses(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	xpubPath := parentPath(DefaultHDPath)
+
+	for _, passphrase := range []string{"", "TREZOR"} {
+		xpub, err := accountXpub(mnemonic, passphrase, xpubPath)
+		if err != nil {
+			t.Fatalf("Failed to export xpub: %v", err)
+		}
+		if !strings.HasPrefix(xpub, "xpub") {
+			t.Fatalf("Unexpected xpub: %s", xpub)
+		}
+
+		for index := 0; index < 3; index++ {
+			path := fmt.Sprintf("%s/%d", DefaultHDPath, index)
+			expected, _, err := getAddressFromMnemonic(mnemonic, passphrase, path)
+			if err != nil {
+				t.Fatalf("Failed to derive address: %v", err)
+			}
+			derived, err := deriveXpubAddress(xpub, xpubPath, path)
+			if err != nil {
+				t.Fatalf("Failed to derive address from xpub: %v", err)
+			}
+			if derived != expected {
+				t.Errorf("Address mismatch at %s: xpub gave %s, mnemonic gave %s", path, derived, expected)
+			}
+		}
+	}
+
+	xpub, _ := accountXpub(mnemonic, "", xpubPath)
+	if address, _ := deriveXpubAddress(xpub, xpubPath, DefaultHDPath+"/0"); address != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Unexpected address: %s", address)
+	}
+	if _, err := deriveXpubAddress(xpub, xpubPath, "m/44'/60'/0'/0'/0"); err == nil {
+		t.Error("Expected error for a hardened path below the xpub, but got none")
+	}
+	if _, err := deriveXpubAddress(xpub, xpubPath, "m/44'/60'/1'/0/0"); err == nil {
+		t.Error("Expected error for a path outside the xpub, but got none")
+	}
+}
+
+func TestGetWatchOnlyAddress(t *testing.T) {
+	wallet := WalletFile{Version: 1, Type: WalletTypeWatchOnly, Address: "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23"}
+
+	address, _, err := getWatchOnlyAddress(wallet, accountSelector{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if address != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("Unexpected address: %s", address)
+	}
+
+	if _, _, err := getWatchOnlyAddress(wallet, accountSelector{index: 1, hasIndex: true}); err == nil {
+		t.Error("Expected error for --index on an address-only wallet, but got none")
+	}
+}
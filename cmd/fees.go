@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// DefaultDryRunPriorityFee is the priority fee used in dry-run mode when --priority-fee is not set (0.1 Gwei)
+const DefaultDryRunPriorityFee = 1e8
+
+// addFeeFlags adds the transaction type and fee flags to a transaction command
+func addFeeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("tx-type", "", "Transaction type: legacy, 2930 or 1559 (default: 1559, or legacy if the chain has no base fee)")
+	cmd.Flags().String("max-fee", "", "Max fee per gas, base fee included (e.g., 30gwei)")
+	cmd.Flags().String("priority-fee", "", "Max priority fee (tip) per gas (e.g., 1.5gwei)")
+	cmd.Flags().String("gas-price", "", "Gas price (e.g., 3gwei); alias for --max-fee")
+	cmd.Flags().String("access-list", "", "Access list JSON, inline or a file path (2930/1559 only)")
+}
+
+// resolveTxParams determines the transaction type, fees and access list from the flags.
+// Without --tx-type, chains whose latest header has no base fee get legacy transactions.
+func resolveTxParams(cmd *cobra.Command, client *ethclient.Client, dryRun bool) (util.TxParams, error) {
+	txType, _ := cmd.Flags().GetString("tx-type")
+	accessListStr, _ := cmd.Flags().GetString("access-list")
+
+	var txParams util.TxParams
+	switch txType {
+	case "", util.TxTypeLegacy, util.TxTypeAccessList, util.TxTypeDynamicFee:
+	default:
+		return txParams, fmt.Errorf("--tx-type must be one of: %s, %s, %s", util.TxTypeLegacy, util.TxTypeAccessList, util.TxTypeDynamicFee)
+	}
+
+	// Detect EIP-1559 support from the latest header
+	if !dryRun && client != nil && txType != util.TxTypeLegacy && txType != util.TxTypeAccessList {
+		baseFee, err := util.GetBaseFee(client)
+		if err != nil {
+			return txParams, fmt.Errorf("failed to get base fee: %v", err)
+		}
+		if baseFee == nil {
+			if txType == util.TxTypeDynamicFee {
+				return txParams, fmt.Errorf("the chain does not support EIP-1559 transactions (no base fee); use --tx-type legacy or 2930")
+			}
+			txType = util.TxTypeLegacy
+			fmt.Println("No base fee in the latest block, using a legacy transaction")
+		}
+	}
+	if txType == "" {
+		txType = util.TxTypeDynamicFee
+	}
+	txParams.Type = txType
+
+	// Parse the access list
+	if accessListStr != "" {
+		if txType == util.TxTypeLegacy {
+			return txParams, fmt.Errorf("--access-list is not supported for legacy transactions")
+		}
+		data := []byte(accessListStr)
+		if !strings.HasPrefix(strings.TrimSpace(accessListStr), "[") {
+			var err error
+			if data, err = os.ReadFile(accessListStr); err != nil {
+				return txParams, fmt.Errorf("failed to read access list file: %v", err)
+			}
+		}
+		accessList, err := util.ParseAccessList(data)
+		if err != nil {
+			return txParams, err
+		}
+		txParams.AccessList = accessList
+	}
+
+	var err error
+	if txType == util.TxTypeDynamicFee {
+		txParams.Fees, err = resolveFees(cmd, client, dryRun)
+	} else {
+		txParams.Fees, err = resolveGasPrice(cmd, client, dryRun)
+	}
+	return txParams, err
+}
+
+// resolveGasPrice determines the gas price of a legacy or EIP-2930 transaction.
+// The gas price is stored as both the max fee and the priority fee.
+func resolveGasPrice(cmd *cobra.Command, client *ethclient.Client, dryRun bool) (util.FeeParams, error) {
+	maxFeeStr, _ := cmd.Flags().GetString("max-fee")
+	priorityFeeStr, _ := cmd.Flags().GetString("priority-fee")
+	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
+
+	if priorityFeeStr != "" {
+		return util.FeeParams{}, fmt.Errorf("--priority-fee is only supported for EIP-1559 transactions, use --gas-price")
+	}
+	if gasPriceStr != "" && maxFeeStr != "" {
+		return util.FeeParams{}, fmt.Errorf("--gas-price and --max-fee are mutually exclusive, use one or the other")
+	}
+	if gasPriceStr == "" {
+		gasPriceStr = maxFeeStr
+	}
+
+	var gasPrice *big.Int
+	var err error
+	if gasPriceStr != "" {
+		if gasPrice, err = parseEthAmount(gasPriceStr); err != nil {
+			return util.FeeParams{}, fmt.Errorf("invalid gas price: %v", err)
+		}
+	} else if !dryRun && client != nil {
+		if gasPrice, err = client.SuggestGasPrice(context.Background()); err != nil {
+			return util.FeeParams{}, fmt.Errorf("failed to get suggested gas price: %v", err)
+		}
+	} else {
+		gasPrice = big.NewInt(DefaultDryRunGasPrice) // Default 1 Gwei if dry run
+	}
+
+	return util.FeeParams{MaxFeePerGas: gasPrice, MaxPriorityFeePerGas: gasPrice}, nil
+}
+
+// resolveFees determines the EIP-1559 fees from the fee flags, filling in values that
+// are not set from the network (or dry-run defaults when there is no client)
+func resolveFees(cmd *cobra.Command, client *ethclient.Client, dryRun bool) (util.FeeParams, error) {
+	maxFeeStr, _ := cmd.Flags().GetString("max-fee")
+	priorityFeeStr, _ := cmd.Flags().GetString("priority-fee")
+	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
+
+	if gasPriceStr != "" {
+		if maxFeeStr != "" {
+			return util.FeeParams{}, fmt.Errorf("--gas-price and --max-fee are mutually exclusive, use one or the other")
+		}
+		maxFeeStr = gasPriceStr
+	}
+
+	var fees util.FeeParams
+	var err error
+	if maxFeeStr != "" {
+		if fees.MaxFeePerGas, err = parseEthAmount(maxFeeStr); err != nil {
+			return fees, fmt.Errorf("invalid max fee: %v", err)
+		}
+	}
+	if priorityFeeStr != "" {
+		if fees.MaxPriorityFeePerGas, err = parseEthAmount(priorityFeeStr); err != nil {
+			return fees, fmt.Errorf("invalid priority fee: %v", err)
+		}
+	}
+
+	if dryRun || client == nil {
+		// Dry run defaults: 1 Gwei max fee and a 0.1 Gwei tip (capped at the max fee)
+		if fees.MaxFeePerGas == nil {
+			fees.MaxFeePerGas = big.NewInt(DefaultDryRunGasPrice)
+		}
+		if fees.MaxPriorityFeePerGas == nil {
+			fees.MaxPriorityFeePerGas = big.NewInt(DefaultDryRunPriorityFee)
+			if fees.MaxPriorityFeePerGas.Cmp(fees.MaxFeePerGas) > 0 {
+				fees.MaxPriorityFeePerGas = new(big.Int).Set(fees.MaxFeePerGas)
+			}
+		}
+		return fees, fees.Validate()
+	}
+
+	suggested, err := util.SuggestFees(client)
+	if err != nil {
+		return fees, fmt.Errorf("failed to get suggested fees: %v", err)
+	}
+	fees.BaseFee = suggested.BaseFee
+
+	if fees.MaxPriorityFeePerGas == nil {
+		fees.MaxPriorityFeePerGas = suggested.MaxPriorityFeePerGas
+		// Keep a user-set max fee valid by capping the suggested tip
+		if fees.MaxFeePerGas != nil && fees.MaxPriorityFeePerGas.Cmp(fees.MaxFeePerGas) > 0 {
+			fees.MaxPriorityFeePerGas = new(big.Int).Set(fees.MaxFeePerGas)
+		}
+	}
+	if fees.MaxFeePerGas == nil {
+		if fees.BaseFee != nil {
+			fees.MaxFeePerGas = new(big.Int).Mul(fees.BaseFee, big.NewInt(2))
+			fees.MaxFeePerGas.Add(fees.MaxFeePerGas, fees.MaxPriorityFeePerGas)
+		} else {
+			fees.MaxFeePerGas = suggested.MaxFeePerGas
+		}
+	}
+
+	if err := fees.Validate(); err != nil {
+		return fees, err
+	}
+
+	if fees.BaseFee != nil && fees.MaxFeePerGas.Cmp(fees.BaseFee) < 0 {
+		fmt.Printf("\033[33mWARNING: Max fee (%s Gwei) is below the current base fee (%s Gwei); the transaction will not be mined until the base fee drops.\033[0m\n",
+			formatGwei(fees.MaxFeePerGas), formatGwei(fees.BaseFee))
+	}
+
+	return fees, nil
+}
+
+// feeDetails returns the label/value pairs describing the type and fees of a transaction
+func feeDetails(txParams util.TxParams, gasLimit uint64) [][2]string {
+	fees := txParams.Fees
+	gas := big.NewInt(int64(gasLimit))
+
+	details := [][2]string{{"Transaction Type", util.TxTypeName(txParams.Type)}}
+	if len(txParams.AccessList) > 0 {
+		details = append(details, [2]string{"Access List", fmt.Sprintf("%d addresses, %d storage keys", len(txParams.AccessList), txParams.AccessList.StorageKeys())})
+	}
+
+	if txParams.Type != util.TxTypeDynamicFee {
+		details = append(details, [2]string{"Gas Price", formatGwei(fees.MaxFeePerGas) + " Gwei"})
+		details = append(details, [2]string{"Gas Fee", formatEther(new(big.Int).Mul(fees.MaxFeePerGas, gas)) + " " + nativeSymbol()})
+		return details
+	}
+
+	details = append(details, [2]string{"Max Fee", formatGwei(fees.MaxFeePerGas) + " Gwei"})
+	details = append(details, [2]string{"Priority Fee", formatGwei(fees.MaxPriorityFeePerGas) + " Gwei"})
+	if fees.BaseFee != nil {
+		details = append(details, [2]string{"Base Fee", formatGwei(fees.BaseFee) + " Gwei"})
+		details = append(details, [2]string{"Estimated Gas Fee", formatEther(new(big.Int).Mul(fees.EffectiveGasPrice(), gas)) + " " + nativeSymbol()})
+	}
+	details = append(details, [2]string{"Max Gas Fee", formatEther(new(big.Int).Mul(fees.MaxFeePerGas, gas)) + " " + nativeSymbol()})
+	return details
+}
+
+// txParamsFromTransaction returns the type, fees and access list of a decoded transaction
+func txParamsFromTransaction(tx *types.Transaction) util.TxParams {
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return util.TxParams{
+			Type: util.TxTypeLegacy,
+			Fees: util.FeeParams{MaxFeePerGas: tx.GasPrice(), MaxPriorityFeePerGas: tx.GasPrice()},
+		}
+	case types.AccessListTxType:
+		return util.TxParams{
+			Type:       util.TxTypeAccessList,
+			Fees:       util.FeeParams{MaxFeePerGas: tx.GasPrice(), MaxPriorityFeePerGas: tx.GasPrice()},
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return util.TxParams{
+			Type:       util.TxTypeDynamicFee,
+			Fees:       util.FeeParams{MaxFeePerGas: tx.GasFeeCap(), MaxPriorityFeePerGas: tx.GasTipCap()},
+			AccessList: tx.AccessList(),
+		}
+	}
+}
+
+// printFeeDetails prints the type and fee lines of the transaction details
+func printFeeDetails(txParams util.TxParams, gasLimit uint64) {
+	for _, detail := range feeDetails(txParams, gasLimit) {
+		fmt.Printf("%s: %s\n", detail[0], detail[1])
+	}
+}
+
+// formatGwei formats a wei amount in Gwei with 9 decimals
+func formatGwei(wei *big.Int) string {
+	return fmt.Sprintf("%d.%09d", new(big.Int).Div(wei, big.NewInt(GweiToWei)), new(big.Int).Mod(wei, big.NewInt(GweiToWei)))
+}
+
+// formatEther formats a wei amount in ETH with 18 decimals
+func formatEther(wei *big.Int) string {
+	return fmt.Sprintf("%d.%018d", new(big.Int).Div(wei, big.NewInt(EthToWei)), new(big.Int).Mod(wei, big.NewInt(EthToWei)))
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolveFeesDryRun(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addFeeFlags(cmd)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("Failed to parse flags %v: %v", args, err)
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		args        []string
+		maxFee      int64
+		priorityFee int64
+		wantErr     bool
+	}{
+		{nil, 1e9, 1e8, false},
+		{[]string{"--max-fee", "30gwei", "--priority-fee", "2gwei"}, 30e9, 2e9, false},
+		{[]string{"--gas-price", "3gwei"}, 3e9, 1e8, false},
+		{[]string{"--max-fee", "0.05gwei"}, 5e7, 5e7, false},
+		{[]string{"--max-fee", "1gwei", "--priority-fee", "2gwei"}, 0, 0, true},
+		{[]string{"--max-fee", "1gwei", "--gas-price", "1gwei"}, 0, 0, true},
+	}
+
+	for _, tc := range tests {
+		fees, err := resolveFees(newCmd(tc.args...), nil, true)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %v, but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %v: %v", tc.args, err)
+			continue
+		}
+		if fees.MaxFeePerGas.Cmp(big.NewInt(tc.maxFee)) != 0 {
+			t.Errorf("Unexpected max fee for %v: %s", tc.args, fees.MaxFeePerGas)
+		}
+		if fees.MaxPriorityFeePerGas.Cmp(big.NewInt(tc.priorityFee)) != 0 {
+			t.Errorf("Unexpected priority fee for %v: %s", tc.args, fees.MaxPriorityFeePerGas)
+		}
+	}
+}
@@ -6,10 +6,10 @@ import (
 	"math/big"
 	"os"
 
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // GasPriceCmd 返回 gas-price 命令
@@ -19,13 +19,10 @@ func GasPriceCmd() *cobra.Command {
 		Short: "Get current gas price from the Ethereum network",
 		Long:  `Retrieve the current gas price from the Ethereum network using the configured RPC endpoint.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// 初始化配置
-			initConfig()
-
-			// 从配置中获取 RPC URL
-			rpcURL := viper.GetString("rpc")
-			if rpcURL == "" {
-				fmt.Println("Error: RPC URL not configured. Please set it with 'eth-cli-wallet config set rpc YOUR_RPC_URL'")
+			// 初始化配置，获取 RPC URL
+			rpcURL, err := initTxConfig()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
 
@@ -56,11 +53,27 @@ func GasPriceCmd() *cobra.Command {
 
 			// 输出rpc
 			fmt.Printf("RPC URL: %s\n", rpcURL)
+			if currentNetwork != nil {
+				fmt.Printf("Network: %s (chain ID %s)\n", currentNetwork.Name, currentNetwork.ChainID)
+			}
 			// 输出 gas 价格
 			fmt.Printf("Current Gas Price:\n")
 			fmt.Printf("Wei:   %s\n", gasPrice.String())
 			fmt.Printf("Gwei:  %s\n", displayGwei)
-			fmt.Printf("ETH:   %s\n", displayEther)
+			fmt.Printf("%-6s %s\n", nativeSymbol()+":", displayEther)
+
+			// 输出 EIP-1559 费用建议
+			fees, err := util.SuggestFees(client)
+			if err != nil {
+				fmt.Printf("Error getting EIP-1559 fees: %v\n", err)
+				os.Exit(1)
+			}
+			if fees.BaseFee != nil {
+				fmt.Printf("\nEIP-1559 Fees:\n")
+				fmt.Printf("Base Fee:               %s Gwei\n", formatGwei(fees.BaseFee))
+				fmt.Printf("Suggested Priority Fee: %s Gwei\n", formatGwei(fees.MaxPriorityFeePerGas))
+				fmt.Printf("Suggested Max Fee:      %s Gwei\n", formatGwei(fees.MaxFeePerGas))
+			}
 		},
 	}
 
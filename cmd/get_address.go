@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 	"syscall"
 
 	"github.com/ethanzhrepo/eth-cli-wallet/util"
@@ -18,11 +17,20 @@ func GetAddressCmd() *cobra.Command {
 	var walletName string
 	var showMnemonics bool
 	var showPrivateKey bool
+	var indexRange string
 
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: "Get the Ethereum address from a wallet file",
-		Long:  `Retrieve the Ethereum address from a local or cloud-stored wallet file.`,
+		Long: `Retrieve the Ethereum address from a local or cloud-stored wallet file.
+
+Use --index or --account to select another account derived from the same mnemonic,
+or --range to list a range of derived addresses. Watch-only wallets do not need a password.
+
+Examples:
+  eth-cli get -i google -n myWallet --index 3
+  eth-cli get -i google -n myWallet --account treasury
+  eth-cli get -i /path/to/wallet.json --range 0-49`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// 初始化配置
 			initConfig()
@@ -34,9 +42,19 @@ func GetAddressCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
+			// 确定要派生的账户
+			selector, err := getAccountSelector(cmd)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if indexRange != "" && (selector.hasIndex || selector.account != "") {
+				fmt.Println("Error: --range cannot be combined with --index or --account")
+				os.Exit(1)
+			}
+
 			// 判断输入位置是云存储还是本地文件
 			var walletData []byte
-			var err error
 
 			// Check if the provider is a cloud provider
 			isCloudProvider := false
@@ -76,6 +94,39 @@ func GetAddressCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
+			// 只读钱包不需要密码
+			if isWatchOnlyWallet(wallet) {
+				if showMnemonics || showPrivateKey {
+					fmt.Println("Error: watch-only wallets have no mnemonic or private key")
+					os.Exit(1)
+				}
+
+				if indexRange != "" {
+					if wallet.Xpub == "" {
+						fmt.Println("Error: --range is only supported for xpub watch-only wallets")
+						os.Exit(1)
+					}
+					listDerivedAddresses(wallet, indexRange, false, func(path string) (string, []byte, error) {
+						address, err := deriveXpubAddress(wallet.Xpub, wallet.XpubPath, path)
+						return address, nil, err
+					})
+					return
+				}
+
+				addressHex, derivationPath, err := getWatchOnlyAddress(wallet, selector)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+
+				fmt.Printf("%s\n", util.GenerateQRCode(addressHex))
+				fmt.Printf("Wallet Address: \033[1;32m%s\033[0m (watch-only)\n", addressHex)
+				if selector.hasIndex || selector.account != "" {
+					fmt.Printf("Derivation Path: %s\n", derivationPath)
+				}
+				return
+			}
+
 			// 获取密码
 			fmt.Print("Please Enter \033[1;31mAES\033[0m Password: ")
 			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
@@ -86,50 +137,73 @@ func GetAddressCmd() *cobra.Command {
 			fmt.Println()
 			password := string(passwordBytes)
 
-			// 解密助记词
-			mnemonic, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
-			if err != nil {
-				fmt.Printf("Error decrypting mnemonic: %v\n", err)
-				os.Exit(1)
-			}
+			// 私钥钱包没有助记词，直接由私钥得到地址
+			var addressHex string
+			var privateKeyBytes []byte
+			var derivationPath string
+			if isPrivateKeyWallet(wallet) {
+				if indexRange != "" || selector.hasIndex || selector.account != "" {
+					fmt.Println("Error: --range, --index and --account are not supported for private key wallets")
+					os.Exit(1)
+				}
 
-			// 显示助记词
-			if showMnemonics {
-				fmt.Printf("Decrypted Mnemonic: \033[1;32m%s\033[0m\n", mnemonic)
-				fmt.Printf("HD Path: \033[1;32m%s\033[0m\n", wallet.HDPath)
-				fmt.Printf("Derivation Path: \033[1;32m%s\033[0m\n", wallet.DerivationPath)
-			}
+				privateKeyHex, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
+				if err != nil {
+					fmt.Printf("Error decrypting private key: %v\n", err)
+					os.Exit(1)
+				}
+
+				if showMnemonics {
+					fmt.Println("This wallet was imported from a private key and has no mnemonic.")
+				}
 
-			// 询问是否使用了passphrase
-			fmt.Print("Did you use a BIP39 passphrase for this wallet? (y/n): ")
-			var answer string
-			fmt.Scanln(&answer)
+				addressHex, privateKeyBytes, err = getAddressFromPrivateKey(privateKeyHex)
+				if err != nil {
+					fmt.Printf("Error generating address: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				// 解密助记词
+				mnemonic, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
+				if err != nil {
+					fmt.Printf("Error decrypting mnemonic: %v\n", err)
+					os.Exit(1)
+				}
 
-			var passphrase string
-			if strings.ToLower(answer) == "y" || strings.ToLower(answer) == "yes" {
-				fmt.Print("Please Enter \033[1;31mBIP39\033[0m Passphrase: ")
-				passphraseBytes, err := term.ReadPassword(int(syscall.Stdin))
+				// 显示助记词
+				if showMnemonics {
+					fmt.Printf("Decrypted Mnemonic: \033[1;32m%s\033[0m\n", mnemonic)
+					fmt.Printf("HD Path: \033[1;32m%s\033[0m\n", wallet.HDPath)
+					fmt.Printf("Derivation Path: \033[1;32m%s\033[0m\n", wallet.DerivationPath)
+				}
+
+				// 询问是否使用了passphrase
+				passphrase, err := promptBIP39Passphrase()
 				if err != nil {
-					fmt.Printf("\nError reading passphrase: %v\n", err)
+					fmt.Printf("Error: %v\n", err)
 					os.Exit(1)
 				}
-				fmt.Println()
-				passphrase = string(passphraseBytes)
-			}
 
-			// 使用共用函数获取地址和私钥
-			// Determine which derivation path to use
-			derivationPath := ""
-			if wallet.DerivationPath != "" {
-				derivationPath = wallet.DerivationPath
-			} else if wallet.HDPath != "" {
-				derivationPath = wallet.HDPath
-			}
+				// 列出范围内派生的地址
+				if indexRange != "" {
+					listDerivedAddresses(wallet, indexRange, showPrivateKey, func(path string) (string, []byte, error) {
+						return getAddressFromMnemonic(mnemonic, passphrase, path)
+					})
+					return
+				}
 
-			addressHex, privateKeyBytes, err := getAddressFromMnemonic(mnemonic, passphrase, derivationPath)
-			if err != nil {
-				fmt.Printf("Error generating address: %v\n", err)
-				os.Exit(1)
+				// 使用共用函数获取地址和私钥
+				derivationPath, err = resolveDerivationPath(wallet, selector)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+
+				addressHex, privateKeyBytes, err = getAddressFromMnemonic(mnemonic, passphrase, derivationPath)
+				if err != nil {
+					fmt.Printf("Error generating address: %v\n", err)
+					os.Exit(1)
+				}
 			}
 
 			// 显示二维码
@@ -138,6 +212,9 @@ func GetAddressCmd() *cobra.Command {
 
 			// 输出地址
 			fmt.Printf("Wallet Address: \033[1;32m%s\033[0m\n", addressHex)
+			if selector.hasIndex || selector.account != "" {
+				fmt.Printf("Derivation Path: %s\n", derivationPath)
+			}
 
 			// 如果开启显示私钥参数，则输出私钥
 			if showPrivateKey {
@@ -152,8 +229,42 @@ func GetAddressCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
 	cmd.Flags().BoolVar(&showMnemonics, "show-mnemonics", false, "Display the decrypted mnemonic phrase")
 	cmd.Flags().BoolVar(&showPrivateKey, "show-private-key", false, "Display the hex-encoded private key")
+	cmd.Flags().StringVar(&indexRange, "range", "", "List derived addresses for an inclusive index range (e.g., 0-49)")
+	addAccountFlags(cmd)
 
 	cmd.MarkFlagRequired("input")
 
 	return cmd
 }
+
+// listDerivedAddresses prints the addresses derived for an inclusive index range
+func listDerivedAddresses(wallet WalletFile, indexRange string, showPrivateKey bool, derive func(path string) (string, []byte, error)) {
+	start, end, err := parseIndexRange(indexRange)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 反查账户标签
+	labels := make(map[uint32]string)
+	for label, index := range wallet.Accounts {
+		labels[index] = label
+	}
+
+	fmt.Printf("%-8s %-24s %-44s %s\n", "Index", "Path", "Address", "Account")
+	for index := start; ; index++ {
+		path := accountPath(wallet, index)
+		addressHex, privateKeyBytes, err := derive(path)
+		if err != nil {
+			fmt.Printf("Error generating address: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%-8d %-24s %-44s %s\n", index, path, addressHex, labels[index])
+		if showPrivateKey {
+			fmt.Printf("         Private Key: \033[1;31m%x\033[0m\n", privateKeyBytes)
+		}
+		if index == end {
+			break
+		}
+	}
+}
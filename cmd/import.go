@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/term"
+)
+
+// Import source types
+const (
+	ImportTypeMnemonic   = "mnemonic"
+	ImportTypePrivateKey = "private-key"
+	ImportTypeKeystore   = "keystore"
+	ImportTypeAddress    = "address"
+	ImportTypeXpub       = "xpub"
+)
+
+// ImportCmd 返回 import 命令，用于导入已有的助记词、私钥或 keystore 文件
+func ImportCmd() *cobra.Command {
+	var importType string
+	var keystoreFile string
+	var derivationPath string
+	var watchAddress string
+	var xpub string
+	var xpubPath string
+	var outputLocations string
+	var walletName string
+	var fsPath string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an existing mnemonic, private key, keystore file or watch-only address",
+		Long: `Import an existing secret and save it as an encrypted wallet file.
+
+Supported import types:
+- mnemonic:    a BIP39 mnemonic phrase (the checksum is validated)
+- private-key: a hex-encoded private key (saved as a private key wallet without a mnemonic)
+- keystore:    a go-ethereum keystore V3 JSON file (saved as a private key wallet)
+- address:     a plain address (saved as a watch-only wallet)
+- xpub:        an account-level xpub from export-xpub (saved as a watch-only wallet)
+
+Secrets are read from a hidden prompt and never passed on the command line.
+Watch-only wallets hold no secret, are not encrypted and can be used by get, balance
+and --dry-run without a password. Storage options are the same as for the create command.
+
+Examples:
+  eth-cli import --type mnemonic --output google,dropbox --name myWallet
+  eth-cli import --type private-key --output fs --path /tmp/wallet.json
+  eth-cli import --type keystore --keystore ./UTC--2024-01-01T00-00-00Z--abc.json --output keychain --name myWallet
+  eth-cli import --type xpub --xpub xpub6... --output google --name myWatchWallet`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			switch importType {
+			case ImportTypeMnemonic, ImportTypePrivateKey, ImportTypeKeystore, ImportTypeAddress, ImportTypeXpub:
+			default:
+				fmt.Printf("Error: --type must be one of: %s, %s, %s, %s, %s\n", ImportTypeMnemonic, ImportTypePrivateKey, ImportTypeKeystore, ImportTypeAddress, ImportTypeXpub)
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if importType == ImportTypeKeystore && keystoreFile == "" {
+				fmt.Println("Error: --keystore parameter is required when using --type keystore")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if outputLocations == "fs" && fsPath == "" {
+				fmt.Println("Error: --path parameter is required when using --output fs")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if outputLocations != "fs" && walletName == "" {
+				fmt.Println("Error: --name parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			// 读取要导入的密钥
+			var wallet WalletFile
+			var secret string
+			var addressHex string
+			var err error
+
+			switch importType {
+			case ImportTypeMnemonic:
+				if _, err := hdwallet.ParseDerivationPath(derivationPath); err != nil {
+					fmt.Printf("Error: invalid derivation path: %v\n", err)
+					os.Exit(1)
+				}
+
+				fmt.Print("Please Enter \033[1;31mBIP39 Mnemonic\033[0m: ")
+				mnemonicBytes, err := term.ReadPassword(int(syscall.Stdin))
+				if err != nil {
+					fmt.Printf("\nError reading mnemonic: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Println()
+
+				secret = normalizeMnemonic(string(mnemonicBytes))
+				if !bip39.IsMnemonicValid(secret) {
+					fmt.Println("Error: invalid BIP39 mnemonic (unknown word or checksum mismatch)")
+					os.Exit(1)
+				}
+
+				passphrase, err := promptBIP39Passphrase()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+
+				addressHex, _, err = getAddressFromMnemonic(secret, passphrase, derivationPath)
+				if err != nil {
+					fmt.Printf("Error generating address: %v\n", err)
+					os.Exit(1)
+				}
+
+				wallet = WalletFile{
+					Version:        1,
+					HDPath:         parentPath(derivationPath),
+					DerivationPath: derivationPath,
+					TestNet:        false,
+				}
+
+			case ImportTypePrivateKey:
+				fmt.Print("Please Enter \033[1;31mPrivate Key\033[0m (hex): ")
+				privateKeyBytes, err := term.ReadPassword(int(syscall.Stdin))
+				if err != nil {
+					fmt.Printf("\nError reading private key: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Println()
+
+				addressHex, secret, err = normalizePrivateKey(string(privateKeyBytes))
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+
+				wallet = WalletFile{Version: 1, Type: WalletTypePrivateKey}
+
+			case ImportTypeKeystore:
+				keystoreJSON, err := os.ReadFile(keystoreFile)
+				if err != nil {
+					fmt.Printf("Error reading keystore file: %v\n", err)
+					os.Exit(1)
+				}
+
+				fmt.Print("Please Enter \033[1;31mKeystore\033[0m Password: ")
+				keystorePasswordBytes, err := term.ReadPassword(int(syscall.Stdin))
+				if err != nil {
+					fmt.Printf("\nError reading keystore password: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Println()
+
+				key, err := keystore.DecryptKey(keystoreJSON, string(keystorePasswordBytes))
+				if err != nil {
+					fmt.Printf("Error decrypting keystore: %v\n", err)
+					os.Exit(1)
+				}
+
+				addressHex = key.Address.Hex()
+				secret = hex.EncodeToString(crypto.FromECDSA(key.PrivateKey))
+				wallet = WalletFile{Version: 1, Type: WalletTypePrivateKey}
+
+			case ImportTypeAddress:
+				if !common.IsHexAddress(watchAddress) {
+					fmt.Println("Error: --address must be a valid address when using --type address")
+					os.Exit(1)
+				}
+
+				addressHex = common.HexToAddress(watchAddress).Hex()
+				wallet = WalletFile{Version: 1, Type: WalletTypeWatchOnly, Address: addressHex}
+
+			case ImportTypeXpub:
+				if xpub == "" {
+					fmt.Println("Error: --xpub parameter is required when using --type xpub")
+					os.Exit(1)
+				}
+
+				wallet = WalletFile{
+					Version:        1,
+					Type:           WalletTypeWatchOnly,
+					HDPath:         parentPath(derivationPath),
+					DerivationPath: derivationPath,
+					Xpub:           strings.TrimSpace(xpub),
+					XpubPath:       xpubPath,
+				}
+				addressHex, _, err = getWatchOnlyAddress(wallet, accountSelector{})
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			fmt.Printf("Imported Address: \033[1;32m%s\033[0m\n", addressHex)
+
+			// 只读钱包没有需要加密的密钥
+			if isWatchOnlyWallet(wallet) {
+				walletJSON, err := json.MarshalIndent(wallet, "", "  ")
+				if err != nil {
+					fmt.Printf("Error serializing wallet: %v\n", err)
+					os.Exit(1)
+				}
+
+				verifyCommands, err := saveWalletToOutputs(outputLocations, fsPath, walletName, walletJSON, force)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+
+				fmt.Println("\nCheck the watch-only wallet with the getAddress command (no password needed):")
+				for _, verifyCommand := range verifyCommands {
+					fmt.Printf("  %s\n", verifyCommand)
+				}
+
+				fmt.Println("\n\033[1;32mSuccess: Watch-only wallet imported successfully.\033[0m")
+				return
+			}
+
+			// 获取AES加密密码
+			fmt.Println("\nPlease enter \033[1;31mAES Encryption Password\033[0m for extra security.")
+			fmt.Println("This password will be used to encrypt your \033[1;31mwallet file\033[0m.")
+			fmt.Println("It is recommended to use a strong password: \033[1;31m8 characters or more, including uppercase, lowercase, numbers, and special characters\033[0m.")
+			password, err := promptNewAESPassword()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 使用AES加密
+			wallet.EncryptedMnemonic, err = util.EncryptMnemonic(secret, password)
+			if err != nil {
+				fmt.Printf("Error encrypting wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 序列化为JSON
+			walletJSON, err := json.MarshalIndent(wallet, "", "  ")
+			if err != nil {
+				fmt.Printf("Error serializing wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 保存到指定位置
+			verifyCommands, err := saveWalletToOutputs(outputLocations, fsPath, walletName, walletJSON, force)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("\nYour wallet address is: \033[1;32m%s\033[0m\n", addressHex)
+			fmt.Println("\nBefore using this wallet, please test it with the getAddress command:")
+			for _, verifyCommand := range verifyCommands {
+				fmt.Printf("  %s\n", verifyCommand)
+			}
+
+			// 安全提示
+			fmt.Println("\n\033[1;31mIMPORTANT: The original secret is still valid. Keep it as safe as the new wallet file, or move the funds to a freshly created wallet.\033[0m")
+
+			// 成功提示
+			fmt.Println("\n\033[1;32mSuccess: Wallet imported successfully.\033[0m")
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVar(&importType, "type", "", "Import type: mnemonic, private-key, keystore, address or xpub")
+	cmd.Flags().StringVar(&keystoreFile, "keystore", "", "Path to a keystore V3 JSON file (for --type keystore)")
+	cmd.Flags().StringVar(&derivationPath, "derivation-path", DefaultHDPath+"/0", "Derivation path of the account (for --type mnemonic or xpub)")
+	cmd.Flags().StringVar(&watchAddress, "address", "", "Address to watch (for --type address)")
+	cmd.Flags().StringVar(&xpub, "xpub", "", "Account-level extended public key (for --type xpub)")
+	cmd.Flags().StringVar(&xpubPath, "xpub-path", parentPath(DefaultHDPath), "Derivation path the xpub was exported at (for --type xpub)")
+	cmd.Flags().StringVarP(&outputLocations, "output", "o", "", "Output location: 'fs' for local file, or comma-separated list of cloud providers (supported: google, dropbox, s3, box, keychain)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required except when using --output fs)")
+	cmd.Flags().StringVarP(&fsPath, "path", "p", "", "File path for wallet when using --output fs")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite if wallet file already exists")
+
+	cmd.MarkFlagRequired("type")
+	cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+// normalizeMnemonic lower-cases a mnemonic and collapses whitespace between words
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// normalizePrivateKey validates a hex private key and returns its address and canonical hex form
+func normalizePrivateKey(privateKeyHex string) (string, string, error) {
+	privateKeyHex = strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x")
+	if len(privateKeyHex) != 64 {
+		return "", "", fmt.Errorf("private key must be 32 bytes (64 hex characters)")
+	}
+
+	address, privateKeyBytes, err := getAddressFromPrivateKey(privateKeyHex)
+	if err != nil {
+		return "", "", err
+	}
+
+	return address, hex.EncodeToString(privateKeyBytes), nil
+}
+
+// parentPath returns the HD path one level above a derivation path (e.g. m/44'/60'/0'/0 for m/44'/60'/0'/0/0)
+func parentPath(derivationPath string) string {
+	if i := strings.LastIndex(derivationPath, "/"); i > 0 {
+		return derivationPath[:i]
+	}
+	return derivationPath
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestNormalizePrivateKey(t *testing.T) {
+	address, privateKeyHex, err := normalizePrivateKey(" 0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318\n")
+	if err != nil {
+		t.Fatalf("Failed to normalize private key: %v", err)
+	}
+	if address != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("Unexpected address: %s", address)
+	}
+	if privateKeyHex != "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318" {
+		t.Errorf("Unexpected private key: %s", privateKeyHex)
+	}
+
+	if _, _, err := normalizePrivateKey("0x1234"); err == nil {
+		t.Error("Expected error for short private key, but got none")
+	}
+	if _, _, err := normalizePrivateKey("zz0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"); err == nil {
+		t.Error("Expected error for non-hex private key, but got none")
+	}
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	mnemonic := normalizeMnemonic("  Abandon abandon  abandon abandon abandon abandon\nabandon abandon abandon abandon abandon ABOUT ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		t.Errorf("Normalized mnemonic should be valid: %q", mnemonic)
+	}
+
+	// Changing the last word breaks the checksum
+	if bip39.IsMnemonicValid(normalizeMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")) {
+		t.Error("Expected mnemonic with a bad checksum to be invalid")
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// LabelCmd 返回 label 命令，用于管理钱包文件中的账户标签
+func LabelCmd() *cobra.Command {
+	var inputLocation string
+	var walletName string
+	var account string
+	var index uint32
+	var remove bool
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "Manage named account labels in a wallet file",
+		Long: `Record named account labels in a wallet file so that --account can be used instead of --index.
+
+Labels only map a name to an address index; they are stored unencrypted and no password is required.
+
+Examples:
+  eth-cli label -i google -n myWallet --account treasury --index 3
+  eth-cli label -i /path/to/wallet.json --account treasury --delete
+  eth-cli label -i google -n myWallet --list`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocation == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if !list && account == "" {
+				fmt.Println("Error: --account parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if !list && !remove && !cmd.Flags().Changed("index") {
+				fmt.Println("Error: --index parameter is required when setting a label")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			// 读取钱包文件
+			walletData, err := getWalletData(inputLocation, walletName)
+			if err != nil {
+				fmt.Printf("Error loading wallet from %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+
+			var wallet WalletFile
+			if err := json.Unmarshal(walletData, &wallet); err != nil {
+				fmt.Printf("Error parsing wallet file: %v\n", err)
+				os.Exit(1)
+			}
+
+			if isPrivateKeyWallet(wallet) {
+				fmt.Println("Error: account labels are not supported for private key wallets")
+				os.Exit(1)
+			}
+
+			// 列出所有标签
+			if list {
+				if len(wallet.Accounts) == 0 {
+					fmt.Println("No account labels found")
+					return
+				}
+
+				labels := make([]string, 0, len(wallet.Accounts))
+				for label := range wallet.Accounts {
+					labels = append(labels, label)
+				}
+				sort.Slice(labels, func(i, j int) bool {
+					return wallet.Accounts[labels[i]] < wallet.Accounts[labels[j]]
+				})
+
+				fmt.Printf("%-20s %-8s %s\n", "Account", "Index", "Path")
+				for _, label := range labels {
+					fmt.Printf("%-20s %-8d %s\n", label, wallet.Accounts[label], accountPath(wallet, wallet.Accounts[label]))
+				}
+				return
+			}
+
+			// 更新标签
+			if remove {
+				if _, ok := wallet.Accounts[account]; !ok {
+					fmt.Printf("Error: account '%s' not found in wallet file\n", account)
+					os.Exit(1)
+				}
+				delete(wallet.Accounts, account)
+			} else {
+				if wallet.Accounts == nil {
+					wallet.Accounts = make(map[string]uint32)
+				}
+				wallet.Accounts[account] = index
+			}
+
+			// 写回钱包文件
+			walletJSON, err := json.MarshalIndent(wallet, "", "  ")
+			if err != nil {
+				fmt.Printf("Error serializing wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := putWalletData(inputLocation, walletName, walletJSON, true)
+			if err != nil {
+				fmt.Printf("Error saving wallet to %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+			fmt.Println(result)
+
+			if remove {
+				fmt.Printf("\033[1;32mAccount label '%s' removed.\033[0m\n", account)
+			} else {
+				fmt.Printf("\033[1;32mAccount label '%s' set to index %d (%s).\033[0m\n", account, index, accountPath(wallet, index))
+			}
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVarP(&inputLocation, "input", "i", "", "Input location (local file path or cloud provider)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
+	cmd.Flags().StringVar(&account, "account", "", "Account label to set or delete")
+	cmd.Flags().Uint32Var(&index, "index", 0, "Address index the label refers to")
+	cmd.Flags().BoolVar(&remove, "delete", false, "Delete the account label")
+	cmd.Flags().BoolVar(&list, "list", false, "List all account labels")
+
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+// senderBoundSignatures are token functions that act on the tokens of msg.sender. Called
+// through Multicall3 they would act on Multicall3's tokens, never on the wallet's.
+var senderBoundSignatures = []string{
+	"transfer(address,uint256)",
+	"approve(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"increaseAllowance(address,uint256)",
+	"decreaseAllowance(address,uint256)",
+	"setApprovalForAll(address,bool)",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"safeTransferFrom(address,address,uint256,uint256,bytes)",
+	"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+}
+
+// senderBoundSelectors maps the selectors of senderBoundSignatures to their signatures
+var senderBoundSelectors = func() map[[4]byte]string {
+	selectors := make(map[[4]byte]string)
+	for _, signature := range senderBoundSignatures {
+		var selector [4]byte
+		copy(selector[:], crypto.Keccak256([]byte(signature))[:4])
+		selectors[selector] = signature
+	}
+	return selectors
+}()
+
+// MulticallCmd creates the command that bundles several contract calls into one transaction
+func MulticallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multicall",
+		Short: "Send several contract calls in one transaction through Multicall3",
+		Long: `Bundle several contract calls into a single transaction sent to the canonical
+Multicall3 contract (` + util.Multicall3Address.Hex() + `) with aggregate3Value.
+
+Each --call is TARGET:CALLDATA[:VALUE], where CALLDATA is 0x-prefixed hex and VALUE is
+the ETH sent with the call (e.g. 0.1eth). The transaction value is the sum of the call values.
+
+IMPORTANT: the target contracts see Multicall3, not your wallet, as msg.sender. Calls such as
+ERC20 transfer/approve or NFT transfers and approvals would act on Multicall3's own tokens,
+so they cannot move or approve your wallet's tokens and are rejected. Use multicall for calls
+that do not depend on the caller, e.g. permissionless keeper functions or payable mints
+that take the recipient as an argument.
+
+Examples:
+  eth-cli multicall -p google -n myWallet --call 0xTarget1:0x3e4f49e6 --call 0xTarget2:0x1249c58b:0.05eth
+  eth-cli multicall -f ./wallet.json --call 0xTarget:0x3e4f49e6 --allow-failure --dry-run`,
+		RunE: runMulticall,
+	}
+
+	cmd.Flags().StringArray("call", nil, "Call as TARGET:CALLDATA[:VALUE] (repeatable)")
+	cmd.Flags().Bool("allow-failure", false, "Let individual calls fail without reverting the whole transaction")
+	addContractTxFlags(cmd)
+
+	cmd.MarkFlagRequired("call")
+
+	return cmd
+}
+
+func runMulticall(cmd *cobra.Command, args []string) error {
+	callSpecs, _ := cmd.Flags().GetStringArray("call")
+	allowFailure, _ := cmd.Flags().GetBool("allow-failure")
+
+	calls, err := parseMulticallCalls(callSpecs, allowFailure)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\033[33mNOTE: the called contracts see Multicall3 as msg.sender, not your wallet.\033[0m")
+
+	_, err = runContractTx(cmd, func(txCtx contractTxContext) (contractTxRequest, error) {
+		data, total, err := util.EncodeAggregate3Value(calls)
+		if err != nil {
+			return contractTxRequest{}, err
+		}
+
+		req := contractTxRequest{
+			To:              &util.Multicall3Address,
+			Value:           total,
+			Data:            data,
+			DefaultGasLimit: uint64(DefaultGasLimitContractCall * len(calls)),
+		}
+		for i, call := range calls {
+			req.Details = append(req.Details, [2]string{
+				fmt.Sprintf("Call %d", i+1),
+				fmt.Sprintf("%s %s (%s %s)", call.Target.Hex(), describeCallData(call.CallData), formatEther(call.Value), nativeSymbol()),
+			})
+		}
+
+		// Simulate the bundle so failing calls show up before signing
+		if txCtx.Client != nil {
+			msg := ethereum.CallMsg{From: txCtx.From, To: req.To, Value: req.Value, Data: req.Data}
+			output, err := txCtx.Client.CallContract(context.Background(), msg, nil)
+			if err != nil {
+				return req, fmt.Errorf("multicall simulation failed: %v", err)
+			}
+			results, err := util.DecodeAggregate3Value(output, len(calls))
+			if err != nil {
+				return req, err
+			}
+			for i, result := range results {
+				status := "ok"
+				if !result.Success {
+					status = "\033[1;31mwould fail\033[0m"
+				}
+				req.Details = append(req.Details, [2]string{fmt.Sprintf("Simulated Call %d", i+1), status})
+			}
+		}
+
+		return req, nil
+	})
+	return err
+}
+
+// parseMulticallCalls parses the --call values and rejects calls that depend on msg.sender
+func parseMulticallCalls(callSpecs []string, allowFailure bool) ([]util.Call3Value, error) {
+	if len(callSpecs) == 0 {
+		return nil, fmt.Errorf("at least one --call is required")
+	}
+
+	calls := make([]util.Call3Value, 0, len(callSpecs))
+	for i, spec := range callSpecs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("call %d: expected TARGET:CALLDATA[:VALUE], got %s", i+1, spec)
+		}
+
+		target := strings.TrimSpace(parts[0])
+		if !common.IsHexAddress(target) {
+			return nil, fmt.Errorf("call %d: invalid target address: %s", i+1, target)
+		}
+		callData, err := hexutil.Decode(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("call %d: invalid calldata: %v", i+1, err)
+		}
+
+		call := util.Call3Value{
+			Target:       common.HexToAddress(target),
+			AllowFailure: allowFailure,
+			Value:        big.NewInt(0),
+			CallData:     callData,
+		}
+		if len(parts) == 3 {
+			if call.Value, err = parseEthAmount(parts[2]); err != nil {
+				return nil, fmt.Errorf("call %d: invalid value: %v", i+1, err)
+			}
+		}
+
+		if err := checkMulticallCall(call); err != nil {
+			return nil, fmt.Errorf("call %d: %v", i+1, err)
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, nil
+}
+
+// checkMulticallCall rejects token transfers and approvals, which would run with Multicall3
+// as msg.sender and so could never act on the wallet's tokens
+func checkMulticallCall(call util.Call3Value) error {
+	if len(call.CallData) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], call.CallData[:4])
+	if signature, ok := senderBoundSelectors[selector]; ok {
+		return fmt.Errorf("%s on %s would run with Multicall3 as msg.sender and act on Multicall3's tokens, not your wallet's; send it as a separate transaction instead",
+			signature, call.Target.Hex())
+	}
+	return nil
+}
+
+// describeCallData returns the selector of calldata, or "(no data)"
+func describeCallData(data []byte) string {
+	if len(data) < 4 {
+		if len(data) == 0 {
+			return "(no data)"
+		}
+		return hexutil.Encode(data)
+	}
+	return fmt.Sprintf("selector %s, %d bytes", hexutil.Encode(data[:4]), len(data))
+}
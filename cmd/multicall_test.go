@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMulticallCalls(t *testing.T) {
+	target := "0x00000000000000000000000000000000000000aa"
+
+	tests := []struct {
+		name      string
+		specs     []string
+		wantValue string
+		wantErr   string
+	}{
+		{"calldata only", []string{target + ":0x3e4f49e6"}, "0", ""},
+		{"with value", []string{target + ":0x1249c58b:0.5eth"}, "500000000000000000", ""},
+		{"no calls", nil, "", "at least one --call"},
+		{"missing calldata", []string{target}, "", "expected TARGET:CALLDATA"},
+		{"bad target", []string{"0x1234:0x3e4f49e6"}, "", "invalid target address"},
+		{"bad calldata", []string{target + ":zz"}, "", "invalid calldata"},
+		{"bad value", []string{target + ":0x3e4f49e6:lots"}, "", "invalid value"},
+		// ERC20 transfer(0xaa..., 1) runs with Multicall3 as msg.sender and must be rejected
+		{"erc20 transfer", []string{target + ":0xa9059cbb" + strings.Repeat("0", 64) + strings.Repeat("0", 63) + "1"}, "", "transfer(address,uint256)"},
+		{"approve", []string{target + ":0x095ea7b3"}, "", "approve(address,uint256)"},
+		{"setApprovalForAll", []string{target + ":0xa22cb465"}, "", "setApprovalForAll(address,bool)"},
+	}
+
+	for _, tc := range tests {
+		calls, err := parseMulticallCalls(tc.specs, true)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if len(calls) != 1 || !calls[0].AllowFailure || calls[0].Value.String() != tc.wantValue {
+			t.Errorf("%s: unexpected calls: %+v", tc.name, calls)
+		}
+	}
+}
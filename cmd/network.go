@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// NetworksConfigKey is the config key holding the network profiles by name
+	NetworksConfigKey = "networks"
+	// NetworkConfigKey is the config key holding the name of the default network profile
+	NetworkConfigKey = "network"
+)
+
+// networkNamePattern restricts profile names to what can be used as a config key
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// networkProfile is a named network: its RPC endpoint, the chain ID the endpoint must serve,
+// the symbol of its native currency and its block explorer
+type networkProfile struct {
+	Name     string
+	RPC      string
+	ChainID  *big.Int
+	Symbol   string
+	Explorer string
+}
+
+// selectedNetwork is the value of the global --network flag
+var selectedNetwork string
+
+// currentNetwork is the network profile of the running command, nil if it uses the plain rpc key
+var currentNetwork *networkProfile
+
+// AddNetworkFlag registers the global --network flag
+func AddNetworkFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&selectedNetwork, "network", "", "Network profile to use (see 'eth-cli config network list'; default: the 'network' config key, or the 'rpc' key)")
+}
+
+// configNetworkCmd returns the config network command that manages network profiles
+func configNetworkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Manage named network profiles",
+		Long: `Manage named network profiles, each with an RPC URL, the chain ID the RPC must serve,
+the symbol of the native currency and a block explorer.
+
+Transaction commands use the profile given with --network, or the default profile set with
+'config network use'. Without either they use the 'rpc' config key. The chain ID of the
+profile is checked against the RPC's eth_chainId before anything is signed.
+
+Examples:
+  eth-cli config network add base --rpc https://mainnet.base.org --chain-id 8453 --symbol ETH --explorer https://basescan.org
+  eth-cli config network use base
+  eth-cli transfer --network sepolia --amount 0.1eth --to 0x... --provider google --name myWallet`,
+	}
+
+	cmd.AddCommand(configNetworkAddCmd())
+	cmd.AddCommand(configNetworkListCmd())
+	cmd.AddCommand(configNetworkUseCmd())
+	cmd.AddCommand(configNetworkRemoveCmd())
+
+	return cmd
+}
+
+// configNetworkAddCmd returns the config network add subcommand
+func configNetworkAddCmd() *cobra.Command {
+	var rpcURL string
+	var chainID uint64
+	var symbol string
+	var explorer string
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace a network profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.ToLower(args[0])
+			if !networkNamePattern.MatchString(name) {
+				return fmt.Errorf("invalid network name %q: use lowercase letters, digits, '-' and '_'", args[0])
+			}
+			if rpcURL == "" {
+				return fmt.Errorf("--rpc is required")
+			}
+			if chainID == 0 {
+				return fmt.Errorf("--chain-id is required")
+			}
+
+			network := &networkProfile{
+				Name:     name,
+				RPC:      rpcURL,
+				ChainID:  new(big.Int).SetUint64(chainID),
+				Symbol:   symbol,
+				Explorer: strings.TrimRight(explorer, "/"),
+			}
+
+			// A reachable RPC on another chain is a mistake, an unreachable one may be temporary
+			if err := verifyNetworkChainID(network); err != nil {
+				if _, ok := err.(*chainIDMismatchError); ok {
+					return err
+				}
+				fmt.Printf("\033[33mWARNING: could not check the chain ID of the RPC: %v\033[0m\n", err)
+			}
+
+			key := NetworksConfigKey + "." + name
+			viper.Set(key+".rpc", network.RPC)
+			viper.Set(key+".chain-id", chainID)
+			viper.Set(key+".symbol", network.Symbol)
+			viper.Set(key+".explorer", network.Explorer)
+			if err := viper.WriteConfig(); err != nil {
+				return fmt.Errorf("error writing config: %v", err)
+			}
+
+			fmt.Printf("Saved network '%s' (chain ID %d)\n", name, chainID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "RPC URL of the network")
+	cmd.Flags().Uint64Var(&chainID, "chain-id", 0, "Chain ID the RPC must serve")
+	cmd.Flags().StringVar(&symbol, "symbol", "ETH", "Symbol of the native currency")
+	cmd.Flags().StringVar(&explorer, "explorer", "", "Block explorer URL (e.g. https://basescan.org)")
+
+	return cmd
+}
+
+// configNetworkListCmd returns the config network list subcommand
+func configNetworkListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the network profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := networkNames()
+			if len(names) == 0 {
+				fmt.Println("No network profiles configured")
+				return nil
+			}
+
+			defaultNetwork := viper.GetString(NetworkConfigKey)
+			fmt.Printf("  %-12s %-10s %-8s %-40s %s\n", "Name", "Chain ID", "Symbol", "RPC", "Explorer")
+			for _, name := range names {
+				network, err := loadNetwork(name)
+				if err != nil {
+					fmt.Printf("  %-12s \033[1;31m%v\033[0m\n", name, err)
+					continue
+				}
+				marker := " "
+				if name == defaultNetwork {
+					marker = "*"
+				}
+				fmt.Printf("%s %-12s %-10s %-8s %-40s %s\n", marker, name, network.ChainID, network.Symbol, network.RPC, network.Explorer)
+			}
+			return nil
+		},
+	}
+}
+
+// configNetworkUseCmd returns the config network use subcommand
+func configNetworkUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the default network profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.ToLower(args[0])
+			if _, err := loadNetwork(name); err != nil {
+				return err
+			}
+
+			viper.Set(NetworkConfigKey, name)
+			if err := viper.WriteConfig(); err != nil {
+				return fmt.Errorf("error writing config: %v", err)
+			}
+
+			fmt.Printf("Default network set to '%s'\n", name)
+			return nil
+		},
+	}
+}
+
+// configNetworkRemoveCmd returns the config network remove subcommand
+func configNetworkRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a network profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.ToLower(args[0])
+			if !viper.IsSet(NetworksConfigKey + "." + name) {
+				return fmt.Errorf("network '%s' not found in configuration", name)
+			}
+
+			// The default network must not point to a removed profile
+			if viper.GetString(NetworkConfigKey) == name {
+				if err := deleteConfigKey(NetworkConfigKey); err != nil {
+					return fmt.Errorf("error writing config: %v", err)
+				}
+			}
+			if err := deleteConfigKey(NetworksConfigKey + "." + name); err != nil {
+				return fmt.Errorf("error writing config: %v", err)
+			}
+
+			fmt.Printf("Removed network '%s'\n", name)
+			return nil
+		},
+	}
+}
+
+// networkNames returns the names of the configured network profiles, sorted
+func networkNames() []string {
+	var names []string
+	for name := range viper.GetStringMap(NetworksConfigKey) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadNetwork reads a network profile from the config
+func loadNetwork(name string) (*networkProfile, error) {
+	key := NetworksConfigKey + "." + strings.ToLower(name)
+	if !viper.IsSet(key) {
+		available := "none configured, add one with 'eth-cli config network add'"
+		if names := networkNames(); len(names) > 0 {
+			available = strings.Join(names, ", ")
+		}
+		return nil, fmt.Errorf("network '%s' not found in configuration (available: %s)", name, available)
+	}
+
+	network := &networkProfile{
+		Name:     strings.ToLower(name),
+		RPC:      viper.GetString(key + ".rpc"),
+		Symbol:   viper.GetString(key + ".symbol"),
+		Explorer: viper.GetString(key + ".explorer"),
+	}
+	if network.RPC == "" {
+		return nil, fmt.Errorf("network '%s' has no RPC URL", name)
+	}
+	chainID := viper.GetUint64(key + ".chain-id")
+	if chainID == 0 {
+		return nil, fmt.Errorf("network '%s' has no chain ID", name)
+	}
+	network.ChainID = new(big.Int).SetUint64(chainID)
+	return network, nil
+}
+
+// activeNetwork returns the network profile selected with --network or the network config
+// key, or nil if there is none and the rpc config key should be used
+func activeNetwork() (*networkProfile, error) {
+	name := selectedNetwork
+	if name == "" {
+		name = viper.GetString(NetworkConfigKey)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return loadNetwork(name)
+}
+
+// chainIDMismatchError reports a chain ID that is not the one of a network profile
+type chainIDMismatchError struct {
+	Network  string
+	Expected *big.Int
+	Actual   *big.Int
+	Source   string // what has the wrong chain ID, e.g. "--chain-id"; empty for the RPC of the network
+}
+
+func (e *chainIDMismatchError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("the RPC of network '%s' serves chain ID %s, not chain ID %s: check the RPC URL of the network", e.Network, e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("%s is for chain ID %s, not for network '%s' (chain ID %s)", e.Source, e.Actual, e.Network, e.Expected)
+}
+
+// verifyNetworkChainID checks that the RPC of a network serves the chain ID of the network
+func verifyNetworkChainID(network *networkProfile) error {
+	client, err := ethclient.Dial(network.RPC)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the RPC of network '%s': %v", network.Name, err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get the chain ID of network '%s': %v", network.Name, err)
+	}
+	if chainID.Cmp(network.ChainID) != 0 {
+		return &chainIDMismatchError{Network: network.Name, Expected: network.ChainID, Actual: chainID}
+	}
+	return nil
+}
+
+// checkTxNetwork checks the chain ID of a transaction against the current network, or without
+// network access against the network selected with --network, for commands that may sign
+// offline. A nil chain ID is not checked.
+func checkTxNetwork(chainID *big.Int) error {
+	if currentNetwork == nil {
+		if selectedNetwork == "" {
+			return nil
+		}
+		initConfig()
+		network, err := loadNetwork(selectedNetwork)
+		if err != nil {
+			return err
+		}
+		currentNetwork = network
+	}
+	if chainID != nil && chainID.Cmp(currentNetwork.ChainID) != 0 {
+		return &chainIDMismatchError{Network: currentNetwork.Name, Expected: currentNetwork.ChainID, Actual: chainID, Source: "the transaction"}
+	}
+	return nil
+}
+
+// checkRawTxNetwork checks the chain ID of a built transaction against the current network
+// right before it is signed
+func checkRawTxNetwork(rawTx string) error {
+	tx, err := util.DecodeSignedTransaction(rawTx)
+	if err != nil {
+		return err
+	}
+	return checkTxNetwork(util.TransactionChainID(tx))
+}
+
+// nativeSymbol returns the symbol of the native currency of the current network
+func nativeSymbol() string {
+	if currentNetwork != nil && currentNetwork.Symbol != "" {
+		return currentNetwork.Symbol
+	}
+	return "ETH"
+}
+
+// printExplorerLink prints the block explorer link of a transaction on the current network
+func printExplorerLink(txHash string) {
+	if currentNetwork == nil || currentNetwork.Explorer == "" {
+		return
+	}
+	fmt.Printf("Explorer: %s/tx/%s\n", strings.TrimRight(currentNetwork.Explorer, "/"), txHash)
+}
+
+// dryRunChainID returns the chain ID of a dry run: --chain-id, or the chain ID of the current
+// network when the flag is not given
+func dryRunChainID(cmd *cobra.Command) (uint64, error) {
+	chainID, _ := cmd.Flags().GetUint64("chain-id")
+	if currentNetwork == nil {
+		return chainID, nil
+	}
+	if !cmd.Flags().Changed("chain-id") {
+		return currentNetwork.ChainID.Uint64(), nil
+	}
+	if chainID != currentNetwork.ChainID.Uint64() {
+		return 0, &chainIDMismatchError{Network: currentNetwork.Name, Expected: currentNetwork.ChainID, Actual: new(big.Int).SetUint64(chainID), Source: "--chain-id"}
+	}
+	return chainID, nil
+}
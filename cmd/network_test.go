@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// useNetworkConfig replaces the config with the given settings for the duration of a test
+func useNetworkConfig(t *testing.T, settings map[string]interface{}) {
+	viper.Reset()
+	for key, value := range settings {
+		viper.Set(key, value)
+	}
+	t.Cleanup(func() {
+		viper.Reset()
+		selectedNetwork = ""
+		currentNetwork = nil
+	})
+}
+
+func TestActiveNetwork(t *testing.T) {
+	useNetworkConfig(t, map[string]interface{}{
+		"networks.base.rpc":         "https://mainnet.base.org",
+		"networks.base.chain-id":    8453,
+		"networks.base.symbol":      "ETH",
+		"networks.base.explorer":    "https://basescan.org",
+		"networks.polygon.rpc":      "https://polygon-rpc.com",
+		"networks.polygon.chain-id": 137,
+		"networks.polygon.symbol":   "POL",
+		"networks.broken.rpc":       "https://example.com",
+		NetworkConfigKey:            "polygon",
+	})
+
+	if names := networkNames(); strings.Join(names, ",") != "base,broken,polygon" {
+		t.Errorf("Unexpected network names: %v", names)
+	}
+
+	network, err := activeNetwork()
+	if err != nil || network.Name != "polygon" || network.ChainID.Int64() != 137 || network.Symbol != "POL" {
+		t.Errorf("Unexpected default network: %+v, %v", network, err)
+	}
+
+	selectedNetwork = "Base"
+	network, err = activeNetwork()
+	if err != nil || network.Name != "base" || network.RPC != "https://mainnet.base.org" || network.Explorer != "https://basescan.org" {
+		t.Errorf("Unexpected selected network: %+v, %v", network, err)
+	}
+
+	for _, name := range []string{"broken", "arbitrum"} {
+		selectedNetwork = name
+		if _, err := activeNetwork(); err == nil {
+			t.Errorf("Expected error for %q, but got none", name)
+		}
+	}
+
+	selectedNetwork = ""
+	viper.Set(NetworkConfigKey, "")
+	if network, err := activeNetwork(); network != nil || err != nil {
+		t.Errorf("Expected no network, got %+v, %v", network, err)
+	}
+}
+
+// chainIDServer is an RPC answering eth_chainId with 8453
+func chainIDServer(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x2105"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestVerifyNetworkChainID(t *testing.T) {
+	rpcURL := chainIDServer(t)
+
+	if err := verifyNetworkChainID(&networkProfile{Name: "base", RPC: rpcURL, ChainID: big.NewInt(8453)}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err := verifyNetworkChainID(&networkProfile{Name: "mainnet", RPC: rpcURL, ChainID: big.NewInt(1)})
+	if _, ok := err.(*chainIDMismatchError); !ok {
+		t.Errorf("Expected a chain ID mismatch, got %v", err)
+	}
+}
+
+func TestDryRunChainID(t *testing.T) {
+	useNetworkConfig(t, nil)
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Uint64("chain-id", 1, "")
+		cmd.Flags().Parse(args)
+		return cmd
+	}
+
+	if chainID, err := dryRunChainID(newCmd()); err != nil || chainID != 1 {
+		t.Errorf("Unexpected chain ID without a network: %d, %v", chainID, err)
+	}
+
+	currentNetwork = &networkProfile{Name: "base", ChainID: big.NewInt(8453)}
+	if chainID, err := dryRunChainID(newCmd()); err != nil || chainID != 8453 {
+		t.Errorf("Unexpected chain ID of the network: %d, %v", chainID, err)
+	}
+	if chainID, err := dryRunChainID(newCmd("--chain-id", "8453")); err != nil || chainID != 8453 {
+		t.Errorf("Unexpected chain ID: %d, %v", chainID, err)
+	}
+	if _, err := dryRunChainID(newCmd("--chain-id", "1")); err == nil {
+		t.Errorf("Expected error for a --chain-id of another network, but got none")
+	} else if _, ok := err.(*chainIDMismatchError); !ok {
+		t.Errorf("Expected a chain ID mismatch, got %v", err)
+	}
+}
+
+func TestCheckTxNetwork(t *testing.T) {
+	useNetworkConfig(t, nil)
+
+	if err := checkTxNetwork(big.NewInt(1)); err != nil {
+		t.Errorf("Unexpected error without a network: %v", err)
+	}
+
+	currentNetwork = &networkProfile{Name: "sepolia", ChainID: big.NewInt(11155111)}
+	if err := checkTxNetwork(big.NewInt(11155111)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := checkTxNetwork(nil); err != nil {
+		t.Errorf("Unexpected error without a chain ID: %v", err)
+	}
+	if err := checkTxNetwork(big.NewInt(1)); err == nil {
+		t.Errorf("Expected error for a transaction of another chain, but got none")
+	}
+	if symbol := nativeSymbol(); symbol != "ETH" {
+		t.Errorf("Unexpected default symbol: %s", symbol)
+	}
+}
+
+func TestInitDryRunTxConfig(t *testing.T) {
+	rpcURL := chainIDServer(t)
+	useNetworkConfig(t, map[string]interface{}{
+		"networks.base.rpc":         rpcURL,
+		"networks.base.chain-id":    8453,
+		"networks.sepolia.rpc":      "http://127.0.0.1:1",
+		"networks.sepolia.chain-id": 11155111,
+		"networks.wrong.rpc":        rpcURL,
+		"networks.wrong.chain-id":   1,
+	})
+	// initConfig reads the config file of the home directory
+	t.Setenv("HOME", t.TempDir())
+
+	for _, name := range []string{"base", "sepolia"} {
+		selectedNetwork = name
+		currentNetwork = nil
+		if _, err := initDryRunTxConfig(); err != nil {
+			t.Errorf("Unexpected error for %s: %v", name, err)
+		} else if currentNetwork == nil || currentNetwork.Name != name {
+			t.Errorf("Unexpected network for %s: %+v", name, currentNetwork)
+		}
+	}
+
+	selectedNetwork = "wrong"
+	currentNetwork = nil
+	if _, err := initDryRunTxConfig(); err == nil {
+		t.Errorf("Expected error for an RPC of another chain, but got none")
+	}
+}
+
+func TestCheckRawTxNetwork(t *testing.T) {
+	useNetworkConfig(t, nil)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	fees := util.TxParams{Type: util.TxTypeLegacy, Fees: util.FeeParams{MaxFeePerGas: big.NewInt(1e9)}}
+	rawTx, err := util.CreateRawTx(&to, big.NewInt(1), nil, 0, fees, 21000, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	currentNetwork = &networkProfile{Name: "mainnet", ChainID: big.NewInt(1)}
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	currentNetwork = &networkProfile{Name: "sepolia", ChainID: big.NewInt(11155111)}
+	if err := checkRawTxNetwork(rawTx); err == nil {
+		t.Errorf("Expected error for a transaction of another chain, but got none")
+	}
+}
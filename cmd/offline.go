@@ -0,0 +1,639 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// DefaultQRInterval is how long each frame of an animated QR code is shown
+const DefaultQRInterval = 500 * time.Millisecond
+
+// PrepareCmd creates the command that builds an unsigned transaction envelope on an online machine
+func PrepareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prepare",
+		Short: "Prepare an unsigned transaction envelope for offline signing",
+		Long: `Prepare an unsigned transaction on an online machine and write it as a JSON envelope
+with the chain ID, nonce, fees, decoded intent and token metadata, to be reviewed and signed
+on an air-gapped machine with sign-envelope and submitted with broadcast.
+
+The sender is --from, or the wallet given by --provider/--name or --file (no password needed,
+watch-only wallets work). The transaction is:
+  an ETH transfer          --to and --amount (e.g. 0.1eth)
+  an ERC20 transfer        --token, --to and --amount in token units
+  an ERC20 approval        --token, --approve, --to (the spender) and --amount in token units
+  a contract call          --to, --data (calldata hex) and optionally --value
+
+The envelope goes to --output, or to stdout. --qr shows it as an animated QR code instead.
+
+Examples:
+  eth-cli prepare --from 0xCold --to 0xFriend --amount 0.5eth --output tx.json
+  eth-cli prepare -f ./watch-only.json --token 0xUSDC --to 0xFriend --amount 100 --qr
+  eth-cli prepare --from 0xCold --token 0xUSDC --approve --to 0xRouter --amount 50 --output approve.json`,
+		RunE: runPrepare,
+	}
+
+	cmd.Flags().String("from", "", "Sender address")
+	cmd.Flags().StringP("provider", "p", "", "Key provider of the sender wallet (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().StringP("to", "t", "", "Recipient, spender (--approve) or called contract (--data)")
+	cmd.Flags().StringP("amount", "a", "", "Amount of ETH (e.g., 0.1eth) or of tokens with --token")
+	cmd.Flags().String("token", "", "ERC20 token contract address")
+	cmd.Flags().Bool("approve", false, "Approve --to to spend --amount of --token instead of transferring")
+	cmd.Flags().String("data", "", "Calldata hex of a contract call")
+	cmd.Flags().String("value", "0", "ETH sent with a contract call (e.g., 0.1eth)")
+	addFeeFlags(cmd)
+	cmd.Flags().Uint64("gas-limit", 0, "Gas limit (default: estimated)")
+	cmd.Flags().Uint64("nonce", 0, "Nonce (default: the pending nonce of the sender)")
+	addEnvelopeOutputFlags(cmd)
+
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// SignEnvelopeCmd creates the command that reviews and signs an envelope on an offline machine
+func SignEnvelopeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-envelope",
+		Short: "Review and sign a transaction envelope (no network access needed)",
+		Long: `Review and sign a transaction envelope created by prepare, without network access.
+
+The envelope is checked before it is shown: its fields and intent must match its raw
+transaction, so the review shows exactly what is signed. Token symbols and decimals come
+from the online machine and are marked as such. The key must belong to the envelope sender.
+
+--envelope is a JSON file, or a file of animated QR frames (one ETHCLI:... frame per line,
+in any order) as read by a QR scanner; "-" reads from stdin. The signed envelope goes to
+--output, or to stdout; --qr shows it as an animated QR code instead.
+
+Examples:
+  eth-cli sign-envelope --envelope tx.json -f ./wallet.json --output tx.signed.json
+  eth-cli sign-envelope --envelope frames.txt -p keychain -n myWallet --qr`,
+		RunE: runSignEnvelope,
+	}
+
+	cmd.Flags().String("envelope", "", "Envelope JSON or QR frames file, or - for stdin")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().BoolP("yes", "y", false, "Sign without asking for confirmation")
+	addEnvelopeOutputFlags(cmd)
+
+	cmd.MarkFlagRequired("envelope")
+
+	return cmd
+}
+
+// BroadcastCmd creates the command that submits a signed transaction
+func BroadcastCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast",
+		Short: "Broadcast a signed transaction or transaction envelope",
+		Long: `Broadcast a transaction envelope signed with sign-envelope, or any signed transaction.
+
+With --envelope, the signed transaction is checked against the envelope (same transaction,
+signed by the envelope sender). --envelope is a JSON file, or a file of animated QR frames
+(one ETHCLI:... frame per line), or - for stdin.
+
+With --signed-tx, the transaction is given as hex (e.g. the output of sign-raw-tx) or as a
+file holding the hex.
+
+The node's chain ID is checked against the transaction's before it is sent.
+
+Examples:
+  eth-cli broadcast --envelope tx.signed.json
+  eth-cli broadcast --envelope frames.txt --sync
+  eth-cli broadcast --signed-tx 0x02f8b1...
+  eth-cli broadcast --signed-tx ./signed.txt --sync --wait-timeout 30m`,
+		RunE: runBroadcast,
+	}
+
+	cmd.Flags().String("envelope", "", "Signed envelope JSON or QR frames file, or - for stdin")
+	cmd.Flags().String("signed-tx", "", "Signed transaction hex, or a file containing it")
+	cmd.Flags().BoolP("yes", "y", false, "Broadcast without asking for confirmation")
+	cmd.Flags().Bool("sync", false, "Wait for transaction confirmation")
+	cmd.Flags().Duration("wait-timeout", DefaultReceiptWaitTimeout, "How long to wait for confirmation before giving up")
+
+	return cmd
+}
+
+// addEnvelopeOutputFlags adds the flags selecting where an envelope is written
+func addEnvelopeOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().String("output", "", "Write the envelope to this file (default: stdout)")
+	cmd.Flags().Bool("qr", false, "Show the envelope as an animated QR code")
+	cmd.Flags().Int("qr-frame-size", util.DefaultQRFrameSize, "Characters per animated QR frame")
+	cmd.Flags().Duration("qr-interval", DefaultQRInterval, "How long each animated QR frame is shown")
+}
+
+func runPrepare(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	to, _ := cmd.Flags().GetString("to")
+	amountStr, _ := cmd.Flags().GetString("amount")
+	tokenAddress, _ := cmd.Flags().GetString("token")
+	approve, _ := cmd.Flags().GetBool("approve")
+	dataHex, _ := cmd.Flags().GetString("data")
+	valueStr, _ := cmd.Flags().GetString("value")
+	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
+
+	// Resolve the sender
+	if from != "" && (provider != "" || name != "" || filePath != "") {
+		return fmt.Errorf("--from cannot be combined with --provider/--name or --file")
+	}
+	if from == "" {
+		if (provider != "" || name != "") && filePath != "" {
+			return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+		}
+		if provider == "" && filePath == "" {
+			return fmt.Errorf("either --from, --provider or --file must be specified")
+		}
+		selector, err := getAccountSelector(cmd)
+		if err != nil {
+			return err
+		}
+		if from, err = getWalletAddress(filePath, provider, name, selector); err != nil {
+			return fmt.Errorf("failed to get wallet address: %v", err)
+		}
+	}
+	if !common.IsHexAddress(from) {
+		return fmt.Errorf("invalid from address: %s", from)
+	}
+	fromAddr := common.HexToAddress(from)
+
+	if !common.IsHexAddress(to) {
+		return fmt.Errorf("invalid 'to' address format: %s", to)
+	}
+	if tokenAddress != "" && dataHex != "" {
+		return fmt.Errorf("--token and --data are mutually exclusive")
+	}
+	if approve && tokenAddress == "" {
+		return fmt.Errorf("--approve requires --token")
+	}
+	if dataHex == "" && amountStr == "" {
+		return fmt.Errorf("--amount is required unless --data is specified")
+	}
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+
+	// Build the transaction of the selected intent
+	txTo := common.HexToAddress(to)
+	value := big.NewInt(0)
+	var data []byte
+	var token *util.EnvelopeToken
+	switch {
+	case tokenAddress != "":
+		if !common.IsHexAddress(tokenAddress) {
+			return fmt.Errorf("invalid token address format: %s", tokenAddress)
+		}
+		tokenAddr := common.HexToAddress(tokenAddress)
+		infos, err := fetchTokenInfo(client, []common.Address{tokenAddr}, nil, nil)
+		if err != nil {
+			return err
+		}
+		amount, err := util.ParseTokenAmount(amountStr, infos[0].Decimals)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %v", err)
+		}
+		if approve {
+			data = util.EncodeERC20Approve(to, amount)
+		} else {
+			data = util.EncodeERC20Transfer(to, amount)
+		}
+		token = &util.EnvelopeToken{Address: tokenAddr.Hex(), Symbol: infos[0].Symbol, Decimals: infos[0].Decimals}
+		txTo = tokenAddr
+
+	case dataHex != "":
+		if data, err = hexutil.Decode(dataHex); err != nil {
+			return fmt.Errorf("invalid --data: %v", err)
+		}
+		if value, err = parseEthAmount(valueStr); err != nil {
+			return fmt.Errorf("invalid value: %v", err)
+		}
+
+	default:
+		if value, err = parseEthAmount(amountStr); err != nil {
+			return fmt.Errorf("invalid amount: %v", err)
+		}
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	nonce, err := util.GetNonce(client, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if cmd.Flags().Changed("nonce") {
+		nonce, _ = cmd.Flags().GetUint64("nonce")
+	}
+
+	txParams, err := resolveTxParams(cmd, client, false)
+	if err != nil {
+		return err
+	}
+	if gasLimit == 0 {
+		estimated, err := util.EstimateGas(client, fromAddr, &txTo, value, data)
+		if err != nil {
+			return fmt.Errorf("failed to estimate gas: %v", err)
+		}
+		gasLimit = uint64(float64(estimated) * GasEstimationBuffer)
+	}
+
+	envelope, err := util.NewTxEnvelope(fromAddr, &txTo, value, data, nonce, txParams, gasLimit, chainID, token)
+	if err != nil {
+		return err
+	}
+
+	// Details go to stderr so the envelope alone can be piped from stdout
+	printEnvelope(os.Stderr, envelope)
+	return writeEnvelope(cmd, envelope)
+}
+
+func runSignEnvelope(cmd *cobra.Command, args []string) error {
+	envelopePath, _ := cmd.Flags().GetString("envelope")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	// Determine which account to derive
+	selector, err := getAccountSelector(cmd)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := readEnvelope(envelopePath)
+	if err != nil {
+		return err
+	}
+	if envelope.SignedTx != "" {
+		return fmt.Errorf("the envelope is already signed (%s)", envelope.TxHash)
+	}
+	if err := checkTxNetwork(envelope.ChainIDInt()); err != nil {
+		return err
+	}
+
+	// The review and the wallet prompts go to stderr so the signed envelope alone can be
+	// redirected from stdout
+	restoreStdout := stdoutToStderr()
+	defer restoreStdout()
+
+	// Display the envelope for review before the wallet is unlocked
+	printEnvelope(os.Stderr, envelope)
+
+	if !autoConfirm {
+		fmt.Fprint(os.Stderr, "Sign this transaction? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Fprintln(os.Stderr, "Signing cancelled.")
+			return nil
+		}
+	}
+
+	// Get private key from provider or file
+	var privateKey string
+	var fromAddress string
+	if filePath != "" {
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+	if !strings.EqualFold(fromAddress, envelope.From) {
+		return fmt.Errorf("the unlocked key belongs to %s, not to the envelope sender %s", fromAddress, envelope.From)
+	}
+
+	signedTx, err := util.SignTransactionWithChainID(envelope.RawTx, privateKey, envelope.ChainIDInt())
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	if err := envelope.SetSignedTx(signedTx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Transaction signed: %s\n", envelope.TxHash)
+	restoreStdout()
+	return writeEnvelope(cmd, envelope)
+}
+
+// stdoutToStderr sends what is printed to stdout, such as the password prompts of the wallet, to
+// stderr until the returned function is called
+func stdoutToStderr() (restore func()) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return func() {
+		os.Stdout = stdout
+	}
+}
+
+func runBroadcast(cmd *cobra.Command, args []string) error {
+	envelopePath, _ := cmd.Flags().GetString("envelope")
+	signedTxArg, _ := cmd.Flags().GetString("signed-tx")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	sync, _ := cmd.Flags().GetBool("sync")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	if (envelopePath == "") == (signedTxArg == "") {
+		return fmt.Errorf("exactly one of --envelope or --signed-tx must be specified")
+	}
+
+	var envelope *util.TxEnvelope
+	var signedTxHex string
+	var tx *types.Transaction
+	var err error
+	if envelopePath != "" {
+		if envelope, err = readEnvelope(envelopePath); err != nil {
+			return err
+		}
+		if tx, err = envelope.SignedTransaction(); err != nil {
+			return err
+		}
+		signedTxHex = envelope.SignedTx
+	} else {
+		if signedTxHex, err = readHexOrFile(signedTxArg, "--signed-tx"); err != nil {
+			return err
+		}
+		if tx, err = util.DecodeSignedTransaction(signedTxHex); err != nil {
+			return err
+		}
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction sender: %v", err)
+	}
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	if !tx.Protected() {
+		fmt.Println("\033[33mWARNING: the transaction is not replay-protected, it is valid on every chain\033[0m")
+	} else if chainID.Cmp(tx.ChainId()) != 0 {
+		return fmt.Errorf("the transaction is for chain ID %s, but the RPC node is on chain ID %s", tx.ChainId(), chainID)
+	}
+
+	if envelope != nil {
+		printEnvelope(os.Stdout, envelope)
+	} else {
+		printSignedTx(tx, sender)
+	}
+	fmt.Printf("Transaction Hash: %s\n", tx.Hash().Hex())
+
+	if !autoConfirm {
+		fmt.Print("Broadcast this transaction? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Transaction broadcasting cancelled.")
+			return nil
+		}
+	}
+
+	txHash, err := util.BroadcastTransaction(signedTxHex, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast transaction: %v", err)
+	}
+	fmt.Printf("Transaction submitted: %s\n", txHash)
+	printExplorerLink(txHash)
+
+	if sync {
+		fmt.Println("Waiting for transaction confirmation...")
+		receipt, err := waitForReceipt(client, txHash, waitTimeout)
+		if err != nil {
+			return err
+		}
+		printReceiptSummary(receipt)
+	}
+	return nil
+}
+
+// readHexOrFile returns hex given directly or stored in a file; name is the flag or
+// argument the value came from, for error messages
+func readHexOrFile(arg string, name string) (string, error) {
+	value := strings.TrimSpace(arg)
+	if _, err := hexutil.Decode(value); err != nil {
+		data, readErr := util.LoadFromFileSystem(value)
+		if readErr != nil {
+			return "", fmt.Errorf("%s is neither 0x-prefixed hex nor a readable file: %v", name, readErr)
+		}
+		value = strings.TrimSpace(string(data))
+	}
+
+	if _, err := hexutil.Decode(value); err != nil {
+		return "", fmt.Errorf("invalid hex in %s: %v", name, err)
+	}
+	return value, nil
+}
+
+// printSignedTx prints the fields of a signed transaction before it is broadcast
+func printSignedTx(tx *types.Transaction, sender common.Address) {
+	fmt.Println("Transaction Details:")
+	if tx.Protected() {
+		fmt.Printf("Chain ID: %s\n", tx.ChainId())
+	}
+	fmt.Printf("From: %s\n", sender.Hex())
+	if tx.To() != nil {
+		fmt.Printf("To: %s\n", tx.To().Hex())
+	} else {
+		fmt.Println("To: (contract creation)")
+	}
+	fmt.Printf("Value: %s %s\n", formatEther(tx.Value()), nativeSymbol())
+	fmt.Printf("Data: %d bytes\n", len(tx.Data()))
+	fmt.Printf("Nonce: %d\n", tx.Nonce())
+	fmt.Printf("Gas Limit: %d\n", tx.Gas())
+	printFeeDetails(txParamsFromTransaction(tx), tx.Gas())
+}
+
+// readEnvelope reads an envelope from a JSON file or a file of animated QR frames ("-" for stdin)
+func readEnvelope(path string) (*util.TxEnvelope, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = util.LoadFromFileSystem(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read envelope: %v", err)
+	}
+
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, util.QRFramePrefix+":") {
+		if data, err = util.DecodeQRFrames(strings.Split(trimmed, "\n")); err != nil {
+			return nil, err
+		}
+	}
+	return util.ParseTxEnvelope(data)
+}
+
+// writeEnvelope writes the envelope to --output or stdout, or shows it as an animated QR code with --qr
+func writeEnvelope(cmd *cobra.Command, envelope *util.TxEnvelope) error {
+	outputPath, _ := cmd.Flags().GetString("output")
+	showQR, _ := cmd.Flags().GetBool("qr")
+	frameSize, _ := cmd.Flags().GetInt("qr-frame-size")
+	interval, _ := cmd.Flags().GetDuration("qr-interval")
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope: %v", err)
+	}
+
+	if outputPath != "" {
+		if err := util.SaveToFileSystem(data, outputPath); err != nil {
+			return fmt.Errorf("failed to save envelope: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Envelope saved to %s\n", outputPath)
+	} else if !showQR {
+		fmt.Println(string(data))
+	}
+
+	if showQR {
+		// Compact JSON keeps the number of frames down
+		compact, err := json.Marshal(envelope)
+		if err != nil {
+			return fmt.Errorf("failed to encode envelope: %v", err)
+		}
+		frames := util.EncodeQRFrames(compact, frameSize)
+		if len(frames) > util.MaxQRFrames {
+			return fmt.Errorf("envelope needs %d QR frames, more than the %d a scanner accepts; use a larger --qr-frame-size or a file", len(frames), util.MaxQRFrames)
+		}
+		showAnimatedQR(frames, interval)
+	}
+	return nil
+}
+
+// showAnimatedQR cycles through the frames of an animated QR code until Enter is pressed
+func showAnimatedQR(frames []string, interval time.Duration) {
+	if len(frames) == 1 {
+		fmt.Println(util.GenerateQRCode(frames[0]))
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		bufio.NewReader(os.Stdin).ReadString('\n')
+		close(done)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := 0; ; i = (i + 1) % len(frames) {
+		fmt.Print("\033[H\033[2J")
+		fmt.Println(util.GenerateQRCode(frames[i]))
+		fmt.Printf("Frame %d/%d, press Enter when the scanner has read every frame\n", i+1, len(frames))
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// printEnvelope prints the review of an envelope: network, intent, transaction fields and fees
+func printEnvelope(out io.Writer, envelope *util.TxEnvelope) {
+	txParams, _ := envelope.TxParams()
+	value, _ := new(big.Int).SetString(envelope.Value, 10)
+
+	fmt.Fprintln(out, "Transaction Envelope:")
+	fmt.Fprintf(out, "Chain ID: %s\n", envelope.ChainID)
+	fmt.Fprintf(out, "From: %s\n", envelope.From)
+	fmt.Fprintf(out, "Intent: %s\n", describeEnvelopeIntent(envelope))
+	if envelope.To != "" {
+		fmt.Fprintf(out, "To: %s\n", envelope.To)
+	} else {
+		fmt.Fprintln(out, "To: (contract creation)")
+	}
+	fmt.Fprintf(out, "Value: %s %s\n", formatEther(value), nativeSymbol())
+	fmt.Fprintf(out, "Data: %d bytes\n", (len(envelope.Data)-2)/2)
+	fmt.Fprintf(out, "Nonce: %d\n", envelope.Nonce)
+	fmt.Fprintf(out, "Gas Limit: %d\n", envelope.Fees.GasLimit)
+	for _, detail := range feeDetails(txParams, envelope.Fees.GasLimit) {
+		fmt.Fprintf(out, "%s: %s\n", detail[0], detail[1])
+	}
+	if envelope.Token != nil {
+		fmt.Fprintf(out, "Token: %s (symbol %s and %d decimals as reported by the online machine)\n", envelope.Token.Address, envelope.Token.Symbol, envelope.Token.Decimals)
+	}
+}
+
+// describeEnvelopeIntent describes the intent of an envelope in words
+func describeEnvelopeIntent(envelope *util.TxEnvelope) string {
+	intent := envelope.Intent
+	amount, _ := new(big.Int).SetString(intent.Amount, 10)
+
+	tokenAmount := func() string {
+		if envelope.Token == nil {
+			return amount.String() + " base units of " + envelope.To
+		}
+		return util.FormatTokenAmount(amount, envelope.Token.Decimals) + " " + envelope.Token.Symbol
+	}
+
+	switch intent.Action {
+	case util.IntentTransferETH:
+		return fmt.Sprintf("transfer %s %s to %s", formatEther(amount), nativeSymbol(), intent.Recipient)
+	case util.IntentTransferERC20:
+		return fmt.Sprintf("transfer %s to %s", tokenAmount(), intent.Recipient)
+	case util.IntentApproveERC20:
+		return fmt.Sprintf("approve %s to spend %s", intent.Recipient, tokenAmount())
+	case util.IntentDeploy:
+		return "deploy a contract"
+	default:
+		if intent.Selector == "" {
+			return fmt.Sprintf("call %s", envelope.To)
+		}
+		return fmt.Sprintf("call %s (selector %s)", envelope.To, intent.Selector)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadHexOrFile(t *testing.T) {
+	const signedTx = "0x02f8b00103843b9aca008506fc23ac0082fde89400000000000000000000000000000000000000cc80b844a9059cbb00000000000000000000000000000000000000000000000000000000000000bb00000000000000000000000000000000000000000000000000000000000f4240c001a0c65e54a1ec4500b7e5ecfdd66c3b0c7a437d96f67dabc77b39f10018c35b01afa03b3536cca97ea49c49a73560ae6f04f54f1c3d986b75c7c488cb56d700b4e252"
+
+	path := filepath.Join(t.TempDir(), "signed.txt")
+	if err := os.WriteFile(path, []byte(signedTx+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write signed transaction: %v", err)
+	}
+
+	for _, arg := range []string{signedTx, " " + signedTx + "\n", path} {
+		got, err := readHexOrFile(arg, "--signed-tx")
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", arg, err)
+			continue
+		}
+		if got != signedTx {
+			t.Errorf("Unexpected signed transaction for %q: %s", arg, got)
+		}
+	}
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.txt")
+	if err := os.WriteFile(invalidPath, []byte("not hex"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	for _, arg := range []string{"0xzz", filepath.Join(t.TempDir(), "missing.txt"), invalidPath} {
+		if _, err := readHexOrFile(arg, "--signed-tx"); err == nil {
+			t.Errorf("Expected error for %q, but got none", arg)
+		}
+	}
+}
+
+func TestStdoutToStderr(t *testing.T) {
+	stdout := os.Stdout
+	restore := stdoutToStderr()
+	if os.Stdout != os.Stderr {
+		t.Errorf("Expected stdout to be redirected to stderr")
+	}
+	restore()
+	if os.Stdout != stdout {
+		t.Errorf("Expected stdout to be restored")
+	}
+	// Restoring twice, as runSignEnvelope does with defer, keeps stdout
+	restore()
+	if os.Stdout != stdout {
+		t.Errorf("Expected stdout to stay restored")
+	}
+}
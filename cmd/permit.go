@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/spf13/cobra"
+)
+
+// Permit types supported by the permit command
+const (
+	PermitTypeEIP2612            = "eip2612"
+	PermitTypeSingle             = "permit-single"
+	PermitTypeTransferFrom       = "permit-transfer-from"
+	DefaultPermitDeadline        = 30 * time.Minute
+	DefaultPermit2Expiration     = 30 * 24 * time.Hour
+	maxPermit2NonceWordsSearched = 256
+)
+
+// permitDomainVersions are the versions tried when a token has no version() function
+var permitDomainVersions = []string{"1", "2"}
+
+// PermitCmd creates the command that signs EIP-2612 and Permit2 permits
+func PermitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "permit",
+		Short: "Sign an EIP-2612 permit or a Uniswap Permit2 permit (gasless approval)",
+		Long: `Sign a gasless token approval and print the signature with its v, r and s values,
+to be submitted by the spender. Nothing is sent from the wallet.
+
+--type selects the permit:
+  eip2612               Permit(owner,spender,value,nonce,deadline) of a token implementing
+                        EIP-2612. The nonce comes from nonces(owner) and the domain from name(),
+                        version() and the chain ID, checked against the token's DOMAIN_SEPARATOR.
+  permit-single         Permit2 PermitSingle, setting the Permit2 allowance of --spender until
+                        --expiration. The nonce comes from Permit2's allowance(owner,token,spender).
+  permit-transfer-from  Permit2 PermitTransferFrom (signature transfer), letting --spender transfer
+                        up to --amount once. The first unused unordered nonce is picked.
+
+Permit2 can only move tokens the wallet approved to Permit2 (` + util.Permit2Address.Hex() + `)
+with approveERC20; a warning is shown when that allowance is too low.
+
+--amount is in token units, or "max". --deadline and --expiration take a duration from now
+(e.g. 30m, 720h) or a unix timestamp.
+
+Examples:
+  eth-cli permit -p google -n myWallet --token 0xUSDC --spender 0xRouter --amount 100
+  eth-cli permit -p google -n myWallet --type permit-single --token 0xUSDC --spender 0xRouter --amount max --expiration 168h
+  eth-cli permit -f ./wallet.json --type permit-transfer-from --token 0xUSDC --spender 0xRouter --amount 50 --output permit.json`,
+		RunE: runPermit,
+	}
+
+	cmd.Flags().String("type", PermitTypeEIP2612, "Permit type: eip2612, permit-single or permit-transfer-from")
+	cmd.Flags().String("token", "", "ERC20 token contract address")
+	cmd.Flags().String("spender", "", "Spender allowed by the permit")
+	cmd.Flags().StringP("amount", "a", "", "Amount of tokens (decimal format), or max")
+	cmd.Flags().String("deadline", DefaultPermitDeadline.String(), "Signature deadline as a duration from now or a unix timestamp")
+	cmd.Flags().String("expiration", DefaultPermit2Expiration.String(), "Permit2 allowance expiration for permit-single, as a duration or a unix timestamp")
+	cmd.Flags().String("nonce", "", "Nonce to sign instead of the one read from the chain")
+	cmd.Flags().String("output", "", "Write the typed data and signature to this JSON file")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().BoolP("yes", "y", false, "Sign without asking for confirmation")
+
+	cmd.MarkFlagRequired("token")
+	cmd.MarkFlagRequired("spender")
+	cmd.MarkFlagRequired("amount")
+
+	return cmd
+}
+
+// permitOutput is the JSON written by --output
+type permitOutput struct {
+	TypedData apitypes.TypedData `json:"typedData"`
+	Signer    string             `json:"signer"`
+	Signature string             `json:"signature"`
+	V         uint8              `json:"v"`
+	R         string             `json:"r"`
+	S         string             `json:"s"`
+}
+
+func runPermit(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	permitType, _ := cmd.Flags().GetString("type")
+	tokenAddress, _ := cmd.Flags().GetString("token")
+	spender, _ := cmd.Flags().GetString("spender")
+	amountStr, _ := cmd.Flags().GetString("amount")
+	deadlineStr, _ := cmd.Flags().GetString("deadline")
+	expirationStr, _ := cmd.Flags().GetString("expiration")
+	nonceStr, _ := cmd.Flags().GetString("nonce")
+	outputPath, _ := cmd.Flags().GetString("output")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+
+	if permitType != PermitTypeEIP2612 && permitType != PermitTypeSingle && permitType != PermitTypeTransferFrom {
+		return fmt.Errorf("invalid --type %s, expected %s, %s or %s", permitType, PermitTypeEIP2612, PermitTypeSingle, PermitTypeTransferFrom)
+	}
+	if !common.IsHexAddress(tokenAddress) {
+		return fmt.Errorf("invalid token address format: %s", tokenAddress)
+	}
+	if !common.IsHexAddress(spender) {
+		return fmt.Errorf("invalid spender address format: %s", spender)
+	}
+	tokenAddr := common.HexToAddress(tokenAddress)
+	spenderAddr := common.HexToAddress(spender)
+
+	now := time.Now()
+	deadline, err := parsePermitTime(deadlineStr, now)
+	if err != nil {
+		return fmt.Errorf("invalid --deadline: %v", err)
+	}
+	if deadline.Int64() <= now.Unix() {
+		return fmt.Errorf("--deadline %s is in the past", deadline)
+	}
+
+	var nonceOverride *big.Int
+	if nonceStr != "" {
+		var ok bool
+		nonceOverride, ok = new(big.Int).SetString(nonceStr, 0)
+		if !ok || nonceOverride.Sign() < 0 {
+			return fmt.Errorf("invalid --nonce: %s", nonceStr)
+		}
+	}
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	// Determine which account to derive
+	selector, err := getAccountSelector(cmd)
+	if err != nil {
+		return err
+	}
+
+	// The owner address is needed to read the nonce, the key only to sign
+	ownerAddress, err := getWalletAddress(filePath, provider, name, selector)
+	if err != nil {
+		return fmt.Errorf("failed to get wallet address: %v", err)
+	}
+	owner := common.HexToAddress(ownerAddress)
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+
+	infos, err := fetchTokenInfo(client, []common.Address{tokenAddr}, nil, nil)
+	if err != nil {
+		return err
+	}
+	token := infos[0]
+
+	maxAmount := math.MaxBig256
+	if permitType == PermitTypeSingle {
+		maxAmount = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	}
+	amount, err := parsePermitAmount(amountStr, token.Decimals, maxAmount)
+	if err != nil {
+		return err
+	}
+
+	var typedData apitypes.TypedData
+	var nonce *big.Int
+	switch permitType {
+	case PermitTypeEIP2612:
+		domain, err := resolvePermitDomain(client, tokenAddr, chainID)
+		if err != nil {
+			return err
+		}
+		if nonce = nonceOverride; nonce == nil {
+			if nonce, err = callUint(client, tokenAddr, "nonces(address)(uint256)", owner); err != nil {
+				return fmt.Errorf("failed to read nonces(%s): %v", owner.Hex(), err)
+			}
+		}
+		typedData = util.NewPermitTypedData(domain, owner, spenderAddr, amount, nonce, deadline)
+
+	case PermitTypeSingle:
+		expiration, err := parsePermitTime(expirationStr, now)
+		if err != nil {
+			return fmt.Errorf("invalid --expiration: %v", err)
+		}
+		if expiration.BitLen() > 48 {
+			return fmt.Errorf("--expiration %s does not fit in uint48", expiration)
+		}
+		if nonce = nonceOverride; nonce == nil {
+			if nonce, err = permit2AllowanceNonce(client, owner, tokenAddr, spenderAddr); err != nil {
+				return err
+			}
+		}
+		if nonce.BitLen() > 48 {
+			return fmt.Errorf("--nonce %s does not fit in uint48", nonce)
+		}
+		typedData = util.NewPermitSingleTypedData(chainID, tokenAddr, amount, expiration, nonce, spenderAddr, deadline)
+
+	case PermitTypeTransferFrom:
+		if nonce, err = permit2UnorderedNonce(client, owner, nonceOverride); err != nil {
+			return err
+		}
+		typedData = util.NewPermitTransferFromTypedData(chainID, tokenAddr, amount, spenderAddr, nonce, deadline)
+	}
+
+	if permitType != PermitTypeEIP2612 {
+		warnPermit2Allowance(client, owner, tokenAddr, amount, token)
+	}
+
+	hashes, err := util.HashTypedData(typedData)
+	if err != nil {
+		return err
+	}
+
+	// Display the permit for confirmation before the wallet is unlocked
+	fmt.Println("\nPermit Details:")
+	fmt.Printf("Type: %s\n", permitType)
+	fmt.Printf("Owner: %s\n", owner.Hex())
+	fmt.Printf("Token: %s (%s)\n", tokenAddr.Hex(), token.Symbol)
+	fmt.Printf("Spender: %s\n", spenderAddr.Hex())
+	fmt.Printf("Amount: %s %s\n", formatPermitAmount(amount, maxAmount, token.Decimals), token.Symbol)
+	fmt.Printf("Nonce: %s\n", nonce)
+	fmt.Printf("Deadline: %s (%s)\n", deadline, time.Unix(deadline.Int64(), 0).Format(time.RFC3339))
+	displayTypedData(typedData, hashes)
+
+	if !autoConfirm {
+		fmt.Print("Sign this permit? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Signing cancelled.")
+			return nil
+		}
+	}
+
+	// Print provider or file info
+	if provider != "" {
+		fmt.Printf("Using provider: %s\n", provider)
+	} else {
+		fmt.Printf("Using wallet file: %s\n", filePath)
+	}
+
+	// Get private key from provider or file
+	var privateKey string
+	var fromAddress string
+	if filePath != "" {
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+	if common.HexToAddress(fromAddress) != owner {
+		return fmt.Errorf("the unlocked key belongs to %s, not to the permit owner %s", fromAddress, owner.Hex())
+	}
+
+	signature, err := util.SignTypedData(typedData, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign permit: %v", err)
+	}
+	v, r, s, err := util.SplitSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signer Address: %s\n", fromAddress)
+	fmt.Printf("Signature: %s\n", signature)
+	fmt.Printf("v: %d\n", v)
+	fmt.Printf("r: %s\n", hexutil.Encode(r[:]))
+	fmt.Printf("s: %s\n", hexutil.Encode(s[:]))
+	fmt.Printf("Deadline: %s\n", deadline)
+
+	if outputPath != "" {
+		data, err := json.MarshalIndent(permitOutput{
+			TypedData: typedData,
+			Signer:    fromAddress,
+			Signature: signature,
+			V:         v,
+			R:         hexutil.Encode(r[:]),
+			S:         hexutil.Encode(s[:]),
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode permit: %v", err)
+		}
+		if err := util.SaveToFileSystem(data, outputPath); err != nil {
+			return fmt.Errorf("failed to save permit: %v", err)
+		}
+		fmt.Printf("Permit saved to %s\n", outputPath)
+	}
+
+	return nil
+}
+
+// parsePermitTime parses a unix timestamp, or a duration added to now
+func parsePermitTime(value string, now time.Time) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+	if timestamp, ok := new(big.Int).SetString(value, 10); ok {
+		if timestamp.Sign() < 0 {
+			return nil, fmt.Errorf("negative timestamp %s", value)
+		}
+		return timestamp, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither a unix timestamp nor a duration", value)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration %s must be positive", value)
+	}
+	return big.NewInt(now.Add(duration).Unix()), nil
+}
+
+// parsePermitAmount parses an amount in token units, or "max" for the largest amount the permit can hold
+func parsePermitAmount(amountStr string, decimals uint8, maxAmount *big.Int) (*big.Int, error) {
+	if strings.EqualFold(strings.TrimSpace(amountStr), "max") {
+		return new(big.Int).Set(maxAmount), nil
+	}
+
+	amount, err := util.ParseTokenAmount(amountStr, decimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %v", err)
+	}
+	if amount.Cmp(maxAmount) > 0 {
+		return nil, fmt.Errorf("amount %s is above the permit maximum, use max", amountStr)
+	}
+	return amount, nil
+}
+
+// formatPermitAmount formats a permit amount in token units, or as max
+func formatPermitAmount(amount, maxAmount *big.Int, decimals uint8) string {
+	if amount.Cmp(maxAmount) == 0 {
+		return "max (unlimited)"
+	}
+	return util.FormatTokenAmount(amount, decimals)
+}
+
+// resolvePermitDomain finds the EIP-712 domain of an EIP-2612 token. The name comes from name()
+// and the version from version() when the token has it; otherwise the usual versions are tried.
+// The domain is only returned if it hashes to the token's DOMAIN_SEPARATOR, so a permit signed
+// with it is valid.
+func resolvePermitDomain(client *ethclient.Client, token common.Address, chainID *big.Int) (util.PermitDomain, error) {
+	values, err := callContractFunction(client, token, "DOMAIN_SEPARATOR()(bytes32)")
+	if err != nil {
+		return util.PermitDomain{}, fmt.Errorf("token %s has no DOMAIN_SEPARATOR(), it does not support EIP-2612 permit: %v", token.Hex(), err)
+	}
+	separator := values[0].([32]byte)
+
+	values, err = callContractFunction(client, token, "name()(string)")
+	if err != nil {
+		return util.PermitDomain{}, fmt.Errorf("failed to read name() of %s: %v", token.Hex(), err)
+	}
+	domain := util.PermitDomain{Name: values[0].(string), ChainID: chainID, VerifyingContract: token}
+
+	versions := permitDomainVersions
+	if values, err := callContractFunction(client, token, "version()(string)"); err == nil {
+		versions = []string{values[0].(string)}
+	}
+	for _, version := range versions {
+		domain.Version = version
+		if permitDomainMatches(domain, separator) {
+			return domain, nil
+		}
+	}
+	return util.PermitDomain{}, fmt.Errorf("could not reproduce DOMAIN_SEPARATOR %s of %s from name %q and version %s (non-standard permit domain)",
+		hexutil.Encode(separator[:]), token.Hex(), domain.Name, strings.Join(versions, "/"))
+}
+
+// permitDomainMatches reports whether a domain hashes to the given domain separator
+func permitDomainMatches(domain util.PermitDomain, separator [32]byte) bool {
+	typedData := util.NewPermitTypedData(domain, common.Address{}, common.Address{}, big.NewInt(0), big.NewInt(0), big.NewInt(0))
+	hashes, err := util.HashTypedData(typedData)
+	return err == nil && bytes.Equal(hashes.DomainSeparator, separator[:])
+}
+
+// permit2AllowanceNonce reads the nonce of the Permit2 allowance of (owner, token, spender),
+// printing the current allowance
+func permit2AllowanceNonce(client *ethclient.Client, owner, token, spender common.Address) (*big.Int, error) {
+	values, err := callContractFunction(client, util.Permit2Address, "allowance(address,address,address)(uint160,uint48,uint48)", owner, token, spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Permit2 allowance (is Permit2 deployed on this chain?): %v", err)
+	}
+	amount, expiration, nonce := values[0].(*big.Int), values[1].(*big.Int), values[2].(*big.Int)
+	fmt.Printf("Current Permit2 allowance: %s (expires %s)\n", amount, time.Unix(expiration.Int64(), 0).Format(time.RFC3339))
+	return nonce, nil
+}
+
+// permit2UnorderedNonce returns nonce if it is still unused in Permit2's nonceBitmap, or the first
+// unused nonce when nonce is nil
+func permit2UnorderedNonce(client *ethclient.Client, owner common.Address, nonce *big.Int) (*big.Int, error) {
+	if nonce != nil {
+		wordPos := new(big.Int).Rsh(nonce, 8)
+		bitmap, err := callUint(client, util.Permit2Address, "nonceBitmap(address,uint256)(uint256)", owner, wordPos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Permit2 nonce bitmap (is Permit2 deployed on this chain?): %v", err)
+		}
+		if bitmap.Bit(int(new(big.Int).And(nonce, big.NewInt(0xff)).Int64())) == 1 {
+			return nil, fmt.Errorf("Permit2 nonce %s was already used", nonce)
+		}
+		return nonce, nil
+	}
+
+	for word := int64(0); word < maxPermit2NonceWordsSearched; word++ {
+		bitmap, err := callUint(client, util.Permit2Address, "nonceBitmap(address,uint256)(uint256)", owner, big.NewInt(word))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Permit2 nonce bitmap (is Permit2 deployed on this chain?): %v", err)
+		}
+		if bit := firstUnsetBit(bitmap); bit < 256 {
+			return big.NewInt(word<<8 | int64(bit)), nil
+		}
+	}
+	return nil, fmt.Errorf("no unused Permit2 nonce in the first %d words, pass --nonce", maxPermit2NonceWordsSearched)
+}
+
+// firstUnsetBit returns the lowest bit of a 256-bit bitmap that is not set, or 256 if all are set
+func firstUnsetBit(bitmap *big.Int) int {
+	for i := 0; i < 256; i++ {
+		if bitmap.Bit(i) == 0 {
+			return i
+		}
+	}
+	return 256
+}
+
+// warnPermit2Allowance warns when the owner did not approve enough tokens to Permit2 for the permit to be used
+func warnPermit2Allowance(client *ethclient.Client, owner, token common.Address, amount *big.Int, info tokenInfo) {
+	allowance, err := callUint(client, token, "allowance(address,address)(uint256)", owner, util.Permit2Address)
+	if err != nil || allowance.Cmp(amount) >= 0 {
+		return
+	}
+	fmt.Printf("\033[33mWARNING: the %s allowance of Permit2 is only %s. Approve Permit2 (%s) with approveERC20 before the permit is used.\033[0m\n",
+		info.Symbol, util.FormatTokenAmount(allowance, info.Decimals), util.Permit2Address.Hex())
+}
+
+// callContractFunction calls a view function given as "name(inputs)(outputs)" and returns its decoded outputs
+func callContractFunction(client *ethclient.Client, contract common.Address, signature string, args ...interface{}) ([]interface{}, error) {
+	method, err := util.ParseFunctionSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	data, err := util.EncodeArgumentValues(signature, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("%s returned no data", method.Sig)
+	}
+	return method.Outputs.Unpack(output)
+}
+
+// callUint calls a view function returning a single unsigned integer
+func callUint(client *ethclient.Client, contract common.Address, signature string, args ...interface{}) (*big.Int, error) {
+	values, err := callContractFunction(client, contract, signature, args...)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T", values[0])
+	}
+	return value, nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+func TestParsePermitTime(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"30m", 1700001800},
+		{"720h", 1702592000},
+		{"1800000000", 1800000000},
+	}
+	for _, test := range tests {
+		got, err := parsePermitTime(test.input, now)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.input, err)
+			continue
+		}
+		if got.Int64() != test.expected {
+			t.Errorf("Unexpected time for %q: got %s, expected %d", test.input, got, test.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "soon", "-5m", "-1"} {
+		if _, err := parsePermitTime(invalid, now); err == nil {
+			t.Errorf("Expected error for %q, but got none", invalid)
+		}
+	}
+}
+
+func TestParsePermitAmount(t *testing.T) {
+	maxUint160 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+
+	amount, err := parsePermitAmount("1.5", 6, math.MaxBig256)
+	if err != nil || amount.String() != "1500000" {
+		t.Errorf("Unexpected amount: %v, %v", amount, err)
+	}
+
+	amount, err = parsePermitAmount("MAX", 18, maxUint160)
+	if err != nil || amount.Cmp(maxUint160) != 0 {
+		t.Errorf("Unexpected max amount: %v, %v", amount, err)
+	}
+	if formatPermitAmount(amount, maxUint160, 18) != "max (unlimited)" {
+		t.Errorf("Unexpected max amount format: %s", formatPermitAmount(amount, maxUint160, 18))
+	}
+
+	// 2^160 tokens with 0 decimals do not fit in a Permit2 uint160 amount
+	if _, err := parsePermitAmount(new(big.Int).Lsh(big.NewInt(1), 160).String(), 0, maxUint160); err == nil {
+		t.Error("Expected error for an amount above uint160, but got none")
+	}
+	if _, err := parsePermitAmount("abc", 18, math.MaxBig256); err == nil {
+		t.Error("Expected error for an invalid amount, but got none")
+	}
+}
+
+func TestFirstUnsetBit(t *testing.T) {
+	tests := []struct {
+		bitmap   *big.Int
+		expected int
+	}{
+		{big.NewInt(0), 0},
+		{big.NewInt(0b1011), 2},
+		{math.MaxBig256, 256},
+	}
+	for _, test := range tests {
+		if got := firstUnsetBit(test.bitmap); got != test.expected {
+			t.Errorf("Unexpected first unset bit of %s: got %d, expected %d", test.bitmap.Text(2), got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// RekeyCmd 返回 rekey 命令，用于更换 AES 密码或升级 Argon2id 参数
+func RekeyCmd() *cobra.Command {
+	var inputLocation string
+	var walletName string
+	var keepPassword bool
+	var memory uint32
+	var iterations uint32
+	var parallelism uint8
+	var autoConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "rekey",
+		Short: "Change the AES password or Argon2id parameters of a wallet",
+		Long: `Re-encrypt an existing wallet file with a new AES password and/or new Argon2id parameters.
+
+The mnemonic is decrypted with the current password, re-encrypted, and the address derived
+from the new file is compared with the original before the wallet is overwritten in place.
+Local files are replaced atomically; cloud copies are read back and verified after upload,
+and the original is restored if verification fails.
+
+Examples:
+  eth-cli rekey -i google -n myWallet
+  eth-cli rekey -i /path/to/wallet.json --keep-password --kdf-memory 2097152 --kdf-iterations 16`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocation == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			params := util.KDFParams{
+				Memory:      memory,
+				Iterations:  iterations,
+				Parallelism: parallelism,
+			}
+			if err := params.Validate(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 读取钱包文件
+			walletData, err := getWalletData(inputLocation, walletName)
+			if err != nil {
+				fmt.Printf("Error loading wallet from %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+
+			var wallet WalletFile
+			if err := json.Unmarshal(walletData, &wallet); err != nil {
+				fmt.Printf("Error parsing wallet file: %v\n", err)
+				os.Exit(1)
+			}
+			if isWatchOnlyWallet(wallet) {
+				fmt.Println("Error: watch-only wallets are not encrypted and have no password to change")
+				os.Exit(1)
+			}
+
+			// 使用旧密码解密
+			fmt.Print("Please Enter current \033[1;31mAES\033[0m Password: ")
+			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				fmt.Printf("\nError reading password: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+			oldPassword := string(passwordBytes)
+
+			secret, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, oldPassword)
+			if err != nil {
+				fmt.Printf("Error decrypting wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 私钥钱包没有 BIP39 passphrase
+			var passphrase string
+			if !isPrivateKeyWallet(wallet) {
+				passphrase, err = promptBIP39Passphrase()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			originalAddress, err := walletAddressFromSecret(wallet, secret, passphrase)
+			if err != nil {
+				fmt.Printf("Error generating address: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Wallet Address: \033[1;32m%s\033[0m\n", originalAddress)
+
+			// 获取新密码
+			newPassword := oldPassword
+			if !keepPassword {
+				fmt.Println("\nPlease enter the new \033[1;31mAES Encryption Password\033[0m.")
+				fmt.Println("It is recommended to use a strong password: \033[1;31m8 characters or more, including uppercase, lowercase, numbers, and special characters\033[0m.")
+				newPassword, err = promptNewAESPassword()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			old := wallet.EncryptedMnemonic
+			fmt.Println("\nArgon2id Parameters:")
+			fmt.Printf("  Memory:      %d KB -> %d KB\n", old.Memory, params.Memory)
+			fmt.Printf("  Iterations:  %d -> %d\n", old.Iterations, params.Iterations)
+			fmt.Printf("  Parallelism: %d -> %d\n", old.Parallelism, params.Parallelism)
+			if params.Memory < old.Memory || params.Iterations < old.Iterations {
+				fmt.Println("\033[33mWARNING: The new Argon2id parameters are weaker than the current ones.\033[0m")
+			}
+
+			// 使用新密码/参数重新加密
+			encryptedMnemonic, err := util.EncryptMnemonicWithParams(secret, newPassword, params)
+			if err != nil {
+				fmt.Printf("Error encrypting mnemonic: %v\n", err)
+				os.Exit(1)
+			}
+
+			newWallet := wallet
+			newWallet.EncryptedMnemonic = encryptedMnemonic
+
+			// 在覆盖之前确认新文件派生出相同的地址
+			if err := verifyWalletAddress(newWallet, newPassword, passphrase, originalAddress); err != nil {
+				fmt.Printf("Error: re-encrypted wallet failed verification: %v\n", err)
+				os.Exit(1)
+			}
+
+			walletJSON, err := json.MarshalIndent(newWallet, "", "  ")
+			if err != nil {
+				fmt.Printf("Error serializing wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			if !autoConfirm {
+				fmt.Printf("\nOverwrite wallet at %s? (y/N): ", inputLocation)
+				var response string
+				fmt.Scanln(&response)
+				if !strings.EqualFold(response, "y") {
+					fmt.Println("Rekey cancelled.")
+					return
+				}
+			}
+
+			// 写回同一存储位置
+			result, err := putWalletData(inputLocation, walletName, walletJSON, true)
+			if err != nil {
+				fmt.Printf("Error saving wallet to %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+			fmt.Println(result)
+
+			// 读回并校验，失败时恢复原文件
+			if err := verifyStoredWallet(inputLocation, walletName, walletJSON); err != nil {
+				fmt.Printf("Error: stored wallet failed verification: %v\n", err)
+				if _, restoreErr := putWalletData(inputLocation, walletName, walletData, true); restoreErr != nil {
+					fmt.Printf("\033[1;31mError restoring original wallet: %v\033[0m\n", restoreErr)
+				} else {
+					fmt.Println("Original wallet restored.")
+				}
+				os.Exit(1)
+			}
+
+			fmt.Println("\n\033[1;32mSuccess: Wallet re-encrypted successfully.\033[0m")
+			if !keepPassword {
+				fmt.Println("\033[1;31mIMPORTANT: Other copies of this wallet are still encrypted with the old password.\033[0m")
+			}
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVarP(&inputLocation, "input", "i", "", "Input location (local file path or cloud provider)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
+	cmd.Flags().BoolVar(&keepPassword, "keep-password", false, "Keep the current AES password and only change the Argon2id parameters")
+	cmd.Flags().Uint32Var(&memory, "kdf-memory", util.DefaultKDFParams.Memory, "Argon2id memory in KB")
+	cmd.Flags().Uint32Var(&iterations, "kdf-iterations", util.DefaultKDFParams.Iterations, "Argon2id iterations")
+	cmd.Flags().Uint8Var(&parallelism, "kdf-parallelism", util.DefaultKDFParams.Parallelism, "Argon2id parallelism")
+	cmd.Flags().BoolVarP(&autoConfirm, "yes", "y", false, "Overwrite the wallet without prompting")
+
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// walletAddressFromSecret derives the default address of a wallet from its decrypted secret
+func walletAddressFromSecret(wallet WalletFile, secret, passphrase string) (string, error) {
+	if isPrivateKeyWallet(wallet) {
+		address, _, err := getAddressFromPrivateKey(secret)
+		return address, err
+	}
+
+	derivationPath, err := resolveDerivationPath(wallet, accountSelector{})
+	if err != nil {
+		return "", err
+	}
+
+	address, _, err := getAddressFromMnemonic(secret, passphrase, derivationPath)
+	return address, err
+}
+
+// verifyWalletAddress decrypts a wallet and checks it derives the expected address
+func verifyWalletAddress(wallet WalletFile, password, passphrase, expectedAddress string) error {
+	secret, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, password)
+	if err != nil {
+		return err
+	}
+
+	address, err := walletAddressFromSecret(wallet, secret, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(address, expectedAddress) {
+		return fmt.Errorf("derived address %s does not match %s", address, expectedAddress)
+	}
+	return nil
+}
+
+// verifyStoredWallet reads a wallet back from storage and checks it matches what was written
+func verifyStoredWallet(location, name string, expectedData []byte) error {
+	storedData, err := getWalletData(location, name)
+	if err != nil {
+		return fmt.Errorf("error reading wallet back: %v", err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(storedData), bytes.TrimSpace(expectedData)) {
+		return fmt.Errorf("stored wallet does not match the written data")
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// MinFeeBumpPercent is the minimum fee increase nodes require to replace a pending transaction
+const MinFeeBumpPercent = 10
+
+// SpeedupCmd creates the command that re-sends a pending transaction with higher fees
+func SpeedupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "speedup",
+		Short: "Speed up a pending transaction by re-sending it with higher fees",
+		Long: `Re-sign a pending transaction with the same nonce, recipient, value and data but higher fees.
+
+Fees are bumped by at least 10% (the minimum nodes accept for a replacement), or raised to
+the current network suggestion or the --max-fee/--priority-fee/--gas-price values if higher.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReplaceTx(cmd, false)
+		},
+	}
+	addReplaceTxFlags(cmd)
+	return cmd
+}
+
+// CancelCmd creates the command that cancels a pending transaction
+func CancelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel a pending transaction",
+		Long: `Cancel a pending transaction by replacing it with a zero-value transfer to yourself
+that uses the same nonce and fees bumped by at least 10%.
+
+The cancellation only succeeds if it is mined before the original transaction.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReplaceTx(cmd, true)
+		},
+	}
+	addReplaceTxFlags(cmd)
+	return cmd
+}
+
+// addReplaceTxFlags adds the flags shared by speedup and cancel
+func addReplaceTxFlags(cmd *cobra.Command) {
+	cmd.Flags().String("tx", "", "Hash of the pending transaction")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().Uint64("bump", MinFeeBumpPercent, "Minimum fee increase in percent (at least 10)")
+	cmd.Flags().String("max-fee", "", "Max fee per gas for the replacement (e.g., 30gwei)")
+	cmd.Flags().String("priority-fee", "", "Max priority fee (tip) per gas for the replacement (e.g., 2gwei)")
+	cmd.Flags().String("gas-price", "", "Gas price for a legacy/2930 replacement (e.g., 3gwei)")
+	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the transaction")
+	cmd.Flags().Bool("sync", false, "Wait for transaction confirmation")
+
+	cmd.MarkFlagRequired("tx")
+}
+
+func runReplaceTx(cmd *cobra.Command, cancel bool) error {
+	// Parse flags
+	txHashStr, _ := cmd.Flags().GetString("tx")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	bump, _ := cmd.Flags().GetUint64("bump")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+	sync, _ := cmd.Flags().GetBool("sync")
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	if bump < MinFeeBumpPercent {
+		return fmt.Errorf("--bump must be at least %d percent", MinFeeBumpPercent)
+	}
+
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	if rpcURL == "" {
+		return fmt.Errorf("RPC URL is required")
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	// Fetch the pending transaction
+	txHash := common.HexToHash(txHashStr)
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %v", txHash.Hex(), err)
+	}
+	if !isPending {
+		return fmt.Errorf("transaction %s is already mined", txHash.Hex())
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction sender: %v", err)
+	}
+
+	// The nonce must not have been used by a mined transaction yet
+	minedNonce, err := client.NonceAt(context.Background(), sender, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if minedNonce > tx.Nonce() {
+		return fmt.Errorf("nonce %d of %s has already been used by a mined transaction", tx.Nonce(), sender.Hex())
+	}
+
+	chainID := tx.ChainId()
+	if tx.Type() == types.LegacyTxType {
+		chainID, err = client.ChainID(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get chain ID: %v", err)
+		}
+	}
+
+	// Calculate the replacement fees
+	oldParams := txParamsFromTransaction(tx)
+	newParams, err := replacementTxParams(cmd, client, oldParams, bump)
+	if err != nil {
+		return err
+	}
+
+	// Print provider or file info
+	if provider != "" {
+		fmt.Printf("Using provider: %s\n", provider)
+	} else {
+		fmt.Printf("Using wallet file: %s\n", filePath)
+	}
+
+	// Get private key from provider or file
+	var privateKey string
+	var fromAddress string
+	if filePath != "" {
+		// Use local file
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		// Use provider
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+	if common.HexToAddress(fromAddress) != sender {
+		return fmt.Errorf("wallet address %s does not match the transaction sender %s", fromAddress, sender.Hex())
+	}
+
+	// Build the replacement transaction
+	to := tx.To()
+	value := tx.Value()
+	data := tx.Data()
+	gasLimit := tx.Gas()
+	action := "Speed up"
+	if cancel {
+		action = "Cancel"
+		to = &sender
+		value = big.NewInt(0)
+		data = nil
+		gasLimit = 21000
+		newParams.AccessList = nil
+	}
+
+	rawTx, err := util.CreateRawTx(to, value, data, tx.Nonce(), newParams, gasLimit, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %v", err)
+	}
+
+	// Check the chain ID it is signed for against the selected network
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		return err
+	}
+
+	// Sign the transaction
+	signedTx, err := util.SignTransactionWithChainID(rawTx, privateKey, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %v", err)
+	}
+
+	// Display transaction details for confirmation
+	if !autoConfirm {
+		fmt.Println("Transaction Details:")
+		fmt.Printf("Action: %s %s\n", action, txHash.Hex())
+		fmt.Printf("From: %s\n", sender.Hex())
+		if to != nil {
+			fmt.Printf("To: %s\n", to.Hex())
+		} else {
+			fmt.Println("To: (contract creation)")
+		}
+		fmt.Printf("Value: %s %s\n", formatEther(value), nativeSymbol())
+		fmt.Printf("Nonce: %d\n", tx.Nonce())
+		fmt.Printf("Gas Limit: %d\n", gasLimit)
+		fmt.Println("Original Fees:")
+		for _, detail := range feeDetails(oldParams, tx.Gas()) {
+			fmt.Printf("  %s: %s\n", detail[0], detail[1])
+		}
+		fmt.Println("Replacement Fees:")
+		for _, detail := range feeDetails(newParams, gasLimit) {
+			fmt.Printf("  %s: %s\n", detail[0], detail[1])
+		}
+
+		// Ask for confirmation
+		fmt.Print("Confirm transaction? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Transaction cancelled.")
+			return nil
+		}
+	}
+
+	// Broadcast the transaction
+	newTxHash, err := util.BroadcastTransaction(signedTx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast transaction: %v", err)
+	}
+
+	fmt.Printf("Replacement transaction submitted: %s\n", newTxHash)
+	printExplorerLink(newTxHash)
+
+	// Wait for confirmation if requested
+	if sync {
+		return waitForConfirmation(client, newTxHash)
+	}
+
+	return nil
+}
+
+// replacementTxParams returns the fees of a replacement transaction: at least the old
+// fees bumped by the given percentage, raised to the flag values or network suggestion
+func replacementTxParams(cmd *cobra.Command, client *ethclient.Client, oldParams util.TxParams, bump uint64) (util.TxParams, error) {
+	newParams := oldParams
+	minMaxFee := bumpFee(oldParams.Fees.MaxFeePerGas, bump)
+	minPriorityFee := bumpFee(oldParams.Fees.MaxPriorityFeePerGas, bump)
+
+	// Current fees from the flags or the network
+	var current util.FeeParams
+	var err error
+	if oldParams.Type == util.TxTypeDynamicFee {
+		current, err = resolveFees(cmd, client, false)
+	} else {
+		current, err = resolveGasPrice(cmd, client, false)
+	}
+	if err != nil {
+		return newParams, err
+	}
+
+	// Explicitly requested fees must satisfy the replacement rule
+	maxFeeStr, _ := cmd.Flags().GetString("max-fee")
+	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
+	priorityFeeStr, _ := cmd.Flags().GetString("priority-fee")
+	if (maxFeeStr != "" || gasPriceStr != "") && current.MaxFeePerGas.Cmp(minMaxFee) < 0 {
+		return newParams, fmt.Errorf("max fee must be at least %s Gwei to replace the transaction", formatGwei(minMaxFee))
+	}
+	if priorityFeeStr != "" && current.MaxPriorityFeePerGas.Cmp(minPriorityFee) < 0 {
+		return newParams, fmt.Errorf("priority fee must be at least %s Gwei to replace the transaction", formatGwei(minPriorityFee))
+	}
+
+	newParams.Fees = util.FeeParams{
+		MaxFeePerGas:         maxBig(minMaxFee, current.MaxFeePerGas),
+		MaxPriorityFeePerGas: maxBig(minPriorityFee, current.MaxPriorityFeePerGas),
+		BaseFee:              current.BaseFee,
+	}
+	if oldParams.Type != util.TxTypeDynamicFee {
+		newParams.Fees.MaxPriorityFeePerGas = newParams.Fees.MaxFeePerGas
+	}
+	if newParams.Fees.MaxPriorityFeePerGas.Cmp(newParams.Fees.MaxFeePerGas) > 0 {
+		newParams.Fees.MaxFeePerGas = new(big.Int).Set(newParams.Fees.MaxPriorityFeePerGas)
+	}
+
+	return newParams, nil
+}
+
+// bumpFee increases a fee by the given percentage, rounding up
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// maxBig returns the larger of two values
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/spf13/cobra"
+)
+
+func TestBumpFee(t *testing.T) {
+	tests := []struct {
+		fee     int64
+		percent uint64
+		want    int64
+	}{
+		{1e9, 10, 11e8},
+		{1e8, 12, 112e6},
+		{15, 10, 17},
+		{0, 10, 0},
+	}
+
+	for _, tc := range tests {
+		got := bumpFee(big.NewInt(tc.fee), tc.percent)
+		if got.Cmp(big.NewInt(tc.want)) != 0 {
+			t.Errorf("bumpFee(%d, %d) = %s, want %d", tc.fee, tc.percent, got, tc.want)
+		}
+	}
+}
+
+func TestReplacementTxParams(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addReplaceTxFlags(cmd)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("Failed to parse flags %v: %v", args, err)
+		}
+		return cmd
+	}
+	dynamic := util.TxParams{
+		Type: util.TxTypeDynamicFee,
+		Fees: util.FeeParams{MaxFeePerGas: big.NewInt(2e9), MaxPriorityFeePerGas: big.NewInt(1e9)},
+	}
+	legacy := util.TxParams{
+		Type: util.TxTypeLegacy,
+		Fees: util.FeeParams{MaxFeePerGas: big.NewInt(2e9), MaxPriorityFeePerGas: big.NewInt(2e9)},
+	}
+
+	tests := []struct {
+		old         util.TxParams
+		args        []string
+		maxFee      int64
+		priorityFee int64
+		wantErr     bool
+	}{
+		// Without a client the current fees are the dry-run defaults, below the bumped fees
+		{dynamic, nil, 22e8, 11e8, false},
+		{dynamic, []string{"--max-fee", "30gwei", "--priority-fee", "3gwei"}, 30e9, 3e9, false},
+		{dynamic, []string{"--max-fee", "2.1gwei"}, 0, 0, true},
+		{dynamic, []string{"--priority-fee", "1gwei"}, 0, 0, true},
+		{legacy, nil, 22e8, 22e8, false},
+		{legacy, []string{"--gas-price", "5gwei"}, 5e9, 5e9, false},
+		{legacy, []string{"--gas-price", "2gwei"}, 0, 0, true},
+	}
+
+	for _, tc := range tests {
+		params, err := replacementTxParams(newCmd(tc.args...), nil, tc.old, MinFeeBumpPercent)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %s %v, but got none", tc.old.Type, tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s %v: %v", tc.old.Type, tc.args, err)
+			continue
+		}
+		if params.Fees.MaxFeePerGas.Cmp(big.NewInt(tc.maxFee)) != 0 {
+			t.Errorf("Unexpected max fee for %s %v: %s", tc.old.Type, tc.args, params.Fees.MaxFeePerGas)
+		}
+		if params.Fees.MaxPriorityFeePerGas.Cmp(big.NewInt(tc.priorityFee)) != 0 {
+			t.Errorf("Unexpected priority fee for %s %v: %s", tc.old.Type, tc.args, params.Fees.MaxPriorityFeePerGas)
+		}
+	}
+}
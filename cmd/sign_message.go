@@ -25,6 +25,7 @@ func SignMessageCmd() *cobra.Command {
 	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
 	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
 	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
 
 	return cmd
 }
@@ -67,6 +68,12 @@ func runSignMessage(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --provider or --file must be specified")
 	}
 
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
 	// Print provider or file info
 	if provider != "" {
 		fmt.Printf("Using provider: %s\n", provider)
@@ -80,10 +87,10 @@ func runSignMessage(cmd *cobra.Command, args []string) error {
 	var err error
 	if filePath != "" {
 		// Use local file
-		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath)
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
 	} else {
 		// Use provider
-		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name)
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get private key: %v", err)
@@ -6,8 +6,6 @@ import (
 	"strings"
 
 	"github.com/ethanzhrepo/eth-cli-wallet/util"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -16,8 +14,11 @@ func SignTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sign-raw-tx",
 		Short: "Sign an Ethereum transaction",
-		Long:  `Sign an Ethereum transaction using the specified private key.`,
-		RunE:  runSignTx,
+		Long: `Sign an Ethereum transaction using the specified private key.
+
+The transaction is always decoded and shown before the wallet is unlocked: type, chain ID,
+nonce, fees, recipient, value and the called function with its arguments (see decode).`,
+		RunE: runSignTx,
 	}
 
 	cmd.Flags().String("raw-tx", "", "Raw transaction hex string to sign")
@@ -25,7 +26,11 @@ func SignTxCmd() *cobra.Command {
 	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., googledrive)")
 	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
 	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
 	cmd.Flags().Bool("broadcast", false, "Broadcast the transaction after signing")
+	cmd.Flags().Uint64("chain-id", 0, "Chain ID for legacy transactions that do not carry one")
+	cmd.Flags().StringArray("abi", nil, "ABI or Foundry/Hardhat artifact JSON file to decode calldata with (repeatable)")
+	cmd.Flags().BoolP("yes", "y", false, "Sign (and broadcast) without asking for confirmation")
 
 	return cmd
 }
@@ -38,6 +43,9 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 	name, _ := cmd.Flags().GetString("name")
 	filePath, _ := cmd.Flags().GetString("file")
 	broadcast, _ := cmd.Flags().GetBool("broadcast")
+	chainIDValue, _ := cmd.Flags().GetUint64("chain-id")
+	abiPaths, _ := cmd.Flags().GetStringArray("abi")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
 
 	// Check for raw transaction source
 	if rawTx == "" && rawTxFile == "" {
@@ -59,7 +67,10 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 		// Trim any whitespace or newlines
 		rawTxHex = strings.TrimSpace(string(data))
 	} else {
-		rawTxHex = rawTx
+		rawTxHex = strings.TrimSpace(rawTx)
+	}
+	if !strings.HasPrefix(rawTxHex, "0x") {
+		rawTxHex = "0x" + rawTxHex
 	}
 
 	// Check mutual exclusivity between provider+name and file
@@ -72,6 +83,12 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --provider or --file must be specified")
 	}
 
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
 	// Get RPC URL from config if needed for broadcasting
 	var rpcURL string
 	var err error
@@ -82,6 +99,48 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Show what is about to be signed before unlocking the wallet
+	tx, err := util.DecodeSignedTransaction(rawTxHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode raw transaction: %v", err)
+	}
+	abis, err := loadABIs(abiPaths)
+	if err != nil {
+		return err
+	}
+	var chainID *big.Int
+	if chainIDValue != 0 {
+		chainID = new(big.Int).SetUint64(chainIDValue)
+	}
+
+	// The transaction must be for the selected network, whose chain ID legacy transactions
+	// without one are signed for
+	txChainID := util.TransactionChainID(tx)
+	if txChainID == nil {
+		txChainID = chainID
+	}
+	if err := checkTxNetwork(txChainID); err != nil {
+		return err
+	}
+	if chainID == nil && currentNetwork != nil {
+		chainID = currentNetwork.ChainID
+	}
+
+	printTxPreview(tx, chainID, abis)
+	if util.IsSignedTransaction(tx) {
+		fmt.Println("\033[33mWARNING: the transaction is already signed, its signature will be replaced\033[0m")
+	}
+
+	if !autoConfirm {
+		fmt.Print("Sign this transaction? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Transaction signing cancelled.")
+			return nil
+		}
+	}
+
 	// Print provider or file info
 	if provider != "" {
 		fmt.Printf("Using provider: %s\n", provider)
@@ -94,18 +153,18 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 	var fromAddress string
 	if filePath != "" {
 		// Use local file
-		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath)
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
 	} else {
 		// Use provider
-		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name)
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get private key: %v", err)
 	}
 
-	// Sign the transaction
+	// Sign the transaction with the signer matching its type
 	var signErr error
-	signedTx, signErr := util.SignTransaction(rawTxHex, privateKey)
+	signedTx, signErr := util.SignTransactionWithChainID(rawTxHex, privateKey, chainID)
 	if signErr != nil {
 		return fmt.Errorf("failed to sign transaction: %v", signErr)
 	}
@@ -117,66 +176,20 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("RPC URL is required for broadcasting")
 		}
 
-		// Try to extract gas details from the raw transaction
-		txDetails := "Transaction Details:\n"
-		txDetails += fmt.Sprintf("From: %s\n", fromAddress)
-
-		// Try to decode the transaction to extract gas details
-		txData, decodeErr := hexutil.Decode(rawTxHex)
-		if decodeErr == nil {
-			var tx types.Transaction
-			unmarshalErr := tx.UnmarshalBinary(txData)
-			if unmarshalErr == nil {
-				// Display to address if available
-				if tx.To() != nil {
-					txDetails += fmt.Sprintf("To: %s\n", tx.To().Hex())
-				}
-
-				// Display value if it's not zero
-				if tx.Value().Cmp(big.NewInt(0)) > 0 {
-					ethAmount := new(big.Int).Div(tx.Value(), big.NewInt(1e18))
-					remainder := new(big.Int).Mod(tx.Value(), big.NewInt(1e18))
-					displayAmount := fmt.Sprintf("%d.%018d", ethAmount, remainder)
-					txDetails += fmt.Sprintf("Value: %s ETH\n", displayAmount)
-				}
-
-				// Display gas limit
-				txDetails += fmt.Sprintf("Gas Limit: %d\n", tx.Gas())
-
-				// Display gas price if available
-				gasPrice := tx.GasPrice()
-				if gasPrice != nil && gasPrice.Cmp(big.NewInt(0)) > 0 {
-					gasPriceGwei := new(big.Int).Div(gasPrice, big.NewInt(1e9))
-					gasPriceRemainder := new(big.Int).Mod(gasPrice, big.NewInt(1e9))
-					displayGasPrice := fmt.Sprintf("%d.%09d", gasPriceGwei, gasPriceRemainder)
-					txDetails += fmt.Sprintf("Gas Price: %s Gwei\n", displayGasPrice)
-
-					// Calculate and display gas fee
-					gasFee := new(big.Int).Mul(gasPrice, big.NewInt(int64(tx.Gas())))
-					gasFeeEth := new(big.Int).Div(gasFee, big.NewInt(1e18))
-					gasFeeRemainder := new(big.Int).Mod(gasFee, big.NewInt(1e18))
-					displayGasFee := fmt.Sprintf("%d.%018d", gasFeeEth, gasFeeRemainder)
-					txDetails += fmt.Sprintf("Gas Fee: %s ETH\n", displayGasFee)
-				}
-
-				// Display nonce
-				txDetails += fmt.Sprintf("Nonce: %d\n", tx.Nonce())
+		// The transaction was previewed before signing
+		fmt.Printf("From: %s\n", fromAddress)
+		fmt.Printf("Signed Transaction: %s...\n", signedTx[:66])
+
+		if !autoConfirm {
+			fmt.Print("Broadcast this transaction? (y/N): ")
+			var response string
+			fmt.Scanln(&response)
+			if !strings.EqualFold(response, "y") {
+				fmt.Println("Transaction broadcasting cancelled.")
+				return nil
 			}
 		}
 
-		// Display truncated signed transaction
-		txDetails += fmt.Sprintf("Signed Transaction: %s...\n", signedTx[:66]+"...")
-
-		// Display transaction details and ask for confirmation
-		fmt.Println(txDetails)
-		fmt.Print("Broadcast this transaction? (y/N): ")
-		var response string
-		fmt.Scanln(&response)
-		if !strings.EqualFold(response, "y") {
-			fmt.Println("Transaction broadcasting cancelled.")
-			return nil
-		}
-
 		// Broadcast the transaction
 		var broadcastErr error
 		txHash, broadcastErr := util.BroadcastTransaction(signedTx, rpcURL)
@@ -185,6 +198,7 @@ func runSignTx(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("Transaction submitted: %s\n", txHash)
+		printExplorerLink(txHash)
 	} else {
 		// Just display the signed transaction
 		fmt.Printf("Signed Transaction: %s\n", signedTx)
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/spf13/cobra"
+)
+
+// SignTypedDataCmd creates the EIP-712 typed data signing command
+func SignTypedDataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-typed-data",
+		Short: "Sign EIP-712 typed data",
+		Long: `Sign EIP-712 typed structured data (eth_signTypedData_v4) using the specified private key.
+
+The JSON file must contain types, primaryType, domain and message. The decoded fields and
+the domain separator are shown for confirmation before the wallet is unlocked.`,
+		RunE: runSignTypedData,
+	}
+
+	// Add flags
+	cmd.Flags().String("data-file", "", "Path to the typed data JSON file")
+	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
+	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
+	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
+	cmd.Flags().BoolP("yes", "y", false, "Sign without asking for confirmation")
+
+	cmd.MarkFlagRequired("data-file")
+
+	return cmd
+}
+
+func runSignTypedData(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	dataFile, _ := cmd.Flags().GetString("data-file")
+	provider, _ := cmd.Flags().GetString("provider")
+	name, _ := cmd.Flags().GetString("name")
+	filePath, _ := cmd.Flags().GetString("file")
+	autoConfirm, _ := cmd.Flags().GetBool("yes")
+
+	// Check mutual exclusivity between provider+name and file
+	if (provider != "" || name != "") && filePath != "" {
+		return fmt.Errorf("--file and --provider/--name are mutually exclusive, use one or the other")
+	}
+
+	// Ensure we have either file or provider
+	if provider == "" && filePath == "" {
+		return fmt.Errorf("either --provider or --file must be specified")
+	}
+
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Parse and hash the typed data before unlocking the wallet
+	data, err := os.ReadFile(dataFile)
+	if err != nil {
+		return fmt.Errorf("failed to read data file: %v", err)
+	}
+
+	typedData, err := util.ParseTypedData(data)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := util.HashTypedData(typedData)
+	if err != nil {
+		return err
+	}
+
+	// Display the typed data for confirmation
+	displayTypedData(typedData, hashes)
+
+	if !autoConfirm {
+		fmt.Print("Sign this typed data? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			fmt.Println("Signing cancelled.")
+			return nil
+		}
+	}
+
+	// Print provider or file info
+	if provider != "" {
+		fmt.Printf("Using provider: %s\n", provider)
+	} else {
+		fmt.Printf("Using wallet file: %s\n", filePath)
+	}
+
+	// Get private key from provider or file
+	var privateKey string
+	var fromAddress string
+	if filePath != "" {
+		// Use local file
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
+	} else {
+		// Use provider
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get private key: %v", err)
+	}
+
+	// Sign the typed data
+	signature, err := util.SignTypedData(typedData, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign typed data: %v", err)
+	}
+
+	// Display the signature details
+	fmt.Printf("Signer Address: %s\n", fromAddress)
+	fmt.Printf("Signature: %s\n", signature)
+
+	return nil
+}
+
+// displayTypedData prints the domain, decoded message fields and EIP-712 hashes
+func displayTypedData(typedData apitypes.TypedData, hashes util.TypedDataHashes) {
+	fmt.Println("\nTyped Data Details:")
+	fmt.Println("-------------------")
+	fmt.Println("Domain:")
+	for _, field := range typedData.Types["EIP712Domain"] {
+		if value, ok := typedData.Domain.Map()[field.Name]; ok {
+			fmt.Printf("  %s: %s\n", field.Name, formatTypedValue(value))
+		}
+	}
+	fmt.Printf("Primary Type: %s\n", typedData.PrimaryType)
+	fmt.Println("Message:")
+	printTypedStruct(typedData, typedData.PrimaryType, typedData.Message, 1)
+	fmt.Printf("Domain Separator: %s\n", hexutil.Encode(hashes.DomainSeparator))
+	fmt.Printf("Message Hash: %s\n", hexutil.Encode(hashes.MessageHash))
+	fmt.Printf("Signing Digest: %s\n", hexutil.Encode(hashes.Digest))
+	fmt.Println("-------------------")
+}
+
+// printTypedStruct prints the fields of a struct in the order of its type definition
+func printTypedStruct(typedData apitypes.TypedData, typeName string, data map[string]interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, field := range typedData.Types[typeName] {
+		value := data[field.Name]
+		baseType := strings.Split(field.Type, "[")[0]
+		_, isStruct := typedData.Types[baseType]
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			fmt.Printf("%s%s (%s):\n", indent, field.Name, field.Type)
+			printTypedStruct(typedData, baseType, v, depth+1)
+		case []interface{}:
+			fmt.Printf("%s%s (%s): [%d items]\n", indent, field.Name, field.Type, len(v))
+			for i, item := range v {
+				if itemMap, ok := item.(map[string]interface{}); ok && isStruct {
+					fmt.Printf("%s  [%d]:\n", indent, i)
+					printTypedStruct(typedData, baseType, itemMap, depth+2)
+				} else {
+					fmt.Printf("%s  [%d]: %s\n", indent, i, formatTypedValue(item))
+				}
+			}
+		default:
+			fmt.Printf("%s%s (%s): %s\n", indent, field.Name, field.Type, formatTypedValue(v))
+		}
+	}
+
+	// Fields that are not part of the type are ignored by EIP-712 hashing, but worth showing
+	var extra []string
+	for key := range data {
+		found := false
+		for _, field := range typedData.Types[typeName] {
+			if field.Name == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		fmt.Printf("%s%s: %s (not signed: missing from type %s)\n", indent, key, formatTypedValue(data[key]), typeName)
+	}
+}
+
+// formatTypedValue formats a typed data value for display
+func formatTypedValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<missing>"
+	case string:
+		return v
+	case *math.HexOrDecimal256:
+		return (*big.Int)(v).String()
+	case float64:
+		// JSON numbers above 2^53 lose precision; they should be passed as strings
+		return fmt.Sprintf("%.0f", v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// txSimulation is what simulating a transaction before signing it showed
+type txSimulation struct {
+	Method  string // simulation method used, empty if the node supports none
+	Note    string // why there are no asset changes to show when Method is empty
+	Changes []util.AssetChange
+	Tokens  map[common.Address]tokenInfo // symbol and decimals of the tokens in Changes
+}
+
+// simulateTx executes a transaction on the latest state before it is signed and decodes its
+// balance and allowance changes for the sender. It fails if the transaction would revert. If
+// the node cannot simulate it, a plain eth_call still checks for a revert.
+func simulateTx(client *ethclient.Client, from common.Address, to *common.Address, value *big.Int, data []byte, gasLimit uint64) (*txSimulation, error) {
+	msg := ethereum.CallMsg{From: from, To: to, Value: value, Data: data, Gas: gasLimit}
+
+	result, err := util.SimulateTransaction(context.Background(), client.Client(), msg)
+	if err != nil {
+		note := err.Error()
+		if !errors.Is(err, util.ErrSimulationUnsupported) {
+			note = fmt.Sprintf("simulation failed: %v", err)
+		}
+		if to != nil {
+			if _, callErr := client.CallContract(context.Background(), msg, nil); callErr != nil {
+				if reason := util.DecodeRevertReason(callErr); reason != "" {
+					return nil, fmt.Errorf("the transaction would revert: %s", reason)
+				}
+				return nil, fmt.Errorf("the transaction would fail: %v", callErr)
+			}
+		}
+		return &txSimulation{Note: note}, nil
+	}
+	if result.Reverted {
+		return nil, fmt.Errorf("the transaction would revert: %s", result.RevertReason)
+	}
+
+	sim := &txSimulation{Method: result.Method, Changes: util.AssetChangesFromLogs(result.Logs, from)}
+	sim.Tokens = simulationTokenInfo(client, sim.Changes)
+	return sim, nil
+}
+
+// simulateRawTx simulates an unsigned transaction built by a command, see simulateTx
+func simulateRawTx(client *ethclient.Client, from string, rawTx string) (*txSimulation, error) {
+	tx, err := util.DecodeSignedTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	return simulateTx(client, common.HexToAddress(from), tx.To(), tx.Value(), tx.Data(), tx.Gas())
+}
+
+// simulationTokenInfo gets the symbol and decimals of the ERC20 tokens in the changes. A
+// failed lookup leaves the token out, so its amounts are shown in base units.
+func simulationTokenInfo(client *ethclient.Client, changes []util.AssetChange) map[common.Address]tokenInfo {
+	infos := make(map[common.Address]tokenInfo)
+	var tokens []common.Address
+	for _, change := range changes {
+		if change.Kind != util.AssetChangeBalance && change.Kind != util.AssetChangeAllowance {
+			continue
+		}
+		if _, seen := infos[change.Token]; seen || change.Token == util.EthAddress {
+			continue
+		}
+		infos[change.Token] = tokenInfo{}
+		tokens = append(tokens, change.Token)
+	}
+
+	found := make(map[common.Address]tokenInfo)
+	results, err := fetchTokenInfo(client, tokens, nil, nil)
+	if err != nil {
+		return found
+	}
+	for i, token := range tokens {
+		if results[i].DecimalsErr == nil {
+			found[token] = results[i]
+		}
+	}
+	return found
+}
+
+// printSimulation prints the asset changes of a simulated transaction in the confirmation screen
+func printSimulation(sim *txSimulation) {
+	if sim == nil {
+		return
+	}
+	if sim.Method == "" {
+		fmt.Printf("\033[33mSimulated Changes: unavailable (%s)\033[0m\n", sim.Note)
+		return
+	}
+	if len(sim.Changes) == 0 {
+		fmt.Printf("Simulated Changes (%s): no balance or approval changes for the sender, gas fees aside\n", sim.Method)
+		return
+	}
+	fmt.Printf("Simulated Changes (%s, gas fees aside):\n", sim.Method)
+	for _, change := range sim.Changes {
+		fmt.Printf("  %s\n", describeAssetChange(change, sim.Tokens))
+	}
+}
+
+// describeAssetChange describes an asset change in words
+func describeAssetChange(change util.AssetChange, tokens map[common.Address]tokenInfo) string {
+	switch change.Kind {
+	case util.AssetChangeBalance:
+		sign := "+"
+		if change.Amount.Sign() < 0 {
+			sign = "-"
+		}
+		amount := new(big.Int).Abs(change.Amount)
+		if change.Token == util.EthAddress {
+			return fmt.Sprintf("%s%s %s", sign, formatEther(amount), nativeSymbol())
+		}
+		return fmt.Sprintf("%s%s", sign, formatTokenValue(amount, change.Token, tokens))
+
+	case util.AssetChangeAllowance:
+		allowance := formatTokenValue(change.Amount, change.Token, tokens)
+		if change.Amount.Sign() == 0 {
+			return fmt.Sprintf("Allowance of %s revoked for %s", change.Spender.Hex(), tokenLabel(change.Token, tokens))
+		}
+		if change.Amount.Cmp(unlimitedAllowance) >= 0 {
+			allowance = "unlimited " + tokenLabel(change.Token, tokens)
+		}
+		return fmt.Sprintf("Allowance: %s may spend %s", change.Spender.Hex(), allowance)
+
+	case util.AssetChangeNFT:
+		if change.Amount.Sign() < 0 {
+			return fmt.Sprintf("-%s of token #%s of %s", new(big.Int).Neg(change.Amount), change.TokenID, change.Token.Hex())
+		}
+		return fmt.Sprintf("+%s of token #%s of %s", change.Amount, change.TokenID, change.Token.Hex())
+
+	case util.AssetChangeNFTApproval:
+		if change.Spender == (common.Address{}) {
+			return fmt.Sprintf("Approval of token #%s of %s revoked", change.TokenID, change.Token.Hex())
+		}
+		return fmt.Sprintf("Approval: %s may transfer token #%s of %s", change.Spender.Hex(), change.TokenID, change.Token.Hex())
+
+	default:
+		if change.Approved {
+			return fmt.Sprintf("Operator: %s may transfer every token of %s", change.Spender.Hex(), change.Token.Hex())
+		}
+		return fmt.Sprintf("Operator: %s may no longer transfer tokens of %s", change.Spender.Hex(), change.Token.Hex())
+	}
+}
+
+// formatTokenValue formats an amount of a token with its symbol, or in base units if the
+// token is unknown
+func formatTokenValue(amount *big.Int, token common.Address, tokens map[common.Address]tokenInfo) string {
+	info, ok := tokens[token]
+	if !ok {
+		return fmt.Sprintf("%s base units of %s", amount, token.Hex())
+	}
+	return fmt.Sprintf("%s %s", util.FormatTokenAmount(amount, info.Decimals), info.Symbol)
+}
+
+// tokenLabel names a token by its symbol, or its address if unknown
+func tokenLabel(token common.Address, tokens map[common.Address]tokenInfo) string {
+	if info, ok := tokens[token]; ok {
+		return info.Symbol
+	}
+	return token.Hex()
+}
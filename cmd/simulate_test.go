@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDescribeAssetChange(t *testing.T) {
+	usdc := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	unknown := common.HexToAddress("0x00000000000000000000000000000000000000dd")
+	spender := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	tokens := map[common.Address]tokenInfo{usdc: {Symbol: "USDC", Decimals: 6}}
+
+	tests := []struct {
+		change   util.AssetChange
+		expected string
+	}{
+		{util.AssetChange{Kind: util.AssetChangeBalance, Token: util.EthAddress, Amount: big.NewInt(-1e18)}, "-1.000000000000000000 ETH"},
+		{util.AssetChange{Kind: util.AssetChangeBalance, Token: usdc, Amount: big.NewInt(2500000)}, "+2.500000 USDC"},
+		{util.AssetChange{Kind: util.AssetChangeBalance, Token: unknown, Amount: big.NewInt(-7)}, "-7 base units of " + unknown.Hex()},
+		{util.AssetChange{Kind: util.AssetChangeAllowance, Token: usdc, Spender: spender, Amount: big.NewInt(1000000)}, "Allowance: " + spender.Hex() + " may spend 1.000000 USDC"},
+		{util.AssetChange{Kind: util.AssetChangeAllowance, Token: usdc, Spender: spender, Amount: new(big.Int).Set(unlimitedAllowance)}, "Allowance: " + spender.Hex() + " may spend unlimited USDC"},
+		{util.AssetChange{Kind: util.AssetChangeAllowance, Token: usdc, Spender: spender, Amount: big.NewInt(0)}, "Allowance of " + spender.Hex() + " revoked for USDC"},
+		{util.AssetChange{Kind: util.AssetChangeNFT, Token: unknown, TokenID: big.NewInt(5), Amount: big.NewInt(-1)}, "-1 of token #5 of " + unknown.Hex()},
+		{util.AssetChange{Kind: util.AssetChangeNFTApproval, Token: unknown, TokenID: big.NewInt(5)}, "Approval of token #5 of " + unknown.Hex() + " revoked"},
+		{util.AssetChange{Kind: util.AssetChangeOperator, Token: unknown, Spender: spender, Approved: true}, "Operator: " + spender.Hex() + " may transfer every token of " + unknown.Hex()},
+	}
+	for _, test := range tests {
+		if got := describeAssetChange(test.change, tokens); got != test.expected {
+			t.Errorf("Unexpected description: got %q, want %q", got, test.expected)
+		}
+	}
+}
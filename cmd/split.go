@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/term"
+)
+
+// ShareFileType marks a file as one Shamir share of a wallet
+const ShareFileType = "shamir_share"
+
+// ShareScheme identifies the sharing scheme, documented in util/shamir.go
+const ShareScheme = "shamir-gf256"
+
+// ShareFileVersion is the current share file version
+const ShareFileVersion = 2
+
+// shareChecksumLen is the length of the checksum appended to the secret before splitting
+const shareChecksumLen = 4
+
+// ShareFile 分片文件结构
+// EncryptedShare holds the hex-encoded share value encrypted with the share password.
+// The share value is a share of the BIP39 entropy for mnemonic wallets, or of the raw
+// private key for private key wallets, followed by its checksum. The checksum is only
+// known after the shares are combined, so a single share reveals nothing about the secret.
+type ShareFile struct {
+	Version        int                    `json:"version"`
+	Type           string                 `json:"type"`
+	Scheme         string                 `json:"scheme"`
+	SetID          string                 `json:"set_id"`
+	Index          uint8                  `json:"index"`
+	Threshold      int                    `json:"threshold"`
+	Shares         int                    `json:"shares"`
+	WalletType     string                 `json:"wallet_type,omitempty"`
+	EncryptedShare util.EncryptedMnemonic `json:"encrypted_share"`
+	HDPath         string                 `json:"hd_path,omitempty"`
+	DerivationPath string                 `json:"derivation_path,omitempty"`
+	TestNet        bool                   `json:"testnet"`
+	Accounts       map[string]uint32      `json:"accounts,omitempty"`
+}
+
+// SplitCmd 返回 split 命令，用于将钱包拆分为 Shamir 分片
+func SplitCmd() *cobra.Command {
+	var inputLocation string
+	var walletName string
+	var threshold int
+	var shares int
+	var outputLocations string
+	var shareName string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "split",
+		Short: "Split a wallet into Shamir secret shares",
+		Long: `Split the secret of a wallet into Shamir secret shares, any --threshold of which rebuild it.
+
+The BIP39 entropy (or the raw private key for private key wallets) is split over GF(256),
+each share is encrypted with a share password (AES-256-GCM + Argon2id) and saved to a
+different output location. Use the combine command to rebuild a normal wallet file.
+
+--output takes exactly one location per share: a cloud provider (google, dropbox, s3, box,
+keychain) or a local directory/.json file path. Each cloud provider may only be used once.
+
+Examples:
+  eth-cli split -i google -n myWallet --threshold 3 --shares 5 --output google,dropbox,s3,box,keychain
+  eth-cli split -i /path/to/wallet.json --threshold 2 --shares 3 --output dropbox,/mnt/usb1,/mnt/usb2 --share-name myWallet`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// 初始化配置
+			initConfig()
+
+			// 检查必要参数
+			if inputLocation == "" {
+				fmt.Println("Error: --input parameter is required")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			if shareName == "" {
+				shareName = walletName
+			}
+			if shareName == "" {
+				fmt.Println("Error: --share-name parameter is required when the input is a local file")
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			outputs, err := parseShareOutputs(outputLocations, shares)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 检查是否已存在同名文件
+			if !force {
+				for i, output := range outputs {
+					if isCloudProvider(output) {
+						continue
+					}
+					path := shareFilePath(output, shareName, i+1)
+					if _, err := os.Stat(path); err == nil {
+						fmt.Printf("Error: File already exists at %s. Use -f or --force to overwrite.\n", path)
+						os.Exit(1)
+					}
+				}
+			}
+
+			// 读取并解密钱包
+			walletData, err := getWalletData(inputLocation, walletName)
+			if err != nil {
+				fmt.Printf("Error loading wallet from %s: %v\n", inputLocation, err)
+				os.Exit(1)
+			}
+
+			var wallet WalletFile
+			if err := json.Unmarshal(walletData, &wallet); err != nil {
+				fmt.Printf("Error parsing wallet file: %v\n", err)
+				os.Exit(1)
+			}
+			if isWatchOnlyWallet(wallet) {
+				fmt.Println("Error: watch-only wallets have no secret to split")
+				os.Exit(1)
+			}
+
+			fmt.Print("Please Enter \033[1;31mAES\033[0m Password: ")
+			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				fmt.Printf("\nError reading password: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+
+			secret, err := util.DecryptMnemonic(wallet.EncryptedMnemonic, string(passwordBytes))
+			if err != nil {
+				fmt.Printf("Error decrypting wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			secretBytes, err := walletSecretBytes(wallet, secret)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 拆分秘密（连同校验和一起拆分）
+			shamirShares, err := util.SplitSecret(appendShareChecksum(secretBytes), threshold, shares)
+			if err != nil {
+				fmt.Printf("Error splitting wallet: %v\n", err)
+				os.Exit(1)
+			}
+
+			setID, err := newShareSetID()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// 获取分片加密密码
+			fmt.Println("\nPlease enter a \033[1;31mShare Password\033[0m used to encrypt every share.")
+			fmt.Println("It may differ from the wallet password and is required to combine the shares.")
+			sharePassword, err := promptNewAESPassword()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			walletType := wallet.Type
+			if walletType == "" {
+				walletType = WalletTypeMnemonic
+			}
+
+			// 加密并保存每个分片
+			var verifyLocations []string
+			for i, share := range shamirShares {
+				encryptedShare, err := util.EncryptMnemonic(hex.EncodeToString(share.Value), sharePassword)
+				if err != nil {
+					fmt.Printf("Error encrypting share %d: %v\n", share.Index, err)
+					os.Exit(1)
+				}
+
+				shareJSON, err := json.MarshalIndent(ShareFile{
+					Version:        ShareFileVersion,
+					Type:           ShareFileType,
+					Scheme:         ShareScheme,
+					SetID:          setID,
+					Index:          share.Index,
+					Threshold:      threshold,
+					Shares:         shares,
+					WalletType:     walletType,
+					EncryptedShare: encryptedShare,
+					HDPath:         wallet.HDPath,
+					DerivationPath: wallet.DerivationPath,
+					TestNet:        wallet.TestNet,
+					Accounts:       wallet.Accounts,
+				}, "", "  ")
+				if err != nil {
+					fmt.Printf("Error serializing share %d: %v\n", share.Index, err)
+					os.Exit(1)
+				}
+
+				location := outputs[i]
+				if !isCloudProvider(location) {
+					location = shareFilePath(location, shareName, int(share.Index))
+				}
+
+				result, err := putWalletData(location, shareName+"-share", shareJSON, force)
+				if err != nil {
+					fmt.Printf("Error saving share %d to %s: %v\n", share.Index, location, err)
+					continue
+				}
+				fmt.Println(result)
+				verifyLocations = append(verifyLocations, location)
+			}
+
+			for i := range secretBytes {
+				secretBytes[i] = 0
+			}
+
+			if len(verifyLocations) < threshold {
+				fmt.Printf("\033[1;31mError: only %d of %d shares were saved, fewer than the threshold of %d. The original wallet is still required.\033[0m\n", len(verifyLocations), shares, threshold)
+				os.Exit(1)
+			}
+
+			fmt.Printf("\nSaved %d of %d shares (threshold %d, set %s).\n", len(verifyLocations), shares, threshold, setID)
+			fmt.Println("\nBefore deleting the original wallet, test that the shares can be combined:")
+			fmt.Printf("  eth-cli combine -i %s -n %s -o fs -p /tmp/restored.json\n", strings.Join(verifyLocations[:threshold], ","), shareName)
+
+			fmt.Printf("\n\033[1;31mIMPORTANT: Anyone holding %d shares and the share password can rebuild the wallet.\033[0m\n", threshold)
+			fmt.Println("\n\033[1;32mSuccess: Wallet split successfully.\033[0m")
+		},
+	}
+
+	// 添加命令参数
+	cmd.Flags().StringVarP(&inputLocation, "input", "i", "", "Input location (local file path or cloud provider)")
+	cmd.Flags().StringVarP(&walletName, "name", "n", "", "Name of the wallet file (required for cloud storage)")
+	cmd.Flags().IntVar(&threshold, "threshold", 2, "Number of shares required to rebuild the wallet")
+	cmd.Flags().IntVar(&shares, "shares", 3, "Total number of shares to create")
+	cmd.Flags().StringVarP(&outputLocations, "output", "o", "", "Comma-separated list of share locations, one per share (cloud providers or local paths)")
+	cmd.Flags().StringVar(&shareName, "share-name", "", "Base name of the share files (default: the wallet name)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite if a share file already exists")
+
+	cmd.MarkFlagRequired("input")
+	cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+// parseShareOutputs parses --output into one location per share and rejects reused cloud providers
+func parseShareOutputs(outputLocations string, shares int) ([]string, error) {
+	var outputs []string
+	seen := make(map[string]bool)
+	for _, output := range strings.Split(outputLocations, ",") {
+		output = strings.TrimSpace(output)
+		if output == "" {
+			continue
+		}
+		if seen[output] {
+			return nil, fmt.Errorf("output location %s is used more than once", output)
+		}
+		seen[output] = true
+		outputs = append(outputs, output)
+	}
+
+	if len(outputs) != shares {
+		return nil, fmt.Errorf("--output must list exactly %d locations (one per share), got %d", shares, len(outputs))
+	}
+	return outputs, nil
+}
+
+// shareFilePath returns the local file path of a share (a .json path is used as-is)
+func shareFilePath(location, shareName string, index int) string {
+	if strings.HasSuffix(location, ".json") {
+		return location
+	}
+	return filepath.Join(location, fmt.Sprintf("%s-share-%d.json", shareName, index))
+}
+
+// walletSecretBytes returns the bytes that are split: the BIP39 entropy or the raw private key
+func walletSecretBytes(wallet WalletFile, secret string) ([]byte, error) {
+	if isPrivateKeyWallet(wallet) {
+		_, privateKeyBytes, err := getAddressFromPrivateKey(secret)
+		return privateKeyBytes, err
+	}
+
+	entropy, err := bip39.EntropyFromMnemonic(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic in wallet: %v", err)
+	}
+	return entropy, nil
+}
+
+// shareChecksum returns a short checksum of the secret used to detect wrong share sets
+func shareChecksum(secret []byte) []byte {
+	sum := sha256.Sum256(secret)
+	return sum[:shareChecksumLen]
+}
+
+// appendShareChecksum returns the secret followed by its checksum, which is split with it
+func appendShareChecksum(secret []byte) []byte {
+	sealed := make([]byte, 0, len(secret)+shareChecksumLen)
+	sealed = append(sealed, secret...)
+	return append(sealed, shareChecksum(secret)...)
+}
+
+// openShareSecret verifies the checksum of a combined secret and returns the secret without it
+func openShareSecret(combined []byte) ([]byte, error) {
+	if len(combined) <= shareChecksumLen {
+		return nil, fmt.Errorf("combined secret is too short")
+	}
+	secret := combined[:len(combined)-shareChecksumLen]
+	checksum := combined[len(combined)-shareChecksumLen:]
+	if !bytes.Equal(shareChecksum(secret), checksum) {
+		return nil, fmt.Errorf("combined secret does not match its checksum (corrupted or mismatched shares, or a wrong share password)")
+	}
+	return secret, nil
+}
+
+// newShareSetID returns a random identifier shared by all shares of one split
+func newShareSetID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to generate share set id: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
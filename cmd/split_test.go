@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+)
+
+func TestShareChecksumRoundTrip(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x42}, 16)
+
+	shares, err := util.SplitSecret(appendShareChecksum(secret), 2, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	combined, err := util.CombineShares(shares[1:])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	opened, err := openShareSecret(combined)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(opened, secret) {
+		t.Errorf("Unexpected secret: %x", opened)
+	}
+
+	// Shares of another split combine into garbage that fails the checksum
+	other, err := util.SplitSecret(appendShareChecksum(bytes.Repeat([]byte{0x24}, 16)), 2, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mixed, err := util.CombineShares([]util.ShamirShare{shares[0], other[1]})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := openShareSecret(mixed); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("Expected checksum error for mismatched shares, got %v", err)
+	}
+
+	if _, err := openShareSecret([]byte{1, 2}); err == nil {
+		t.Error("Expected error for a too short secret")
+	}
+}
+
+func TestValidateShareSet(t *testing.T) {
+	share := func(index uint8, setID string) ShareFile {
+		return ShareFile{Version: ShareFileVersion, Scheme: ShareScheme, SetID: setID, Index: index, Threshold: 2, Shares: 3}
+	}
+
+	tests := []struct {
+		name    string
+		shares  []ShareFile
+		wantErr string
+	}{
+		{"valid", []ShareFile{share(1, "a"), share(3, "a")}, ""},
+		{"no shares", nil, "no shares"},
+		{"different set", []ShareFile{share(1, "a"), share(2, "b")}, "different split"},
+		{"duplicate", []ShareFile{share(1, "a"), share(1, "a")}, "more than once"},
+		{"below threshold", []ShareFile{share(1, "a")}, "are required"},
+		{"future version", []ShareFile{{Version: ShareFileVersion + 1, Scheme: ShareScheme}}, "unsupported share file version"},
+		{"old version", []ShareFile{{Version: 1, Scheme: ShareScheme}}, "unsupported share file version"},
+	}
+
+	for _, tc := range tests {
+		err := validateShareSet(tc.shares)
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
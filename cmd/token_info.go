@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// tokenInfo is the symbol, decimals and (when an owner was given) balance of an ERC20 token
+type tokenInfo struct {
+	Symbol      string
+	Decimals    uint8
+	Balance     *big.Int
+	DecimalsErr error // set when decimals() gave no usable value and DefaultTokenDecimals is used
+}
+
+// tokenInfoCallNames names the calls made for each token, in the order of tokenInfoCalls
+var tokenInfoCallNames = []string{"symbol()", "decimals()", "balanceOf(address)"}
+
+// fetchTokenInfo gets the symbol and decimals of each token, plus its balance when owner is
+// not nil, at the given block (nil for latest). The lookups go out as a single Multicall3
+// eth_call; on chains or blocks without Multicall3 it falls back to one call per value.
+func fetchTokenInfo(client *ethclient.Client, tokens []common.Address, owner *common.Address, blockNumber *big.Int) ([]tokenInfo, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	results, err := util.Aggregate3(client, tokenInfoCalls(tokens, owner), blockNumber)
+	if err != nil {
+		return fetchTokenInfoDirect(client, tokens, owner, blockNumber)
+	}
+
+	infos, err := decodeTokenInfoResults(tokens, owner, results)
+	if err != nil {
+		return nil, err
+	}
+	warnTokenDecimals(tokens, infos)
+	return infos, nil
+}
+
+// warnTokenDecimals prints a warning for each token whose decimals fell back to the default.
+// Warnings go to stderr so they do not mix with JSON output.
+func warnTokenDecimals(tokens []common.Address, infos []tokenInfo) {
+	for i, info := range infos {
+		if info.DecimalsErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: token %s: %v, using default value (%d)\n", tokens[i].Hex(), info.DecimalsErr, DefaultTokenDecimals)
+		}
+	}
+}
+
+// tokenInfoCalls builds the symbol, decimals and optional balanceOf calls for each token.
+// Failures are allowed so one broken token does not revert the whole batch.
+func tokenInfoCalls(tokens []common.Address, owner *common.Address) []util.Call3 {
+	var calls []util.Call3
+	for _, token := range tokens {
+		calls = append(calls,
+			util.Call3{Target: token, AllowFailure: true, CallData: erc20SymbolSelector},
+			util.Call3{Target: token, AllowFailure: true, CallData: erc20DecimalsSelector},
+		)
+		if owner != nil {
+			calls = append(calls, util.Call3{Target: token, AllowFailure: true, CallData: encodeERC20BalanceOf(*owner)})
+		}
+	}
+	return calls
+}
+
+// decodeTokenInfoResults decodes the results of the calls built by tokenInfoCalls
+func decodeTokenInfoResults(tokens []common.Address, owner *common.Address, results []util.MulticallResult) ([]tokenInfo, error) {
+	callsPerToken := 2
+	if owner != nil {
+		callsPerToken = 3
+	}
+	if len(results) != len(tokens)*callsPerToken {
+		return nil, fmt.Errorf("expected %d results, got %d", len(tokens)*callsPerToken, len(results))
+	}
+
+	infos := make([]tokenInfo, len(tokens))
+	for i, token := range tokens {
+		tokenResults := results[i*callsPerToken : (i+1)*callsPerToken]
+		for j, result := range tokenResults {
+			if !result.Success {
+				return nil, fmt.Errorf("%s call to token %s reverted", tokenInfoCallNames[j], token.Hex())
+			}
+		}
+
+		infos[i].Symbol = decodeERC20Symbol(tokenResults[0].ReturnData)
+		decimals, err := decodeERC20Decimals(tokenResults[1].ReturnData)
+		if err != nil {
+			decimals = DefaultTokenDecimals
+			infos[i].DecimalsErr = err
+		}
+		infos[i].Decimals = decimals
+		if owner != nil {
+			balance, err := decodeERC20Balance(tokenResults[2].ReturnData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get balance of token %s: %v", token.Hex(), err)
+			}
+			infos[i].Balance = balance
+		}
+	}
+	return infos, nil
+}
+
+// fetchTokenInfoDirect gets the same values as fetchTokenInfo with one eth_call per value at the
+// given block, decoded as the Multicall3 results are
+func fetchTokenInfoDirect(client *ethclient.Client, tokens []common.Address, owner *common.Address, blockNumber *big.Int) ([]tokenInfo, error) {
+	calls := tokenInfoCalls(tokens, owner)
+	results := make([]util.MulticallResult, len(calls))
+	for i, call := range calls {
+		output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &call.Target, Data: call.CallData}, blockNumber)
+		results[i] = util.MulticallResult{Success: err == nil, ReturnData: output}
+	}
+
+	infos, err := decodeTokenInfoResults(tokens, owner, results)
+	if err != nil {
+		return nil, err
+	}
+	warnTokenDecimals(tokens, infos)
+	return infos, nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func TestTokenInfoCalls(t *testing.T) {
+	tokens := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	owner := common.HexToAddress("0xaa")
+
+	if calls := tokenInfoCalls(tokens, nil); len(calls) != 4 {
+		t.Errorf("Expected 4 calls without an owner, got %d", len(calls))
+	}
+
+	calls := tokenInfoCalls(tokens, &owner)
+	if len(calls) != 6 {
+		t.Fatalf("Expected 6 calls with an owner, got %d", len(calls))
+	}
+	for i, call := range calls {
+		if !call.AllowFailure {
+			t.Errorf("Call %d does not allow failure", i)
+		}
+		if call.Target != tokens[i/3] {
+			t.Errorf("Call %d targets %s, want %s", i, call.Target.Hex(), tokens[i/3].Hex())
+		}
+	}
+}
+
+func TestDecodeTokenInfoResults(t *testing.T) {
+	tokens := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	owner := common.HexToAddress("0xaa")
+	ok := func(data []byte) util.MulticallResult {
+		return util.MulticallResult{Success: true, ReturnData: data}
+	}
+
+	tests := []struct {
+		name    string
+		owner   *common.Address
+		results []util.MulticallResult
+		wantErr string
+	}{
+		{
+			name:  "metadata only",
+			owner: nil,
+			results: []util.MulticallResult{
+				ok(abiString("USDC")), ok(abiWord(6)),
+				ok(abiString("DAI")), ok(abiWord(18)),
+			},
+		},
+		{
+			name:  "with balances",
+			owner: &owner,
+			results: []util.MulticallResult{
+				ok(abiString("USDC")), ok(abiWord(6)), ok(abiWord(100)),
+				ok(abiString("DAI")), ok(abiWord(18)), ok(abiWord(200)),
+			},
+		},
+		{
+			name:    "result count mismatch",
+			owner:   &owner,
+			results: []util.MulticallResult{ok(abiString("USDC")), ok(abiWord(6))},
+			wantErr: "expected 6 results",
+		},
+		{
+			name:  "failed sub-call",
+			owner: nil,
+			results: []util.MulticallResult{
+				ok(abiString("USDC")), ok(abiWord(6)),
+				{Success: false}, ok(abiWord(18)),
+			},
+			wantErr: "symbol() call to token " + tokens[1].Hex() + " reverted",
+		},
+		{
+			name:  "short balance",
+			owner: &owner,
+			results: []util.MulticallResult{
+				ok(abiString("USDC")), ok(abiWord(6)), ok(abiWord(100)),
+				ok(abiString("DAI")), ok(abiWord(18)), ok(nil),
+			},
+			wantErr: "failed to get balance of token " + tokens[1].Hex(),
+		},
+	}
+
+	for _, tc := range tests {
+		infos, err := decodeTokenInfoResults(tokens, tc.owner, tc.results)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if infos[0].Symbol != "USDC" || infos[0].Decimals != 6 || infos[1].Symbol != "DAI" || infos[1].Decimals != 18 {
+			t.Errorf("%s: unexpected infos: %+v", tc.name, infos)
+		}
+		if tc.owner != nil && (infos[0].Balance.Int64() != 100 || infos[1].Balance.Int64() != 200) {
+			t.Errorf("%s: unexpected balances: %v, %v", tc.name, infos[0].Balance, infos[1].Balance)
+		}
+	}
+}
+
+func TestDecodeTokenInfoResultsDefaultDecimals(t *testing.T) {
+	tokens := []common.Address{common.HexToAddress("0x01")}
+	results := []util.MulticallResult{
+		{Success: true, ReturnData: abiString("ODD")},
+		{Success: true, ReturnData: nil},
+	}
+
+	infos, err := decodeTokenInfoResults(tokens, nil, results)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if infos[0].Decimals != DefaultTokenDecimals || infos[0].DecimalsErr == nil {
+		t.Errorf("Expected default decimals with an error, got %+v", infos[0])
+	}
+}
+
+func TestFetchTokenInfoDirect(t *testing.T) {
+	// A token on a chain without Multicall3 whose decimals() returns nothing
+	var blocks []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Params) != 2 {
+			t.Errorf("Invalid request: %v", err)
+			return
+		}
+		var call struct {
+			Input hexutil.Bytes `json:"input"`
+		}
+		var block string
+		json.Unmarshal(request.Params[0], &call)
+		json.Unmarshal(request.Params[1], &block)
+		blocks = append(blocks, block)
+
+		var output []byte
+		switch {
+		case bytes.Equal(call.Input, erc20SymbolSelector):
+			output = abiString("ODD")
+		case bytes.Equal(call.Input, erc20DecimalsSelector):
+			output = nil
+		default:
+			output = abiWord(100)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(request.ID) + `,"result":"` + hexutil.Encode(output) + `"}`))
+	}))
+	defer server.Close()
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to dial fake RPC: %v", err)
+	}
+	owner := common.HexToAddress("0xaa")
+	infos, err := fetchTokenInfoDirect(client, []common.Address{common.HexToAddress("0x01")}, &owner, big.NewInt(16))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if infos[0].Symbol != "ODD" || infos[0].Decimals != DefaultTokenDecimals || infos[0].DecimalsErr == nil || infos[0].Balance.Int64() != 100 {
+		t.Errorf("Unexpected info: %+v", infos[0])
+	}
+	if len(blocks) != 3 || blocks[0] != "0x10" || blocks[1] != "0x10" || blocks[2] != "0x10" {
+		t.Errorf("Expected every call at block 0x10, got %v", blocks)
+	}
+}
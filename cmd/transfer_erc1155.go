@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// ERC1155BalanceOfSignature is the function signature for the ERC1155 balanceOf function
+const ERC1155BalanceOfSignature = "balanceOf(address,uint256)"
+
+// ERC1155InterfaceID is the ERC165 interface ID of ERC1155
+var ERC1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+
+// TransferERC1155Cmd creates the ERC1155 transfer command
+func TransferERC1155Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transferERC1155",
+		Short: "Transfer ERC1155 (multi-token) tokens to another address",
+		Long: `Transfer ERC1155 tokens to another Ethereum address.
+
+A single --id is sent with safeTransferFrom; several comma-separated ids are sent in one
+transaction with safeBatchTransferFrom, with one amount per id in --amount. The balance of
+every id is checked with balanceOf(address,id) before signing.
+
+Examples:
+  eth-cli transferERC1155 -p google -n myWallet --token 0xGame --to 0xFriend --id 7 --amount 3
+  eth-cli transferERC1155 -f ./wallet.json --token 0xGame --to 0xFriend --id 1,2,5 --amount 10,1,1`,
+		RunE: runTransferERC1155,
+	}
+
+	cmd.Flags().String("id", "", "Token ID, or comma-separated token IDs for a batch transfer")
+	cmd.Flags().String("amount", "", "Amount, or comma-separated amounts (one per token ID)")
+	cmd.Flags().StringP("to", "t", "", "Destination address")
+	cmd.Flags().String("token", "", "ERC1155 token contract address")
+	cmd.Flags().String("data", "0x", "Data passed to the receiver's onERC1155Received hook (hex)")
+	addContractTxFlags(cmd)
+
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("amount")
+	cmd.MarkFlagRequired("to")
+	cmd.MarkFlagRequired("token")
+
+	return cmd
+}
+
+func runTransferERC1155(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	idsStr, _ := cmd.Flags().GetString("id")
+	amountsStr, _ := cmd.Flags().GetString("amount")
+	to, _ := cmd.Flags().GetString("to")
+	tokenAddress, _ := cmd.Flags().GetString("token")
+	dataStr, _ := cmd.Flags().GetString("data")
+
+	// Validate addresses
+	if !common.IsHexAddress(to) {
+		return fmt.Errorf("invalid 'to' address format: %s", to)
+	}
+	if common.HexToAddress(to) == (common.Address{}) {
+		return fmt.Errorf("cannot transfer to the zero address")
+	}
+	if !common.IsHexAddress(tokenAddress) {
+		return fmt.Errorf("invalid token address format: %s", tokenAddress)
+	}
+	tokenAddr := common.HexToAddress(tokenAddress)
+
+	tokenIDs, amounts, err := parseERC1155Amounts(idsStr, amountsStr)
+	if err != nil {
+		return err
+	}
+	data, err := hexutil.Decode(dataStr)
+	if err != nil {
+		return fmt.Errorf("invalid --data: %v", err)
+	}
+
+	_, err = runContractTx(cmd, func(txCtx contractTxContext) (contractTxRequest, error) {
+		callData, err := util.EncodeERC1155Transfer(txCtx.From.Hex(), to, tokenIDs, amounts, data)
+		if err != nil {
+			return contractTxRequest{}, err
+		}
+
+		req := contractTxRequest{
+			To:              &tokenAddr,
+			Data:            callData,
+			DefaultGasLimit: uint64(100000 + 50000*len(tokenIDs)),
+		}
+
+		method := "safeTransferFrom"
+		if len(tokenIDs) > 1 {
+			method = "safeBatchTransferFrom"
+		}
+		req.Details = append(req.Details, [2]string{"Recipient", common.HexToAddress(to).Hex()})
+		req.Details = append(req.Details, [2]string{"Token Contract", tokenAddr.Hex() + " (ERC1155 " + method + ")"})
+
+		// Check the balance of every id before signing
+		var balances map[string]*big.Int
+		if txCtx.Client != nil {
+			if supported, err := supportsInterface(txCtx.Client, tokenAddr, ERC1155InterfaceID); err != nil || !supported {
+				fmt.Printf("\033[33mWARNING: %s does not report ERC1155 support (supportsInterface).\033[0m\n", tokenAddr.Hex())
+			}
+			balances, err = checkERC1155Balances(txCtx.Client, tokenAddr, txCtx.From, tokenIDs, amounts)
+			if err != nil {
+				return req, err
+			}
+		}
+		for i, tokenID := range tokenIDs {
+			line := amounts[i].String()
+			if balance, ok := balances[tokenID.String()]; ok {
+				line += fmt.Sprintf(" (balance %s)", balance)
+			}
+			req.Details = append(req.Details, [2]string{"Token ID " + tokenID.String(), line})
+		}
+		return req, nil
+	})
+	return err
+}
+
+// parseERC1155Amounts parses the comma-separated token IDs and their amounts
+func parseERC1155Amounts(idsStr, amountsStr string) ([]*big.Int, []*big.Int, error) {
+	idParts := strings.Split(idsStr, ",")
+	amountParts := strings.Split(amountsStr, ",")
+	if len(idParts) != len(amountParts) {
+		return nil, nil, fmt.Errorf("%d token ID(s) but %d amount(s), give one amount per token ID", len(idParts), len(amountParts))
+	}
+
+	tokenIDs := make([]*big.Int, len(idParts))
+	amounts := make([]*big.Int, len(amountParts))
+	for i := range idParts {
+		tokenID, ok := new(big.Int).SetString(strings.TrimSpace(idParts[i]), 0)
+		if !ok || tokenID.Sign() < 0 || tokenID.BitLen() > 256 {
+			return nil, nil, fmt.Errorf("invalid token ID format: %s", idParts[i])
+		}
+		amount, ok := new(big.Int).SetString(strings.TrimSpace(amountParts[i]), 0)
+		if !ok || amount.Sign() <= 0 || amount.BitLen() > 256 {
+			return nil, nil, fmt.Errorf("invalid amount for token ID %s: %s", tokenID, amountParts[i])
+		}
+		tokenIDs[i] = tokenID
+		amounts[i] = amount
+	}
+	return tokenIDs, amounts, nil
+}
+
+// checkERC1155Balances checks that owner holds the total amount of every token ID, returning the balances
+func checkERC1155Balances(client *ethclient.Client, token, owner common.Address, tokenIDs, amounts []*big.Int) (map[string]*big.Int, error) {
+	needed := make(map[string]*big.Int)
+	for i, tokenID := range tokenIDs {
+		key := tokenID.String()
+		if needed[key] == nil {
+			needed[key] = new(big.Int)
+		}
+		needed[key].Add(needed[key], amounts[i])
+	}
+
+	balances := make(map[string]*big.Int)
+	for _, tokenID := range tokenIDs {
+		key := tokenID.String()
+		if _, ok := balances[key]; ok {
+			continue
+		}
+		balance, err := erc1155BalanceOf(client, token, owner, tokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of token ID %s: %v", key, err)
+		}
+		balances[key] = balance
+		if balance.Cmp(needed[key]) < 0 {
+			return nil, fmt.Errorf("insufficient balance of token ID %s: %s needed, %s available", key, needed[key], balance)
+		}
+	}
+	return balances, nil
+}
+
+// erc1155BalanceOf returns balanceOf(owner, id) of an ERC1155 contract
+func erc1155BalanceOf(client *ethclient.Client, token, owner common.Address, tokenID *big.Int) (*big.Int, error) {
+	data, err := util.EncodeArgumentValues(ERC1155BalanceOfSignature, owner, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("unexpected balanceOf result: %s", hexutil.Encode(result))
+	}
+	return new(big.Int).SetBytes(result[:32]), nil
+}
+
+// supportsInterface calls ERC165 supportsInterface(interfaceID) on a contract
+func supportsInterface(client *ethclient.Client, contract common.Address, interfaceID [4]byte) (bool, error) {
+	data, err := util.EncodeArgumentValues("supportsInterface(bytes4)", interfaceID)
+	if err != nil {
+		return false, err
+	}
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return false, err
+	}
+	return decodeABIBool(result)
+}
+
+// decodeABIBool decodes a bool return value
+func decodeABIBool(result []byte) (bool, error) {
+	if len(result) < 32 {
+		return false, fmt.Errorf("unexpected bool result: %s", hexutil.Encode(result))
+	}
+	value := new(big.Int).SetBytes(result[:32])
+	if value.Cmp(big.NewInt(1)) > 0 {
+		return false, fmt.Errorf("unexpected bool result: %s", hexutil.Encode(result))
+	}
+	return value.Sign() == 1, nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseERC1155Amounts(t *testing.T) {
+	tokenIDs, amounts, err := parseERC1155Amounts("1, 0x02,5", "10,1, 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tokenIDs) != 3 || tokenIDs[1].Int64() != 2 || amounts[0].Int64() != 10 || amounts[2].Int64() != 1 {
+		t.Errorf("Unexpected ids/amounts: %v %v", tokenIDs, amounts)
+	}
+
+	invalid := [][2]string{
+		{"1,2", "1"},
+		{"abc", "1"},
+		{"-1", "1"},
+		{"1", "0"},
+		{"1", "-5"},
+		{"1", ""},
+	}
+	for _, tc := range invalid {
+		if _, _, err := parseERC1155Amounts(tc[0], tc[1]); err == nil {
+			t.Errorf("Expected error for ids %q and amounts %q, but got none", tc[0], tc[1])
+		}
+	}
+}
+
+func TestDecodeABIBool(t *testing.T) {
+	for _, tc := range []struct {
+		result  []byte
+		want    bool
+		wantErr bool
+	}{
+		{abiWord(1), true, false},
+		{abiWord(0), false, false},
+		{abiWord(2), false, true},
+		{nil, false, true},
+	} {
+		got, err := decodeABIBool(tc.result)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("decodeABIBool(%x) = %v, %v", tc.result, got, err)
+		}
+	}
+}
@@ -5,12 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"time"
 
 	"github.com/ethanzhrepo/eth-cli-wallet/util"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 )
@@ -32,30 +30,80 @@ type ERC20Contract struct {
 	address common.Address
 }
 
+// ERC20 function selectors
+var (
+	erc20SymbolSelector    = []byte{0x95, 0xd8, 0x9b, 0x41} // keccak256("symbol()")[:4]
+	erc20DecimalsSelector  = []byte{0x31, 0x3c, 0xe5, 0x67} // keccak256("decimals()")[:4]
+	erc20BalanceOfSelector = []byte{0x70, 0xa0, 0x82, 0x31} // keccak256("balanceOf(address)")[:4]
+)
+
 // Symbol returns the token's symbol
 func (e *ERC20Contract) Symbol(ctx context.Context) (string, error) {
-	// This is a simplified version; in a real implementation, you'd use ABI binding
-	callData := []byte{0x95, 0xd8, 0x9b, 0x41} // keccak256("symbol()")[:4]
 	msg := ethereum.CallMsg{
 		To:   &e.address,
-		Data: callData,
+		Data: erc20SymbolSelector,
 	}
 	result, err := e.client.CallContract(ctx, msg, nil)
 	if err != nil {
 		return "", err
 	}
 
-	// Simple parsing: Assuming result is a bytes32 string
-	// In real implementation, properly decode according to ABI
+	return decodeERC20Symbol(result), nil
+}
+
+// Decimals returns the token's decimal places
+func (e *ERC20Contract) Decimals(ctx context.Context) (uint8, error) {
+	msg := ethereum.CallMsg{
+		To:   &e.address,
+		Data: erc20DecimalsSelector,
+	}
+	result, err := e.client.CallContract(ctx, msg, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, err := decodeERC20Decimals(result)
+	if err != nil {
+		fmt.Printf("Warning: token %s: %v, using default value (%d)\n", e.address.Hex(), err, DefaultTokenDecimals)
+		return DefaultTokenDecimals, nil
+	}
+
+	return decimals, nil
+}
+
+// BalanceOf returns the token balance of an address at the given block (nil for latest)
+func (e *ERC20Contract) BalanceOf(ctx context.Context, owner common.Address, blockNumber *big.Int) (*big.Int, error) {
+	msg := ethereum.CallMsg{
+		To:   &e.address,
+		Data: encodeERC20BalanceOf(owner),
+	}
+	result, err := e.client.CallContract(ctx, msg, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeERC20Balance(result)
+}
+
+// encodeERC20BalanceOf encodes a balanceOf(owner) call
+func encodeERC20BalanceOf(owner common.Address) []byte {
+	return append(append([]byte{}, erc20BalanceOfSelector...), common.LeftPadBytes(owner.Bytes(), 32)...)
+}
+
+// decodeERC20Symbol decodes the result of symbol(), which is a string for most tokens
+// and a bytes32 for some older ones. Malformed results decode to an empty symbol.
+func decodeERC20Symbol(result []byte) string {
 	symbol := ""
 	if len(result) > 32 {
-		// Handle dynamic string
-		offset := new(big.Int).SetBytes(result[0:32]).Int64()
-		if offset < int64(len(result)) {
-			length := new(big.Int).SetBytes(result[offset : offset+32]).Int64()
-			if offset+32+length <= int64(len(result)) {
-				symbolBytes := result[offset+32 : offset+32+length]
-				symbol = string(symbolBytes)
+		// Handle dynamic string; the offset and length come from the contract, so bound
+		// them before slicing
+		size := big.NewInt(int64(len(result)))
+		offset := new(big.Int).SetBytes(result[0:32])
+		if new(big.Int).Add(offset, big.NewInt(32)).Cmp(size) <= 0 {
+			start := offset.Int64() + 32
+			length := new(big.Int).SetBytes(result[start-32 : start])
+			if new(big.Int).Add(big.NewInt(start), length).Cmp(size) <= 0 {
+				symbol = string(result[start : start+length.Int64()])
 			}
 		}
 	} else if len(result) > 0 {
@@ -68,47 +116,34 @@ func (e *ERC20Contract) Symbol(ctx context.Context) (string, error) {
 		symbol = string(result[:i])
 	}
 
-	return symbol, nil
+	return symbol
 }
 
-// Decimals returns the token's decimal places
-func (e *ERC20Contract) Decimals(ctx context.Context) (uint8, error) {
-	// This is a simplified version; in a real implementation, you'd use ABI binding
-	callData := []byte{0x31, 0x3c, 0xe5, 0x67} // keccak256("decimals()")[:4]
-	msg := ethereum.CallMsg{
-		To:   &e.address,
-		Data: callData,
-	}
-	result, err := e.client.CallContract(ctx, msg, nil)
-	if err != nil {
-		return 0, err
-	}
-
+// decodeERC20Decimals decodes the result of decimals(). It returns an error for empty or
+// implausible values, for which callers fall back to DefaultTokenDecimals.
+func decodeERC20Decimals(result []byte) (uint8, error) {
 	if len(result) == 0 {
-		fmt.Printf("Warning: Token contract returned empty result for decimals, using default value (18)\n")
-		return 18, nil
-	}
-
-	// Extract decimal places - handle different response formats
-	var decimals uint8
-	if len(result) == 32 {
-		// Standard uint8 response, but packed in a uint256
-		decimals = uint8(new(big.Int).SetBytes(result).Uint64())
-	} else if len(result) == 1 {
-		// Direct uint8 response
-		decimals = uint8(result[0])
-	} else {
-		// Try to parse as uint256 anyway and hope for the best
-		decimals = uint8(new(big.Int).SetBytes(result).Uint64())
+		return 0, fmt.Errorf("empty result for decimals")
 	}
 
+	// Standard responses are a uint8 packed in a uint256; a few tokens return a single byte
+	decimals := new(big.Int).SetBytes(result)
+
 	// Sanity check: Decimals usually between 0 and 24
-	if decimals > MaxSaneTokenDecimals {
-		fmt.Printf("Warning: Unusual decimals value: %d, using default value (%d)\n", decimals, DefaultTokenDecimals)
-		return DefaultTokenDecimals, nil
+	if decimals.Cmp(big.NewInt(MaxSaneTokenDecimals)) > 0 {
+		return 0, fmt.Errorf("unusual decimals value: %s", decimals)
 	}
 
-	return decimals, nil
+	return uint8(decimals.Uint64()), nil
+}
+
+// decodeERC20Balance decodes the result of balanceOf()
+func decodeERC20Balance(result []byte) (*big.Int, error) {
+	if len(result) < 32 {
+		return nil, fmt.Errorf("unexpected balanceOf result of %d bytes (no token contract at this block?)", len(result))
+	}
+
+	return new(big.Int).SetBytes(result[:32]), nil
 }
 
 // NewERC20Contract creates a new ERC20 contract instance
@@ -134,10 +169,11 @@ func TransferERC20Cmd() *cobra.Command {
 	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., googledrive)")
 	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
 	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
 	cmd.Flags().Bool("dry-run", false, "Only encode the transaction, do not broadcast")
 	cmd.Flags().Bool("estimate-only", false, "Only display gas estimation")
 	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the transaction")
-	cmd.Flags().String("gas-price", "", "Gas price (e.g., 3gwei)")
+	addFeeFlags(cmd)
 	cmd.Flags().Uint64("gas-limit", 0, "Gas limit")
 	cmd.Flags().Uint64("chain-id", 1, "Chain ID to use in dry-run mode (default: 1)")
 	cmd.Flags().Uint64("nonce", 0, "Nonce to use in dry-run mode (required when chain-id is specified)")
@@ -158,20 +194,12 @@ func setupClientAndTokenInfo(rpcURL, tokenAddress string) (*ethclient.Client, st
 	}
 	fmt.Printf("Using RPC: %s\n", rpcURL)
 
-	// Get token info
-	tokenContract := NewERC20Contract(client, common.HexToAddress(tokenAddress))
-
-	// Get token symbol
-	tokenSymbol, err := tokenContract.Symbol(context.Background())
-	if err != nil {
-		return client, "", 0, fmt.Errorf("failed to get token symbol: %v", err)
-	}
-
-	// Get token decimals
-	tokenDecimals, err := tokenContract.Decimals(context.Background())
+	// Get token symbol and decimals
+	infos, err := fetchTokenInfo(client, []common.Address{common.HexToAddress(tokenAddress)}, nil, nil)
 	if err != nil {
-		return client, tokenSymbol, 0, fmt.Errorf("failed to get token decimals: %v", err)
+		return client, "", 0, err
 	}
+	tokenSymbol, tokenDecimals := infos[0].Symbol, infos[0].Decimals
 
 	fmt.Printf("Token Symbol: %s\n", tokenSymbol)
 	fmt.Printf("Token Decimals: %d\n", tokenDecimals)
@@ -179,28 +207,19 @@ func setupClientAndTokenInfo(rpcURL, tokenAddress string) (*ethclient.Client, st
 	return client, tokenSymbol, tokenDecimals, nil
 }
 
-// determineGasParameters gets gas price and estimates gas limit for an ERC20 transfer
-func determineGasParameters(client *ethclient.Client, fromAddress, tokenAddress, to string, amount *big.Int, gasLimit uint64, gasPriceStr string, dryRun bool) (uint64, *big.Int, error) {
-	// Get gas price
-	var gasPrice *big.Int
-	var err error
-
-	if gasPriceStr != "" {
-		gasPrice, err = parseEthAmount(gasPriceStr)
-		if err != nil {
-			return 0, nil, err
-		}
-	} else if !dryRun {
-		gasPrice, err = client.SuggestGasPrice(context.Background())
-		if err != nil {
-			return 0, nil, fmt.Errorf("failed to get suggested gas price: %v", err)
+// determineGasParameters gets the transaction type and fees and estimates gas limit for an ERC20 transfer
+func determineGasParameters(cmd *cobra.Command, client *ethclient.Client, fromAddress, tokenAddress, to string, amount *big.Int, gasLimit uint64, dryRun bool) (uint64, util.TxParams, error) {
+	// Get transaction type and fees
+	txParams, err := resolveTxParams(cmd, client, dryRun)
+	if err != nil {
+		return 0, txParams, err
+	}
+	if !dryRun {
+		if txParams.Type == util.TxTypeDynamicFee {
+			fmt.Printf("Suggested Max Fee: %s Gwei, Priority Fee: %s Gwei\n", formatGwei(txParams.Fees.MaxFeePerGas), formatGwei(txParams.Fees.MaxPriorityFeePerGas))
+		} else {
+			fmt.Printf("Suggested Gas Price: %s Gwei\n", formatGwei(txParams.Fees.MaxFeePerGas))
 		}
-		fmt.Printf("Suggested Gas Price: %s Gwei\n", new(big.Float).Quo(
-			new(big.Float).SetInt(gasPrice),
-			new(big.Float).SetInt(big.NewInt(GweiToWei)),
-		).Text('f', 9))
-	} else {
-		gasPrice = big.NewInt(DefaultDryRunGasPrice) // Default 1 Gwei if dry run
 	}
 
 	// Get gas limit
@@ -254,11 +273,11 @@ func determineGasParameters(client *ethclient.Client, fromAddress, tokenAddress,
 			// Check account balance
 			balance, balErr := client.BalanceAt(context.Background(), fromAddr, nil)
 			if balErr == nil {
-				fmt.Printf("INFO: Current account balance: %s ETH\n",
+				fmt.Printf("INFO: Current account balance: %s %s\n",
 					new(big.Float).Quo(
 						new(big.Float).SetInt(balance),
 						new(big.Float).SetInt(big.NewInt(EthToWei)),
-					).Text('f', 18))
+					).Text('f', 18), nativeSymbol())
 			}
 
 			// Fall back to default gas limit
@@ -270,36 +289,20 @@ func determineGasParameters(client *ethclient.Client, fromAddress, tokenAddress,
 			fmt.Printf("Estimated gas with buffer: %d\n", gasLimit)
 		}
 	} else if gasLimit == 0 && dryRun {
-		return 0, nil, fmt.Errorf("gas limit is required when --dry-run is true")
+		return 0, txParams, fmt.Errorf("gas limit is required when --dry-run is true")
 	}
 
-	return gasLimit, gasPrice, nil
+	return gasLimit, txParams, nil
 }
 
 // formatAndDisplayTxDetails formats and displays transaction details for user confirmation
 func formatAndDisplayTxDetails(
 	fromAddress, to, tokenAddress, tokenSymbol string,
 	amount *big.Int, tokenDecimals uint8,
-	gasLimit uint64, gasPrice *big.Int, nonce uint64) {
+	gasLimit uint64, txParams util.TxParams, nonce uint64) {
 
 	// Convert amount to token units for display using the token's decimal places
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenDecimals)), nil)
-	amountInt := new(big.Int).Div(amount, divisor)
-	amountRemainder := new(big.Int).Mod(amount, divisor)
-
-	// Format with the correct number of decimal places
-	displayAmount := fmt.Sprintf("%d.%0*d", amountInt, tokenDecimals, amountRemainder)
-
-	// Convert gas price to Gwei
-	gasPriceGwei := new(big.Int).Div(gasPrice, big.NewInt(GweiToWei))
-	gasPriceRemainder := new(big.Int).Mod(gasPrice, big.NewInt(GweiToWei))
-	displayGasPrice := fmt.Sprintf("%d.%09d", gasPriceGwei, gasPriceRemainder)
-
-	// Calculate gas fee in Wei
-	gasFee := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
-	gasFeeEth := new(big.Int).Div(gasFee, big.NewInt(EthToWei))
-	gasFeeRemainder := new(big.Int).Mod(gasFee, big.NewInt(EthToWei))
-	displayGasFee := fmt.Sprintf("%d.%018d", gasFeeEth, gasFeeRemainder)
+	displayAmount := util.FormatTokenAmount(amount, tokenDecimals)
 
 	fmt.Println("Transaction Details:")
 	fmt.Printf("From: %s\n", fromAddress)
@@ -307,8 +310,7 @@ func formatAndDisplayTxDetails(
 	fmt.Printf("Token: %s (%s)\n", tokenAddress, tokenSymbol)
 	fmt.Printf("Amount: %s %s\n", displayAmount, tokenSymbol)
 	fmt.Printf("Gas Limit: %d\n", gasLimit)
-	fmt.Printf("Gas Price: %s Gwei\n", displayGasPrice)
-	fmt.Printf("Gas Fee: %s ETH\n", displayGasFee)
+	printFeeDetails(txParams, gasLimit)
 	fmt.Printf("Nonce: %d\n", nonce)
 }
 
@@ -316,27 +318,11 @@ func formatAndDisplayTxDetails(
 func waitForConfirmation(client *ethclient.Client, txHash string) error {
 	fmt.Println("Waiting for transaction confirmation...")
 
-	// Wait for transaction to be mined
-	var receipt *types.Receipt
-	for {
-		var receiptErr error
-		receipt, receiptErr = client.TransactionReceipt(context.Background(), common.HexToHash(txHash))
-		if receiptErr == nil {
-			break
-		}
-		if receiptErr != nil && receiptErr.Error() != "not found" {
-			return fmt.Errorf("failed to get transaction receipt: %v", receiptErr)
-		}
-		time.Sleep(2 * time.Second)
-	}
-
-	if receipt.Status == 1 {
-		fmt.Println("Transaction confirmed successfully!")
-	} else {
-		fmt.Println("Transaction failed!")
+	receipt, err := waitForReceipt(client, txHash, DefaultReceiptWaitTimeout)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("Block Number: %d\n", receipt.BlockNumber)
-	fmt.Printf("Gas Used: %d\n", receipt.GasUsed)
+	printReceiptSummary(receipt)
 
 	return nil
 }
@@ -352,7 +338,6 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	estimateOnly, _ := cmd.Flags().GetBool("estimate-only")
 	autoConfirm, _ := cmd.Flags().GetBool("yes")
-	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
 	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
 	sync, _ := cmd.Flags().GetBool("sync")
 
@@ -366,9 +351,21 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --provider or --file must be specified")
 	}
 
-	// Get RPC URL from config
-	rpcURL, err := initTxConfig()
-	if err != nil && !dryRun {
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Get RPC URL from config; dry runs do not need a reachable RPC
+	var rpcURL string
+	var err error
+	if dryRun {
+		rpcURL, err = initDryRunTxConfig()
+	} else {
+		rpcURL, err = initTxConfig()
+	}
+	if err != nil {
 		return err
 	}
 
@@ -419,12 +416,15 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	// Get private key from provider or file
 	var privateKey string
 	var fromAddress string
-	if filePath != "" {
+	if dryRun {
+		// Dry runs only need the address, so watch-only wallets work without a password
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
 		// Use local file
-		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath)
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
 	} else {
 		// Use provider
-		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name)
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get private key: %v", err)
@@ -435,7 +435,7 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	var nonce uint64
 	if !dryRun {
 		var chainErr error
-		chainID, chainErr = client.NetworkID(context.Background())
+		chainID, chainErr = client.ChainID(context.Background())
 		if chainErr != nil {
 			return fmt.Errorf("failed to get chain ID: %v", chainErr)
 		}
@@ -445,7 +445,10 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get nonce: %v", err)
 		}
 	} else {
-		chainIDValue, _ := cmd.Flags().GetUint64("chain-id")
+		chainIDValue, err := dryRunChainID(cmd)
+		if err != nil {
+			return err
+		}
 		chainID = big.NewInt(int64(chainIDValue))
 		nonceValue, _ := cmd.Flags().GetUint64("nonce")
 
@@ -458,7 +461,7 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine gas parameters
-	gasLimit, gasPrice, err := determineGasParameters(client, fromAddress, tokenAddress, to, amount, gasLimit, gasPriceStr, dryRun)
+	gasLimit, txParams, err := determineGasParameters(cmd, client, fromAddress, tokenAddress, to, amount, gasLimit, dryRun)
 	if err != nil {
 		return err
 	}
@@ -470,7 +473,7 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 		to,
 		amount,
 		nonce,
-		gasPrice,
+		txParams,
 		gasLimit,
 		chainID,
 	)
@@ -481,14 +484,7 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	// If gas only, just display and exit
 	if estimateOnly {
 		fmt.Printf("Estimated Gas Limit: %d\n", gasLimit)
-		fmt.Printf("Suggested Gas Price: %s Gwei\n", new(big.Float).Quo(
-			new(big.Float).SetInt(gasPrice),
-			new(big.Float).SetInt(big.NewInt(GweiToWei)),
-		).Text('f', 9))
-		fmt.Printf("Estimated Gas Fee: %s ETH\n", new(big.Float).Quo(
-			new(big.Float).SetInt(new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))),
-			new(big.Float).SetInt(big.NewInt(EthToWei)),
-		).Text('f', 18))
+		printFeeDetails(txParams, gasLimit)
 		return nil
 	}
 
@@ -498,6 +494,17 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Simulate the transaction before signing it
+	sim, err := simulateRawTx(client, fromAddress, rawTx)
+	if err != nil {
+		return err
+	}
+
+	// Check the chain ID it is signed for against the selected network
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		return err
+	}
+
 	// Sign the transaction
 	signedTx, err := util.SignTransaction(rawTx, privateKey)
 	if err != nil {
@@ -509,8 +516,9 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 		formatAndDisplayTxDetails(
 			fromAddress, to, tokenAddress, tokenSymbol,
 			amount, tokenDecimals,
-			gasLimit, gasPrice, nonce,
+			gasLimit, txParams, nonce,
 		)
+		printSimulation(sim)
 
 		// Ask for confirmation
 		fmt.Print("Confirm transaction? (y/N): ")
@@ -529,6 +537,7 @@ func runTransferERC20(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Transaction submitted: %s\n", txHash)
+	printExplorerLink(txHash)
 
 	// Wait for confirmation if requested
 	if sync {
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// abiWord returns n as a 32-byte ABI word
+func abiWord(n int64) []byte {
+	return common.LeftPadBytes(big.NewInt(n).Bytes(), 32)
+}
+
+// abiString returns the ABI encoding of a single string return value
+func abiString(s string) []byte {
+	data := append(abiWord(32), abiWord(int64(len(s)))...)
+	return append(data, common.RightPadBytes([]byte(s), (len(s)+31)/32*32)...)
+}
+
+func TestDecodeERC20Symbol(t *testing.T) {
+	hugeOffset := append(common.LeftPadBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 32), abiWord(3)...)
+
+	tests := []struct {
+		name   string
+		result []byte
+		want   string
+	}{
+		{"string", abiString("USDC"), "USDC"},
+		{"bytes32", common.RightPadBytes([]byte("MKR"), 32), "MKR"},
+		{"empty", nil, ""},
+		// The offset points at the last bytes of the result, so the length word would run
+		// past the end; this used to panic with an out of range slice
+		{"offset past end", append(abiWord(48), abiWord(0)...), ""},
+		{"length past end", append(abiWord(32), abiWord(100)...), ""},
+		{"offset overflows int64", hugeOffset, ""},
+	}
+
+	for _, tc := range tests {
+		if got := decodeERC20Symbol(tc.result); got != tc.want {
+			t.Errorf("%s: decodeERC20Symbol = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeERC20Decimals(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  []byte
+		want    uint8
+		wantErr bool
+	}{
+		{"uint256", abiWord(6), 6, false},
+		{"single byte", []byte{18}, 18, false},
+		{"zero", abiWord(0), 0, false},
+		{"empty", nil, 0, true},
+		{"too large", abiWord(77), 0, true},
+		// 256 must not wrap around to 0
+		{"wraps uint8", abiWord(256), 0, true},
+	}
+
+	for _, tc := range tests {
+		got, err := decodeERC20Decimals(tc.result)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %d", tc.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: decodeERC20Decimals = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDecodeERC20Balance(t *testing.T) {
+	balance, err := decodeERC20Balance(abiWord(1234))
+	if err != nil || balance.Int64() != 1234 {
+		t.Errorf("Unexpected balance: %v (%v)", balance, err)
+	}
+
+	if _, err := decodeERC20Balance(nil); err == nil {
+		t.Error("Expected error for an empty result")
+	}
+	if _, err := decodeERC20Balance(make([]byte, 31)); err == nil {
+		t.Error("Expected error for a short result")
+	}
+}
+
+func TestEncodeERC20BalanceOf(t *testing.T) {
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data := encodeERC20BalanceOf(owner)
+	if len(data) != 36 || common.Bytes2Hex(data[:4]) != "70a08231" || common.BytesToAddress(data[4:]) != owner {
+		t.Errorf("Unexpected calldata: %x", data)
+	}
+}
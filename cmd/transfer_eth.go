@@ -35,10 +35,11 @@ func TransferETHCmd() *cobra.Command {
 	cmd.Flags().StringP("provider", "p", "", "Key provider (e.g., google)")
 	cmd.Flags().StringP("name", "n", "", "Name of the wallet file (for cloud storage)")
 	cmd.Flags().StringP("file", "f", "", "Local wallet file path")
+	addAccountFlags(cmd)
 	cmd.Flags().Bool("dry-run", false, "Only encode the transaction, do not broadcast")
 	cmd.Flags().Bool("estimate-only", false, "Only display gas estimation")
 	cmd.Flags().BoolP("yes", "y", false, "Automatically confirm the transaction")
-	cmd.Flags().String("gas-price", "", "Gas price (e.g., 3gwei)")
+	addFeeFlags(cmd)
 	cmd.Flags().Uint64("gas-limit", 0, "Gas limit")
 	cmd.Flags().Uint64("chain-id", 1, "Chain ID to use in dry-run mode (default: 1)")
 	cmd.Flags().Uint64("nonce", 0, "Nonce to use in dry-run mode (required when chain-id is specified)")
@@ -60,7 +61,6 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	estimateOnly, _ := cmd.Flags().GetBool("estimate-only")
 	autoConfirm, _ := cmd.Flags().GetBool("yes")
-	gasPriceStr, _ := cmd.Flags().GetString("gas-price")
 	gasLimit, _ := cmd.Flags().GetUint64("gas-limit")
 	sync, _ := cmd.Flags().GetBool("sync")
 
@@ -74,9 +74,21 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --provider or --file must be specified")
 	}
 
-	// Get RPC URL from config
-	rpcURL, err := initTxConfig()
-	if err != nil && !dryRun {
+	// Determine which account to derive
+	selector, selectorErr := getAccountSelector(cmd)
+	if selectorErr != nil {
+		return selectorErr
+	}
+
+	// Get RPC URL from config; dry runs do not need a reachable RPC
+	var rpcURL string
+	var err error
+	if dryRun {
+		rpcURL, err = initDryRunTxConfig()
+	} else {
+		rpcURL, err = initTxConfig()
+	}
+	if err != nil {
 		return err
 	}
 
@@ -114,12 +126,15 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 	// Get private key from provider or file
 	var privateKey string
 	var fromAddress string
-	if filePath != "" {
+	if dryRun {
+		// Dry runs only need the address, so watch-only wallets work without a password
+		fromAddress, err = getWalletAddress(filePath, provider, name, selector)
+	} else if filePath != "" {
 		// Use local file
-		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath)
+		privateKey, fromAddress, err = getPrivateKeyFromLocalFile(filePath, selector)
 	} else {
 		// Use provider
-		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name)
+		privateKey, fromAddress, err = getPrivateKeyFromProvider(provider, name, selector)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to get private key: %v", err)
@@ -130,7 +145,7 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 	var nonce uint64
 	if !dryRun {
 		var chainErr error
-		chainID, chainErr = client.NetworkID(context.Background())
+		chainID, chainErr = client.ChainID(context.Background())
 		if chainErr != nil {
 			return fmt.Errorf("failed to get chain ID: %v", chainErr)
 		}
@@ -140,7 +155,10 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get nonce: %v", err)
 		}
 	} else {
-		chainIDValue, _ := cmd.Flags().GetUint64("chain-id")
+		chainIDValue, err := dryRunChainID(cmd)
+		if err != nil {
+			return err
+		}
 		chainID = big.NewInt(int64(chainIDValue))
 		nonceValue, _ := cmd.Flags().GetUint64("nonce")
 
@@ -152,22 +170,10 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\033[33mWARNING: Using chain ID %d and nonce %d for dry run.\033[0m\n", chainIDValue, nonce)
 	}
 
-	// Get gas price
-	var gasPrice *big.Int
-	if gasPriceStr != "" {
-		var gasPriceErr error
-		gasPrice, gasPriceErr = parseEthAmount(gasPriceStr)
-		if gasPriceErr != nil {
-			return gasPriceErr
-		}
-	} else if !dryRun {
-		var suggestErr error
-		gasPrice, suggestErr = client.SuggestGasPrice(context.Background())
-		if suggestErr != nil {
-			return fmt.Errorf("failed to get suggested gas price: %v", suggestErr)
-		}
-	} else {
-		gasPrice = big.NewInt(1000000000) // Default 1 Gwei if dry run
+	// Get transaction type and fees
+	txParams, err := resolveTxParams(cmd, client, dryRun)
+	if err != nil {
+		return err
 	}
 
 	// Get gas limit
@@ -189,7 +195,7 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 		to,
 		amountInWei,
 		nonce,
-		gasPrice,
+		txParams,
 		gasLimit,
 		chainID,
 	)
@@ -200,24 +206,28 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 	// If gas only, just display and exit
 	if estimateOnly {
 		fmt.Printf("Estimated Gas Limit: %d\n", gasLimit)
-		fmt.Printf("Suggested Gas Price: %s Gwei\n", new(big.Float).Quo(
-			new(big.Float).SetInt(gasPrice),
-			new(big.Float).SetInt(big.NewInt(1000000000)),
-		).Text('f', 9))
-		fmt.Printf("Estimated Gas Fee: %s ETH\n", new(big.Float).Quo(
-			new(big.Float).SetInt(new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))),
-			new(big.Float).SetInt(big.NewInt(1000000000000000000)),
-		).Text('f', 18))
+		printFeeDetails(txParams, gasLimit)
 		return nil
 	}
 
 	// If dry run, just display the raw transaction and exit
 	if dryRun {
-		displayTransactionDetails(fromAddress, to, amountInWei, gasLimit, gasPrice, nil, nonce, chainID, true)
+		displayTransactionDetails(fromAddress, to, amountInWei, gasLimit, txParams, nonce, chainID, true)
 		fmt.Printf("\n\033[1;36mRaw Transaction:\033[0m %s\n", rawTx)
 		return nil
 	}
 
+	// Simulate the transaction before signing it
+	sim, err := simulateRawTx(client, fromAddress, rawTx)
+	if err != nil {
+		return err
+	}
+
+	// Check the chain ID it is signed for against the selected network
+	if err := checkRawTxNetwork(rawTx); err != nil {
+		return err
+	}
+
 	// Sign the transaction
 	var signErr error
 	signedTx, signErr := util.SignTransaction(rawTx, privateKey)
@@ -227,7 +237,8 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 
 	// Display transaction details for confirmation
 	if !autoConfirm {
-		displayTransactionDetails(fromAddress, to, amountInWei, gasLimit, gasPrice, nil, nonce, chainID, false)
+		displayTransactionDetails(fromAddress, to, amountInWei, gasLimit, txParams, nonce, chainID, false)
+		printSimulation(sim)
 
 		// Ask for confirmation
 		fmt.Print("Confirm transaction? (y/N): ")
@@ -247,6 +258,7 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Transaction submitted: %s\n", txHash)
+	printExplorerLink(txHash)
 
 	// Wait for confirmation if requested
 	if sync {
@@ -279,46 +291,28 @@ func runTransferETH(cmd *cobra.Command, args []string) error {
 }
 
 // displayTransactionDetails formats and displays transaction details
-func displayTransactionDetails(from, to string, amount *big.Int, gasLimit uint64, gasPrice *big.Int, gasFeePredefined *big.Int, nonce uint64, chainID *big.Int, colorize bool) {
+func displayTransactionDetails(from, to string, amount *big.Int, gasLimit uint64, txParams util.TxParams, nonce uint64, chainID *big.Int, colorize bool) {
 	// Convert Wei to ETH for display using big.Int
-	ethAmount := new(big.Int).Div(amount, big.NewInt(1e18))
-	remainder := new(big.Int).Mod(amount, big.NewInt(1e18))
-	displayAmount := fmt.Sprintf("%d.%018d", ethAmount, remainder)
-
-	// Convert gas price to Gwei
-	gasPriceGwei := new(big.Int).Div(gasPrice, big.NewInt(1e9))
-	gasPriceRemainder := new(big.Int).Mod(gasPrice, big.NewInt(1e9))
-	displayGasPrice := fmt.Sprintf("%d.%09d", gasPriceGwei, gasPriceRemainder)
-
-	// Calculate gas fee in Wei if not provided
-	var gasFee *big.Int
-	if gasFeePredefined != nil {
-		gasFee = gasFeePredefined
-	} else {
-		gasFee = new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
-	}
-	gasFeeEth := new(big.Int).Div(gasFee, big.NewInt(1e18))
-	gasFeeRemainder := new(big.Int).Mod(gasFee, big.NewInt(1e18))
-	displayGasFee := fmt.Sprintf("%d.%018d", gasFeeEth, gasFeeRemainder)
+	displayAmount := formatEther(amount)
 
 	if colorize {
 		fmt.Println("\033[1;36mTransaction Details:\033[0m")
 		fmt.Printf("\033[1;33mFrom:\033[0m %s\n", from)
 		fmt.Printf("\033[1;33mTo:\033[0m %s\n", to)
-		fmt.Printf("\033[1;33mAmount:\033[0m \033[1;32m%s ETH\033[0m\n", displayAmount)
+		fmt.Printf("\033[1;33mAmount:\033[0m \033[1;32m%s %s\033[0m\n", displayAmount, nativeSymbol())
 		fmt.Printf("\033[1;33mGas Limit:\033[0m %d\n", gasLimit)
-		fmt.Printf("\033[1;33mGas Price:\033[0m %s Gwei\n", displayGasPrice)
-		fmt.Printf("\033[1;33mGas Fee:\033[0m %s ETH\n", displayGasFee)
+		for _, detail := range feeDetails(txParams, gasLimit) {
+			fmt.Printf("\033[1;33m%s:\033[0m %s\n", detail[0], detail[1])
+		}
 		fmt.Printf("\033[1;33mNonce:\033[0m %d\n", nonce)
 		fmt.Printf("\033[1;33mChain ID:\033[0m %d\n", chainID)
 	} else {
 		fmt.Println("Transaction Details:")
 		fmt.Printf("From: %s\n", from)
 		fmt.Printf("To: %s\n", to)
-		fmt.Printf("Amount: %s ETH\n", displayAmount)
+		fmt.Printf("Amount: %s %s\n", displayAmount, nativeSymbol())
 		fmt.Printf("Gas Limit: %d\n", gasLimit)
-		fmt.Printf("Gas Price: %s Gwei\n", displayGasPrice)
-		fmt.Printf("Gas Fee: %s ETH\n", displayGasFee)
+		printFeeDetails(txParams, gasLimit)
 		fmt.Printf("Nonce: %d\n", nonce)
 	}
 }
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// Statuses reported by tx-status
+const (
+	TxStatusPending = "pending"
+	TxStatusMined   = "mined"
+	TxStatusFailed  = "failed"
+)
+
+// TxStatusCmd creates the command that reports the status of a transaction
+func TxStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx-status <hash>",
+		Short: "Show the status of a transaction",
+		Long: `Show whether a transaction is pending, mined or failed.
+
+For a mined transaction, the block, number of confirmations, gas used, effective gas price
+and fee are shown. For a failed one, the call is replayed on the state it was executed on
+to show the revert reason. A pending transaction whose nonce was used by another one was
+replaced or dropped.
+
+Examples:
+  eth-cli tx-status 0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060
+  eth-cli tx-status 0x5c50...2060 --wait --wait-timeout 30m`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTxStatus,
+	}
+
+	cmd.Flags().Bool("wait", false, "Wait until a pending transaction is mined")
+	cmd.Flags().Duration("wait-timeout", DefaultReceiptWaitTimeout, "How long to wait with --wait before giving up")
+
+	return cmd
+}
+
+func runTxStatus(cmd *cobra.Command, args []string) error {
+	wait, _ := cmd.Flags().GetBool("wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	hashBytes, err := hexutil.Decode(args[0])
+	if err != nil || len(hashBytes) != common.HashLength {
+		return fmt.Errorf("invalid transaction hash: %s", args[0])
+	}
+	txHash := common.BytesToHash(hashBytes)
+
+	// Get RPC URL from config
+	rpcURL, err := initTxConfig()
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %v", err)
+	}
+	fmt.Printf("Using RPC: %s\n", rpcURL)
+
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("transaction %s not found: it was never broadcast to this network, or it was dropped", txHash.Hex())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transaction %s: %v", txHash.Hex(), err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction sender: %v", err)
+	}
+
+	fmt.Printf("Transaction: %s\n", txHash.Hex())
+	fmt.Printf("From: %s\n", sender.Hex())
+	fmt.Printf("Nonce: %d\n", tx.Nonce())
+
+	if isPending {
+		replaced, err := nonceUsedByOther(client, txHash, tx)
+		if err != nil {
+			return err
+		}
+		if replaced {
+			fmt.Printf("Status: %s\n", TxStatusPending)
+			return fmt.Errorf("nonce %d was used by another transaction, %s was replaced or dropped", tx.Nonce(), txHash.Hex())
+		}
+		if !wait {
+			fmt.Printf("Status: %s\n", TxStatusPending)
+			printFeeDetails(txParamsFromTransaction(tx), tx.Gas())
+			return nil
+		}
+		fmt.Println("Waiting for transaction confirmation...")
+		if _, err := waitForReceipt(client, txHash.Hex(), waitTimeout); err != nil {
+			return err
+		}
+	}
+
+	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction receipt: %v", err)
+	}
+	latest, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Printf("Status: %s\n", TxStatusMined)
+	} else {
+		fmt.Printf("Status: \033[1;31m%s\033[0m\n", TxStatusFailed)
+	}
+	fmt.Printf("Block Number: %d\n", receipt.BlockNumber)
+	fmt.Printf("Confirmations: %d\n", confirmations(latest, receipt.BlockNumber.Uint64()))
+	fmt.Printf("Gas Used: %d of %d\n", receipt.GasUsed, tx.Gas())
+	gasPrice := effectiveGasPrice(tx, receipt)
+	if gasPrice != nil {
+		fmt.Printf("Effective Gas Price: %s Gwei\n", formatGwei(gasPrice))
+		fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+		fmt.Printf("Fee Paid: %s %s\n", formatEther(fee), nativeSymbol())
+	}
+	if receipt.ContractAddress != (common.Address{}) && receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Printf("Contract Address: %s\n", receipt.ContractAddress.Hex())
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		fmt.Printf("Revert Reason: %s\n", replayRevertReason(client, tx, sender, receipt))
+	}
+	return nil
+}
+
+// confirmations is the number of blocks from the block of a transaction up to the latest one,
+// counting the block of the transaction itself
+func confirmations(latest, block uint64) uint64 {
+	if latest < block {
+		// The node answering the latest block lags behind the one that returned the receipt
+		return 1
+	}
+	return latest - block + 1
+}
+
+// effectiveGasPrice returns the gas price paid by a mined transaction. Some nodes do not
+// return it in the receipt; the gas price of a legacy transaction is the price paid.
+func effectiveGasPrice(tx *types.Transaction, receipt *types.Receipt) *big.Int {
+	if receipt.EffectiveGasPrice != nil && receipt.EffectiveGasPrice.Sign() > 0 {
+		return receipt.EffectiveGasPrice
+	}
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		return tx.GasPrice()
+	}
+	return nil
+}
+
+// replayRevertReason replays a failed transaction as a call on the state of the block before
+// the one it was mined in, and describes why it reverted
+func replayRevertReason(client *ethclient.Client, tx *types.Transaction, sender common.Address, receipt *types.Receipt) string {
+	if receipt.GasUsed == tx.Gas() {
+		return "out of gas (all of the gas limit was used)"
+	}
+
+	msg := ethereum.CallMsg{
+		From:       sender,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, err := client.CallContract(context.Background(), msg, parent)
+	if err == nil {
+		return "unknown (the replayed call succeeds; the failure depended on earlier transactions in the same block)"
+	}
+	if reason := util.DecodeRevertReason(err); reason != "" {
+		return reason
+	}
+	return fmt.Sprintf("unknown (%v)", err)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestConfirmations(t *testing.T) {
+	tests := []struct {
+		latest, block, expected uint64
+	}{
+		{100, 100, 1},
+		{105, 100, 6},
+		{99, 100, 1},
+	}
+	for _, test := range tests {
+		if got := confirmations(test.latest, test.block); got != test.expected {
+			t.Errorf("Unexpected confirmations for block %d at %d: got %d, want %d", test.block, test.latest, got, test.expected)
+		}
+	}
+}
+
+func TestEffectiveGasPrice(t *testing.T) {
+	legacyTx := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(20e9)})
+	dynamicTx := types.NewTx(&types.DynamicFeeTx{GasFeeCap: big.NewInt(30e9), GasTipCap: big.NewInt(1e9)})
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		receipt  *types.Receipt
+		expected *big.Int
+	}{
+		{"from receipt", dynamicTx, &types.Receipt{EffectiveGasPrice: big.NewInt(21e9)}, big.NewInt(21e9)},
+		{"legacy without receipt price", legacyTx, &types.Receipt{}, big.NewInt(20e9)},
+		{"dynamic fee without receipt price", dynamicTx, &types.Receipt{}, nil},
+	}
+	for _, test := range tests {
+		got := effectiveGasPrice(test.tx, test.receipt)
+		if (got == nil) != (test.expected == nil) || (got != nil && got.Cmp(test.expected) != 0) {
+			t.Errorf("Unexpected effective gas price for %s: got %v, want %v", test.name, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethanzhrepo/eth-cli-wallet/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// VerifyMessageCmd creates the signature verification command
+func VerifyMessageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-message",
+		Short: "Verify a message signature and recover the signer",
+		Long: `Recover the signer of an Ethereum signed message and optionally check it against an expected address.
+
+Messages are verified with the EIP-191 personal_sign prefix used by sign-message; use
+--typed-data-file to verify an EIP-712 signature produced by sign-typed-data.
+Signatures with v = 27/28 and v = 0/1 are both accepted.`,
+		RunE: runVerifyMessage,
+	}
+
+	// Add flags
+	cmd.Flags().BoolP("hex", "x", false, "Interpret message as hex (must start with 0x)")
+	cmd.Flags().StringP("data", "d", "", "Signed message (text or hex)")
+	cmd.Flags().String("data-file", "", "Path to file containing the signed message")
+	cmd.Flags().String("typed-data-file", "", "Path to EIP-712 typed data JSON file")
+	cmd.Flags().StringP("signature", "s", "", "Signature to verify (0x-prefixed hex)")
+	cmd.Flags().StringP("address", "a", "", "Expected signer address")
+
+	cmd.MarkFlagRequired("signature")
+
+	return cmd
+}
+
+func runVerifyMessage(cmd *cobra.Command, args []string) error {
+	// Parse flags
+	isHex, _ := cmd.Flags().GetBool("hex")
+	message, _ := cmd.Flags().GetString("data")
+	dataFile, _ := cmd.Flags().GetString("data-file")
+	typedDataFile, _ := cmd.Flags().GetString("typed-data-file")
+	signature, _ := cmd.Flags().GetString("signature")
+	expectedAddress, _ := cmd.Flags().GetString("address")
+
+	// Check for message source
+	sources := 0
+	for _, source := range []string{message, dataFile, typedDataFile} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of --data, --data-file or --typed-data-file must be specified")
+	}
+
+	if expectedAddress != "" && !common.IsHexAddress(expectedAddress) {
+		return fmt.Errorf("invalid expected address: %s", expectedAddress)
+	}
+
+	// Recover the signer
+	var signer common.Address
+	if typedDataFile != "" {
+		data, err := os.ReadFile(typedDataFile)
+		if err != nil {
+			return fmt.Errorf("failed to read typed data file: %v", err)
+		}
+
+		typedData, err := util.ParseTypedData(data)
+		if err != nil {
+			return err
+		}
+
+		signer, err = util.RecoverTypedDataSigner(typedData, signature)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature: %v", err)
+		}
+		fmt.Printf("Primary Type: %s\n", typedData.PrimaryType)
+	} else {
+		// Get message from file if necessary
+		if dataFile != "" {
+			data, err := os.ReadFile(dataFile)
+			if err != nil {
+				return fmt.Errorf("failed to read data file: %v", err)
+			}
+			// Trim any whitespace or newlines, the same as sign-message
+			message = strings.TrimSpace(string(data))
+		}
+
+		// Check if hex message is valid
+		if isHex && !strings.HasPrefix(message, "0x") {
+			return fmt.Errorf("hex message must start with 0x")
+		}
+
+		var err error
+		signer, err = util.RecoverMessageSigner(message, isHex, signature)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature: %v", err)
+		}
+		fmt.Printf("Message: %s\n", message)
+	}
+
+	fmt.Printf("Signature: %s\n", signature)
+	fmt.Printf("Recovered Signer: %s\n", signer.Hex())
+
+	if expectedAddress != "" {
+		if signer != common.HexToAddress(expectedAddress) {
+			return fmt.Errorf("signature is not valid for %s (recovered %s)", common.HexToAddress(expectedAddress).Hex(), signer.Hex())
+		}
+		fmt.Printf("\033[1;32mValid: signature was made by %s\033[0m\n", signer.Hex())
+	}
+
+	return nil
+}
@@ -53,6 +53,7 @@ Author: https://x.com/0x99_Ethan`,
 
 	// Add version flag
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Show version information")
+	cmd.AddNetworkFlag(rootCmd.PersistentFlags())
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		versionFlag, _ := cmd.Flags().GetBool("version")
 		if versionFlag {
@@ -64,20 +65,46 @@ Author: https://x.com/0x99_Ethan`,
 	// Add subcommands
 	rootCmd.AddCommand(cmd.ConfigCmd())
 	rootCmd.AddCommand(cmd.GasPriceCmd())
+	rootCmd.AddCommand(cmd.BalanceCmd())
 	rootCmd.AddCommand(cmd.CreateCmd())
 	rootCmd.AddCommand(cmd.CreateSpecialCmd())
+	rootCmd.AddCommand(cmd.ImportCmd())
+	rootCmd.AddCommand(cmd.ExportCmd())
+	rootCmd.AddCommand(cmd.ExportXpubCmd())
 	rootCmd.AddCommand(cmd.GetAddressCmd())
 	rootCmd.AddCommand(cmd.ListCmd())
 	rootCmd.AddCommand(cmd.CopyCmd())
+	rootCmd.AddCommand(cmd.LabelCmd())
+	rootCmd.AddCommand(cmd.RekeyCmd())
+	rootCmd.AddCommand(cmd.SplitCmd())
+	rootCmd.AddCommand(cmd.CombineCmd())
 
 	// Add the new transaction commands
 	rootCmd.AddCommand(cmd.TransferETHCmd())
 	rootCmd.AddCommand(cmd.TransferERC20Cmd())
 	rootCmd.AddCommand(cmd.TransferERC721Cmd())
+	rootCmd.AddCommand(cmd.TransferERC1155Cmd())
+	rootCmd.AddCommand(cmd.BatchTransferCmd())
+	rootCmd.AddCommand(cmd.MulticallCmd())
+	rootCmd.AddCommand(cmd.CallCmd())
+	rootCmd.AddCommand(cmd.SendCmd())
+	rootCmd.AddCommand(cmd.DeployCmd())
 	rootCmd.AddCommand(cmd.SignTxCmd())
+	rootCmd.AddCommand(cmd.PrepareCmd())
+	rootCmd.AddCommand(cmd.SignEnvelopeCmd())
+	rootCmd.AddCommand(cmd.BroadcastCmd())
+	rootCmd.AddCommand(cmd.TxStatusCmd())
+	rootCmd.AddCommand(cmd.DecodeCmd())
+	rootCmd.AddCommand(cmd.SpeedupCmd())
+	rootCmd.AddCommand(cmd.CancelCmd())
 	rootCmd.AddCommand(cmd.ApproveERC20Cmd())
 	rootCmd.AddCommand(cmd.ApproveERC721Cmd())
+	rootCmd.AddCommand(cmd.ApproveERC1155Cmd())
+	rootCmd.AddCommand(cmd.AllowancesCmd())
 	rootCmd.AddCommand(cmd.SignMessageCmd())
+	rootCmd.AddCommand(cmd.SignTypedDataCmd())
+	rootCmd.AddCommand(cmd.PermitCmd())
+	rootCmd.AddCommand(cmd.VerifyMessageCmd())
 
 	fd := int(os.Stdin.Fd())
 
@@ -0,0 +1,514 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ParseFunctionSignature parses a human-readable signature such as "transfer(address,uint256)"
+// into a method. Tuple types are written in parentheses, e.g. "submit((address,uint256)[])",
+// and return types may follow the inputs, e.g. "balanceOf(address)(uint256)".
+func ParseFunctionSignature(signature string) (abi.Method, error) {
+	signature = strings.TrimSpace(signature)
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return abi.Method{}, fmt.Errorf("invalid function signature: %s (expected name(type1,type2,...))", signature)
+	}
+	name := strings.TrimSpace(signature[:open])
+
+	closing := matchingParen(signature, open)
+	if closing < 0 {
+		return abi.Method{}, fmt.Errorf("unbalanced parentheses in %s", signature)
+	}
+	inputs, err := parseArgumentList(signature[open+1 : closing])
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid function signature %s: %v", signature, err)
+	}
+
+	var outputs abi.Arguments
+	if rest := strings.TrimSpace(signature[closing+1:]); rest != "" {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "returns"))
+		if !strings.HasPrefix(rest, "(") || matchingParen(rest, 0) != len(rest)-1 {
+			return abi.Method{}, fmt.Errorf("invalid return types in %s", signature)
+		}
+		outputs, err = parseArgumentList(rest[1 : len(rest)-1])
+		if err != nil {
+			return abi.Method{}, fmt.Errorf("invalid return types in %s: %v", signature, err)
+		}
+	}
+
+	return abi.NewMethod(name, name, abi.Function, "", false, false, inputs, outputs), nil
+}
+
+// parseArgumentList parses a comma-separated list of types, ignoring parameter names
+func parseArgumentList(typeList string) (abi.Arguments, error) {
+	var args abi.Arguments
+	for i, typeStr := range SplitTopLevel(typeList) {
+		t, err := parseABIType(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i+1, err)
+		}
+		args = append(args, abi.Argument{Name: fmt.Sprintf("arg%d", i), Type: t})
+	}
+	return args, nil
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open, or -1
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseABIType parses a type string, turning parenthesized tuples into tuple types
+func parseABIType(typeStr string) (abi.Type, error) {
+	marshaling, err := typeMarshaling(strings.TrimSpace(typeStr))
+	if err != nil {
+		return abi.Type{}, err
+	}
+	t, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+	if err != nil {
+		return abi.Type{}, err
+	}
+	if err := checkTypeSize(t); err != nil {
+		return abi.Type{}, err
+	}
+	return t, nil
+}
+
+// checkTypeSize rejects integer and fixed bytes sizes that abi.NewType accepts but Solidity does not
+func checkTypeSize(t abi.Type) error {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		if t.Size == 0 || t.Size > 256 || t.Size%8 != 0 {
+			return fmt.Errorf("invalid integer type: %s", t.String())
+		}
+	case abi.FixedBytesTy:
+		if t.Size == 0 || t.Size > 32 {
+			return fmt.Errorf("invalid fixed bytes type: %s", t.String())
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		return checkTypeSize(*t.Elem)
+	case abi.TupleTy:
+		for _, elem := range t.TupleElems {
+			if err := checkTypeSize(*elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// typeMarshaling converts a type string into the JSON ABI form understood by abi.NewType.
+// Parameter names and data locations such as "uint256 amount" or "bytes calldata data" are dropped.
+func typeMarshaling(typeStr string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(typeStr, "(") {
+		fields := strings.Fields(typeStr)
+		if len(fields) == 0 {
+			return abi.ArgumentMarshaling{}, fmt.Errorf("empty type")
+		}
+		return abi.ArgumentMarshaling{Type: fields[0]}, nil
+	}
+
+	// Find the parenthesis closing the tuple; an array suffix may follow it
+	closing := matchingParen(typeStr, 0)
+	if closing < 0 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced parentheses in %s", typeStr)
+	}
+	suffix := ""
+	if fields := strings.Fields(typeStr[closing+1:]); len(fields) > 0 && strings.HasPrefix(fields[0], "[") {
+		suffix = fields[0]
+	}
+
+	marshaling := abi.ArgumentMarshaling{Type: "tuple" + suffix}
+	for i, component := range SplitTopLevel(typeStr[1:closing]) {
+		componentMarshaling, err := typeMarshaling(component)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		componentMarshaling.Name = fmt.Sprintf("field%d", i)
+		marshaling.Components = append(marshaling.Components, componentMarshaling)
+	}
+	return marshaling, nil
+}
+
+// LoadABI reads a contract ABI from a JSON file holding either the ABI array or a
+// Foundry/Hardhat artifact with an "abi" field
+func LoadABI(path string) (abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to read ABI file: %v", err)
+	}
+	return ParseABIJSON(data)
+}
+
+// ParseABIJSON parses an ABI array or an artifact object with an "abi" field
+func ParseABIJSON(data []byte) (abi.ABI, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(trimmed, &artifact); err != nil {
+			return abi.ABI{}, fmt.Errorf("invalid ABI JSON: %v", err)
+		}
+		if len(artifact.ABI) == 0 {
+			return abi.ABI{}, fmt.Errorf("the JSON object has no \"abi\" field")
+		}
+		trimmed = artifact.ABI
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(trimmed))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("invalid ABI JSON: %v", err)
+	}
+	return parsed, nil
+}
+
+// FindABIMethod looks up a method of an ABI by name or by signature such as "deposit(uint256)".
+// A bare name must not be overloaded.
+func FindABIMethod(contractABI abi.ABI, function string) (abi.Method, error) {
+	function = strings.TrimSpace(function)
+	if strings.Contains(function, "(") {
+		wanted, err := ParseFunctionSignature(function)
+		if err != nil {
+			return abi.Method{}, err
+		}
+		for _, method := range contractABI.Methods {
+			if method.Sig == wanted.Sig {
+				return method, nil
+			}
+		}
+		return abi.Method{}, fmt.Errorf("function %s not found in the ABI", wanted.Sig)
+	}
+
+	var matches []abi.Method
+	for _, method := range contractABI.Methods {
+		if method.RawName == function {
+			matches = append(matches, method)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return abi.Method{}, fmt.Errorf("function %s not found in the ABI", function)
+	case 1:
+		return matches[0], nil
+	}
+	var sigs []string
+	for _, method := range matches {
+		sigs = append(sigs, method.Sig)
+	}
+	sort.Strings(sigs)
+	return abi.Method{}, fmt.Errorf("function %s is overloaded, use one of: %s", function, strings.Join(sigs, ", "))
+}
+
+// EncodeFunctionCall ABI-encodes a call to a function given by its signature, with the
+// arguments given as strings (see ParseABIValue for the accepted formats)
+func EncodeFunctionCall(signature string, args []string) ([]byte, error) {
+	method, err := ParseFunctionSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeArguments(method.ID, method.Inputs, args)
+}
+
+// EncodeArgumentValues ABI-encodes a call to a function given by its signature, with the
+// arguments given as the Go values abi expects (e.g. common.Address, *big.Int, []byte)
+func EncodeArgumentValues(signature string, values ...interface{}) ([]byte, error) {
+	method, err := ParseFunctionSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("encode arguments failed: %v", err)
+	}
+	return append(append([]byte{}, method.ID...), encoded...), nil
+}
+
+// EncodeArguments parses the string arguments and appends their ABI encoding to prefix
+// (a function selector or contract bytecode)
+func EncodeArguments(prefix []byte, inputs abi.Arguments, args []string) ([]byte, error) {
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", len(inputs), len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := ParseABIValue(inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d (%s): %v", i+1, inputs[i].Type.String(), err)
+		}
+		values[i] = value
+	}
+
+	encoded, err := inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("encode arguments failed: %v", err)
+	}
+	return append(append([]byte{}, prefix...), encoded...), nil
+}
+
+// ParseABIValue converts a string into the Go value abi expects for the type.
+// Integers are decimal or 0x-prefixed hex, bytes are 0x-prefixed hex, and arrays and
+// tuples are written as [a,b,...] with elements in the same formats.
+func ParseABIValue(t abi.Type, input string) (interface{}, error) {
+	value, err := parseABIReflectValue(t, strings.TrimSpace(input))
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+func parseABIReflectValue(t abi.Type, input string) (reflect.Value, error) {
+	goType := t.GetType()
+
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(input) {
+			return reflect.Value{}, fmt.Errorf("invalid address: %s", input)
+		}
+		return reflect.ValueOf(common.HexToAddress(input)), nil
+
+	case abi.BoolTy:
+		b, err := strconv.ParseBool(input)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bool: %s", input)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.StringTy:
+		return reflect.ValueOf(unquote(input)), nil
+
+	case abi.BytesTy:
+		b, err := hexutil.Decode(input)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bytes %s: %v", input, err)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(input)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bytes%d %s: %v", t.Size, input, err)
+		}
+		if len(b) != t.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		value := reflect.New(goType).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value, nil
+
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(input, 0)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid integer: %s", input)
+		}
+		if err := checkIntRange(t, n); err != nil {
+			return reflect.Value{}, err
+		}
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(n), nil
+		}
+		value := reflect.New(goType).Elem()
+		if t.T == abi.IntTy {
+			value.SetInt(n.Int64())
+		} else {
+			value.SetUint(n.Uint64())
+		}
+		return value, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		elements, err := splitList(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(goType, len(elements), len(elements))
+		} else {
+			if len(elements) != t.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", t.Size, len(elements))
+			}
+			value = reflect.New(goType).Elem()
+		}
+		for i, element := range elements {
+			elementValue, err := parseABIReflectValue(*t.Elem, element)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %v", i, err)
+			}
+			value.Index(i).Set(elementValue)
+		}
+		return value, nil
+
+	case abi.TupleTy:
+		elements, err := splitList(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(elements) != len(t.TupleElems) {
+			return reflect.Value{}, fmt.Errorf("expected %d tuple fields, got %d", len(t.TupleElems), len(elements))
+		}
+		value := reflect.New(goType).Elem()
+		for i, element := range elements {
+			fieldValue, err := parseABIReflectValue(*t.TupleElems[i], element)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %d: %v", i, err)
+			}
+			value.Field(i).Set(fieldValue)
+		}
+		return value, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported type: %s", t.String())
+}
+
+// checkIntRange checks that n fits in the integer type
+func checkIntRange(t abi.Type, n *big.Int) error {
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return fmt.Errorf("%s out of range for uint%d", n, t.Size)
+		}
+		return nil
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("%s out of range for int%d", n, t.Size)
+	}
+	return nil
+}
+
+// splitList splits a [a,b,...] or (a,b,...) list into its top-level elements
+func splitList(input string) ([]string, error) {
+	if len(input) < 2 || !((input[0] == '[' && input[len(input)-1] == ']') || (input[0] == '(' && input[len(input)-1] == ')')) {
+		return nil, fmt.Errorf("expected a list like [a,b], got %s", input)
+	}
+	return SplitTopLevel(input[1 : len(input)-1]), nil
+}
+
+// SplitTopLevel splits s on commas that are not inside brackets, parentheses or double
+// quotes, trimming whitespace around each part. An empty string has no parts.
+func SplitTopLevel(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	var parts []string
+	depth := 0
+	inQuotes := false
+	start := 0
+	for i, c := range s {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// unquote removes surrounding double quotes from a string element
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// FormatABIValue formats a decoded ABI value for display: integers in decimal, bytes in hex,
+// arrays as [a, b] and tuples as (a, b)
+func FormatABIValue(value interface{}) string {
+	return formatABIReflectValue(reflect.ValueOf(value))
+}
+
+func formatABIReflectValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	switch value := v.Interface().(type) {
+	case *big.Int:
+		return value.String()
+	case common.Address:
+		return value.Hex()
+	case []byte:
+		return hexutil.Encode(value)
+	}
+
+	switch v.Kind() {
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatABIReflectValue(v.Index(i))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case reflect.Struct:
+		parts := make([]string, v.NumField())
+		for i := range parts {
+			parts[i] = formatABIReflectValue(v.Field(i))
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	case reflect.String:
+		return strconv.Quote(v.String())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// DecodeRevertReason extracts the revert reason from an eth_call or eth_estimateGas error.
+// It returns an empty string if the error carries no Error(string) revert data.
+func DecodeRevertReason(err error) string {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return ""
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return ""
+	}
+	revertData, decodeErr := hexutil.Decode(data)
+	if decodeErr != nil {
+		return ""
+	}
+	reason, unpackErr := abi.UnpackRevert(revertData)
+	if unpackErr != nil {
+		return ""
+	}
+	return reason
+}
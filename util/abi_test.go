@@ -0,0 +1,227 @@
+package util
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// abiWords joins 32-byte words given as hex without 0x prefix
+func abiWords(words ...string) string {
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(strings.Repeat("0", 64-len(word)) + word)
+	}
+	return b.String()
+}
+
+func TestParseFunctionSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		wantSig   string
+		outputs   int
+	}{
+		{"transfer(address,uint256)", "transfer(address,uint256)", 0},
+		{"transfer(address to, uint256 amount)", "transfer(address,uint256)", 0},
+		{"balanceOf(address)(uint256)", "balanceOf(address)", 1},
+		{"getReserves() returns (uint112,uint112,uint32)", "getReserves()", 3},
+		{"submit((address,uint256)[] orders,bytes calldata data)", "submit((address,uint256)[],bytes)", 0},
+		{"nested((uint8,(bool,bytes32))[2])", "nested((uint8,(bool,bytes32))[2])", 0},
+	}
+
+	for _, tc := range tests {
+		method, err := ParseFunctionSignature(tc.signature)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.signature, err)
+			continue
+		}
+		if method.Sig != tc.wantSig {
+			t.Errorf("%s: expected signature %s, got %s", tc.signature, tc.wantSig, method.Sig)
+		}
+		if hexutil.Encode(method.ID) != hexutil.Encode(crypto.Keccak256([]byte(tc.wantSig))[:4]) {
+			t.Errorf("%s: unexpected selector %x", tc.signature, method.ID)
+		}
+		if len(method.Outputs) != tc.outputs {
+			t.Errorf("%s: expected %d outputs, got %d", tc.signature, tc.outputs, len(method.Outputs))
+		}
+	}
+
+	for _, invalid := range []string{"transfer", "(address)", "transfer(address", "transfer(uint7)", "f(uint256)uint256", "f((uint256)"} {
+		if _, err := ParseFunctionSignature(invalid); err == nil {
+			t.Errorf("Expected error for %q, but got none", invalid)
+		}
+	}
+}
+
+func TestEncodeFunctionCall(t *testing.T) {
+	selector := func(sig string) string {
+		return hexutil.Encode(crypto.Keccak256([]byte(sig))[:4])
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		args      []string
+		want      string
+	}{
+		{
+			name:      "static arguments",
+			signature: "transfer(address,uint256)",
+			args:      []string{"0x000000000000000000000000000000000000dEaD", "0x10"},
+			want:      "0xa9059cbb" + abiWords("dead", "10"),
+		},
+		{
+			name:      "dynamic bytes",
+			signature: "store(bytes)",
+			args:      []string{"0xdeadbeef"},
+			want:      selector("store(bytes)") + abiWords("20", "4", "deadbeef"+strings.Repeat("0", 56)),
+		},
+		{
+			name:      "empty bytes and string",
+			signature: "set(bytes,string)",
+			args:      []string{"0x", `"hi, there"`},
+			want:      selector("set(bytes,string)") + abiWords("40", "60", "0", "9", "68692c207468657265"+strings.Repeat("0", 46)),
+		},
+		{
+			name:      "fixed bytes",
+			signature: "setRoot(bytes4)",
+			args:      []string{"0x01020304"},
+			want:      selector("setRoot(bytes4)") + "01020304" + strings.Repeat("0", 56),
+		},
+		{
+			name:      "dynamic array",
+			signature: "sum(uint256[])",
+			args:      []string{"[1, 2, 3]"},
+			want:      selector("sum(uint256[])") + abiWords("20", "3", "1", "2", "3"),
+		},
+		{
+			name:      "fixed array",
+			signature: "pair(int8[2])",
+			args:      []string{"[-1,2]"},
+			want:      selector("pair(int8[2])") + strings.Repeat("f", 64) + abiWords("2"),
+		},
+		{
+			name:      "tuple",
+			signature: "order((address,uint256,bool))",
+			args:      []string{"[0x0000000000000000000000000000000000000001,5,true]"},
+			want:      selector("order((address,uint256,bool))") + abiWords("1", "5", "1"),
+		},
+		{
+			name:      "array of tuples",
+			signature: "submit((address,uint256)[])",
+			args:      []string{"[(0x0000000000000000000000000000000000000001,5),(0x0000000000000000000000000000000000000002,6)]"},
+			want:      selector("submit((address,uint256)[])") + abiWords("20", "2", "1", "5", "2", "6"),
+		},
+		{
+			name:      "tuple with dynamic field",
+			signature: "post((uint256,bytes))",
+			args:      []string{"[7,0xff]"},
+			want:      selector("post((uint256,bytes))") + abiWords("20", "7", "40", "1", "ff"+strings.Repeat("0", 62)),
+		},
+	}
+
+	for _, tc := range tests {
+		data, err := EncodeFunctionCall(tc.signature, tc.args)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if hexutil.Encode(data) != tc.want {
+			t.Errorf("%s: unexpected encoding\n got %s\nwant %s", tc.name, hexutil.Encode(data), tc.want)
+		}
+	}
+}
+
+func TestEncodeFunctionCallInvalid(t *testing.T) {
+	tests := []struct {
+		signature string
+		args      []string
+	}{
+		{"transfer(address,uint256)", []string{"0x000000000000000000000000000000000000dEaD"}},
+		{"transfer(address,uint256)", []string{"0x1234", "1"}},
+		{"set(uint8)", []string{"256"}},
+		{"set(uint256)", []string{"-1"}},
+		{"set(int8)", []string{"-129"}},
+		{"set(bool)", []string{"yes"}},
+		{"set(bytes)", []string{"0xabc"}},
+		{"set(bytes32)", []string{"0x01"}},
+		{"set(uint256[2])", []string{"[1]"}},
+		{"set((uint256,bool))", []string{"[1]"}},
+		{"set(uint256[])", []string{"1,2"}},
+	}
+
+	for _, tc := range tests {
+		if _, err := EncodeFunctionCall(tc.signature, tc.args); err == nil {
+			t.Errorf("Expected error for %s with %q, but got none", tc.signature, tc.args)
+		}
+	}
+}
+
+func TestFindABIMethod(t *testing.T) {
+	contractABI, err := ParseABIJSON([]byte(`{"abi": [
+		{"type":"function","name":"deposit","inputs":[{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"payable"},
+		{"type":"function","name":"deposit","inputs":[{"name":"amount","type":"uint256"},{"name":"to","type":"address"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"owner","inputs":[],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"}
+	], "bytecode": "0x00"}`))
+	if err != nil {
+		t.Fatalf("Failed to parse artifact ABI: %v", err)
+	}
+
+	method, err := FindABIMethod(contractABI, "owner")
+	if err != nil || method.Sig != "owner()" || len(method.Outputs) != 1 {
+		t.Errorf("Unexpected owner method: %v, %v", method.Sig, err)
+	}
+
+	method, err = FindABIMethod(contractABI, "deposit(uint256 amount, address to)")
+	if err != nil || method.Sig != "deposit(uint256,address)" {
+		t.Errorf("Unexpected deposit method: %v, %v", method.Sig, err)
+	}
+
+	if _, err := FindABIMethod(contractABI, "deposit"); err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("Expected overload error, got %v", err)
+	}
+	if _, err := FindABIMethod(contractABI, "withdraw"); err == nil {
+		t.Error("Expected error for a missing function, but got none")
+	}
+
+	if _, err := ParseABIJSON([]byte(`{"bytecode": "0x00"}`)); err == nil {
+		t.Error("Expected error for an artifact without an ABI, but got none")
+	}
+}
+
+func TestFormatABIValue(t *testing.T) {
+	method, err := ParseFunctionSignature("f()(uint256,address,bytes,bytes2,string,int8[],(bool,uint64))")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	encoded, err := method.Outputs.Pack(
+		big.NewInt(42),
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		[]byte{0xca, 0xfe},
+		[2]byte{0x01, 0x02},
+		"hi",
+		[]int8{-1, 2},
+		struct {
+			Field0 bool
+			Field1 uint64
+		}{true, 7},
+	)
+	if err != nil {
+		t.Fatalf("Failed to encode outputs: %v", err)
+	}
+	values, err := method.Outputs.Unpack(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode outputs: %v", err)
+	}
+
+	want := []string{"42", "0x000000000000000000000000000000000000dEaD", "0xcafe", "0x0102", `"hi"`, "[-1, 2]", "(true, 7)"}
+	for i, value := range values {
+		if got := FormatABIValue(value); got != want[i] {
+			t.Errorf("Output %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+}
@@ -11,18 +11,56 @@ import (
 	"golang.org/x/crypto/argon2"
 )
 
+// KDFParams holds the Argon2id parameters used to derive the AES key
+type KDFParams struct {
+	Memory      uint32 // in KB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultKDFParams are the Argon2id parameters used for newly encrypted wallets
+var DefaultKDFParams = KDFParams{
+	Memory:      1024 * 1024,
+	Iterations:  12,
+	Parallelism: 4,
+}
+
+// Validate checks that the Argon2id parameters are usable
+func (p KDFParams) Validate() error {
+	if p.Iterations < 1 {
+		return fmt.Errorf("argon2id iterations must be at least 1")
+	}
+	if p.Parallelism < 1 {
+		return fmt.Errorf("argon2id parallelism must be at least 1")
+	}
+	if p.Memory < 8*uint32(p.Parallelism) {
+		return fmt.Errorf("argon2id memory must be at least %d KB for parallelism %d", 8*uint32(p.Parallelism), p.Parallelism)
+	}
+	return nil
+}
+
+// EncryptMnemonic 使用默认的 Argon2id 参数加密助记词
 func EncryptMnemonic(mnemonic, password string) (EncryptedMnemonic, error) {
+	return EncryptMnemonicWithParams(mnemonic, password, DefaultKDFParams)
+}
+
+// EncryptMnemonicWithParams 使用指定的 Argon2id 参数加密助记词
+func EncryptMnemonicWithParams(mnemonic, password string, params KDFParams) (EncryptedMnemonic, error) {
 	// 初始化返回结构
 	result := EncryptedMnemonic{
 		Version:       1,
 		Algorithm:     "AES-256-GCM",
 		KeyDerivation: "Argon2id",
-		Memory:        1024 * 1024,
-		Iterations:    12,
-		Parallelism:   4,
+		Memory:        params.Memory,
+		Iterations:    params.Iterations,
+		Parallelism:   params.Parallelism,
 		KeyLength:     32,
 	}
 
+	if err := params.Validate(); err != nil {
+		return result, err
+	}
+
 	// 生成随机salt (16字节)
 	salt := make([]byte, 16)
 	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
@@ -0,0 +1,44 @@
+package util
+
+import "testing"
+
+func TestEncryptMnemonicWithParams(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	params := KDFParams{Memory: 64, Iterations: 1, Parallelism: 1}
+
+	encrypted, err := EncryptMnemonicWithParams(mnemonic, "Passw0rd!", params)
+	if err != nil {
+		t.Fatalf("Failed to encrypt mnemonic: %v", err)
+	}
+
+	if encrypted.Memory != params.Memory || encrypted.Iterations != params.Iterations || encrypted.Parallelism != params.Parallelism {
+		t.Errorf("Encrypted mnemonic does not record the KDF parameters: %+v", encrypted)
+	}
+
+	decrypted, err := DecryptMnemonic(encrypted, "Passw0rd!")
+	if err != nil {
+		t.Fatalf("Failed to decrypt mnemonic: %v", err)
+	}
+	if decrypted != mnemonic {
+		t.Errorf("Decrypted mnemonic does not match: got %q", decrypted)
+	}
+
+	if _, err := DecryptMnemonic(encrypted, "WrongPassw0rd!"); err == nil {
+		t.Error("Expected error when decrypting with the wrong password, but got none")
+	}
+}
+
+func TestKDFParamsValidate(t *testing.T) {
+	if err := DefaultKDFParams.Validate(); err != nil {
+		t.Errorf("Default KDF parameters should be valid: %v", err)
+	}
+	if err := (KDFParams{Memory: 64, Iterations: 0, Parallelism: 1}).Validate(); err == nil {
+		t.Error("Expected error for zero iterations, but got none")
+	}
+	if err := (KDFParams{Memory: 64, Iterations: 1, Parallelism: 0}).Validate(); err == nil {
+		t.Error("Expected error for zero parallelism, but got none")
+	}
+	if err := (KDFParams{Memory: 8, Iterations: 1, Parallelism: 4}).Validate(); err == nil {
+		t.Error("Expected error for insufficient memory, but got none")
+	}
+}
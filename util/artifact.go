@@ -0,0 +1,85 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractArtifact is the ABI and creation bytecode of a compiled contract
+type ContractArtifact struct {
+	ABI      abi.ABI
+	Bytecode []byte
+}
+
+// LoadContractArtifact reads a Foundry (out/<File>.sol/<Contract>.json) or Hardhat
+// (artifacts/.../<Contract>.json) artifact
+func LoadContractArtifact(path string) (ContractArtifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ContractArtifact{}, fmt.Errorf("failed to read artifact: %v", err)
+	}
+	return ParseContractArtifact(data)
+}
+
+// ParseContractArtifact parses an artifact JSON. Foundry stores the bytecode as
+// {"bytecode": {"object": "0x..."}}, Hardhat as {"bytecode": "0x..."}.
+func ParseContractArtifact(data []byte) (ContractArtifact, error) {
+	var raw struct {
+		Bytecode json.RawMessage `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ContractArtifact{}, fmt.Errorf("invalid artifact JSON: %v", err)
+	}
+	if len(raw.Bytecode) == 0 {
+		return ContractArtifact{}, fmt.Errorf("the artifact has no \"bytecode\" field")
+	}
+
+	var bytecodeHex string
+	if err := json.Unmarshal(raw.Bytecode, &bytecodeHex); err != nil {
+		var foundry struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(raw.Bytecode, &foundry); err != nil {
+			return ContractArtifact{}, fmt.Errorf("invalid artifact bytecode: %v", err)
+		}
+		bytecodeHex = foundry.Object
+	}
+
+	bytecode, err := ParseBytecode(bytecodeHex)
+	if err != nil {
+		return ContractArtifact{}, err
+	}
+
+	contractABI, err := ParseABIJSON(data)
+	if err != nil {
+		return ContractArtifact{}, err
+	}
+
+	return ContractArtifact{ABI: contractABI, Bytecode: bytecode}, nil
+}
+
+// ParseBytecode decodes hex contract creation bytecode, rejecting empty bytecode (abstract
+// contracts and interfaces) and unlinked library placeholders
+func ParseBytecode(bytecodeHex string) ([]byte, error) {
+	bytecodeHex = strings.TrimSpace(bytecodeHex)
+	if !strings.HasPrefix(bytecodeHex, "0x") && !strings.HasPrefix(bytecodeHex, "0X") {
+		bytecodeHex = "0x" + bytecodeHex
+	}
+	if strings.Contains(bytecodeHex, "__") {
+		return nil, fmt.Errorf("the bytecode has unlinked library references, link the libraries before deploying")
+	}
+
+	bytecode, err := hexutil.Decode(bytecodeHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %v", err)
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("the bytecode is empty (abstract contract or interface?)")
+	}
+	return bytecode, nil
+}
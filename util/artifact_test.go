@@ -0,0 +1,57 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseContractArtifact(t *testing.T) {
+	abiJSON := `[{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable"}]`
+
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"foundry", `{"abi":` + abiJSON + `,"bytecode":{"object":"0x6080604052","linkReferences":{}}}`, ""},
+		{"hardhat", `{"_format":"hh-sol-artifact-1","abi":` + abiJSON + `,"bytecode":"0x6080604052","linkReferences":{}}`, ""},
+		{"no bytecode", `{"abi":` + abiJSON + `}`, "no \"bytecode\""},
+		{"empty bytecode", `{"abi":` + abiJSON + `,"bytecode":"0x"}`, "empty"},
+		{"unlinked library", `{"abi":` + abiJSON + `,"bytecode":{"object":"0x73__$1234567890abcdef1234567890abcdef12$__63"}}`, "unlinked library"},
+		{"no abi", `{"bytecode":"0x6080604052"}`, "no \"abi\""},
+		{"not json", `0x6080604052`, "invalid artifact JSON"},
+	}
+
+	for _, tc := range tests {
+		artifact, err := ParseContractArtifact([]byte(tc.json))
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !bytes.Equal(artifact.Bytecode, []byte{0x60, 0x80, 0x60, 0x40, 0x52}) {
+			t.Errorf("%s: unexpected bytecode %x", tc.name, artifact.Bytecode)
+		}
+		if len(artifact.ABI.Constructor.Inputs) != 1 {
+			t.Errorf("%s: expected a constructor with one input", tc.name)
+		}
+	}
+}
+
+func TestParseBytecode(t *testing.T) {
+	bytecode, err := ParseBytecode(" 6080604052\n")
+	if err != nil || !bytes.Equal(bytecode, []byte{0x60, 0x80, 0x60, 0x40, 0x52}) {
+		t.Errorf("Unexpected bytecode without prefix: %x, %v", bytecode, err)
+	}
+	for _, invalid := range []string{"", "0x", "0x608", "0xzz"} {
+		if _, err := ParseBytecode(invalid); err == nil {
+			t.Errorf("Expected error for %q, but got none", invalid)
+		}
+	}
+}
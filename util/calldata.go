@@ -0,0 +1,172 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Sources of a decoded call, from the most to the least trusted
+const (
+	CallSourceABI      = "abi"
+	CallSourceStandard = "standard"
+	CallSourceFourByte = "4byte"
+)
+
+// DecodedParam is a decoded argument of a call
+type DecodedParam struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// DecodedCall is calldata decoded against a function signature
+type DecodedCall struct {
+	Selector     string
+	Signature    string
+	Source       string // CallSourceABI, CallSourceStandard or CallSourceFourByte
+	Standard     string // token standard of a CallSourceStandard function, e.g. ERC20
+	Params       []DecodedParam
+	Alternatives []string // other signatures the calldata also decodes as
+}
+
+// standardFunction is a token standard function decoded with parameter names
+type standardFunction struct {
+	Standard  string
+	Signature string
+	Params    []string
+}
+
+// standardFunctions are the functions of the token standards the CLI sends transactions to.
+// ERC20 and ERC721 share approve and transferFrom, so their last parameter is named for both.
+var standardFunctions = []standardFunction{
+	{"ERC20", ERC20TransferSignature, []string{"to", "amount"}},
+	{"ERC20/ERC721", ERC20ApproveSignature, []string{"spender", "amountOrTokenId"}},
+	{"ERC20/ERC721", ERC721TransferFromSignature, []string{"from", "to", "amountOrTokenId"}},
+	{"ERC20", "increaseAllowance(address,uint256)", []string{"spender", "addedValue"}},
+	{"ERC20", "decreaseAllowance(address,uint256)", []string{"spender", "subtractedValue"}},
+	{"EIP-2612", "permit(address,address,uint256,uint256,uint8,bytes32,bytes32)", []string{"owner", "spender", "value", "deadline", "v", "r", "s"}},
+	{"ERC721", "safeTransferFrom(address,address,uint256)", []string{"from", "to", "tokenId"}},
+	{"ERC721", "safeTransferFrom(address,address,uint256,bytes)", []string{"from", "to", "tokenId", "data"}},
+	{"ERC721/ERC1155", SetApprovalForAllSignature, []string{"operator", "approved"}},
+	{"ERC1155", ERC1155SafeTransferFromSignature, []string{"from", "to", "id", "amount", "data"}},
+	{"ERC1155", ERC1155SafeBatchTransferFromSignature, []string{"from", "to", "ids", "amounts", "data"}},
+}
+
+// callCandidate is a function a selector may belong to
+type callCandidate struct {
+	method   abi.Method
+	source   string
+	standard string
+	names    []string
+}
+
+var (
+	builtinCandidatesOnce sync.Once
+	builtinCandidates     map[[4]byte][]callCandidate
+)
+
+// loadBuiltinCandidates indexes the standard functions and the 4-byte database by selector
+func loadBuiltinCandidates() map[[4]byte][]callCandidate {
+	builtinCandidatesOnce.Do(func() {
+		builtinCandidates = make(map[[4]byte][]callCandidate)
+		add := func(signature string, candidate callCandidate) {
+			method, err := ParseFunctionSignature(signature)
+			if err != nil {
+				panic(fmt.Sprintf("invalid built-in function signature %s: %v", signature, err))
+			}
+			candidate.method = method
+			var selector [4]byte
+			copy(selector[:], method.ID)
+			builtinCandidates[selector] = append(builtinCandidates[selector], candidate)
+		}
+		for _, function := range standardFunctions {
+			add(function.Signature, callCandidate{source: CallSourceStandard, standard: function.Standard, names: function.Params})
+		}
+		for _, signature := range fourByteSignatures {
+			add(signature, callCandidate{source: CallSourceFourByte})
+		}
+	})
+	return builtinCandidates
+}
+
+// DecodeCalldata decodes calldata against the functions of the given ABIs, the token standard
+// functions and the bundled 4-byte database, in that order. A signature matches only if the
+// arguments decode and encode back to exactly the same calldata. It returns nil if no
+// signature matches.
+func DecodeCalldata(data []byte, abis []abi.ABI) *DecodedCall {
+	if len(data) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	var candidates []callCandidate
+	for _, contractABI := range abis {
+		for _, method := range contractABI.Methods {
+			if bytes.Equal(method.ID, selector[:]) {
+				candidates = append(candidates, callCandidate{method: method, source: CallSourceABI})
+			}
+		}
+	}
+	candidates = append(candidates, loadBuiltinCandidates()[selector]...)
+
+	var decoded *DecodedCall
+	for _, candidate := range candidates {
+		params, ok := decodeCallArguments(candidate, data[4:])
+		if !ok {
+			continue
+		}
+		if decoded == nil {
+			decoded = &DecodedCall{
+				Selector:  hexutil.Encode(selector[:]),
+				Signature: candidate.method.Sig,
+				Source:    candidate.source,
+				Standard:  candidate.standard,
+				Params:    params,
+			}
+		} else if candidate.method.Sig != decoded.Signature && !containsString(decoded.Alternatives, candidate.method.Sig) {
+			decoded.Alternatives = append(decoded.Alternatives, candidate.method.Sig)
+		}
+	}
+	return decoded
+}
+
+// decodeCallArguments decodes the arguments of a call, failing unless they encode back to
+// the same bytes so that a selector collision does not produce a plausible wrong decoding
+func decodeCallArguments(candidate callCandidate, argData []byte) ([]DecodedParam, bool) {
+	inputs := candidate.method.Inputs
+	values, err := inputs.Unpack(argData)
+	if err != nil {
+		return nil, false
+	}
+	encoded, err := inputs.Pack(values...)
+	if err != nil || !bytes.Equal(encoded, argData) {
+		return nil, false
+	}
+
+	params := make([]DecodedParam, len(inputs))
+	for i, input := range inputs {
+		name := input.Name
+		if i < len(candidate.names) {
+			name = candidate.names[i]
+		}
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		params[i] = DecodedParam{Name: name, Type: input.Type.String(), Value: FormatABIValue(values[i])}
+	}
+	return params, true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
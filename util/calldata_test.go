@@ -0,0 +1,125 @@
+package util
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDecodeCalldata(t *testing.T) {
+	exactInputSingle, err := EncodeFunctionCall(
+		"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+		[]string{"(0x00000000000000000000000000000000000000aa,0x00000000000000000000000000000000000000bb,3000,0x00000000000000000000000000000000000000cc,1700000000,5,4,0)"})
+	if err != nil {
+		t.Fatalf("Failed to encode exactInputSingle: %v", err)
+	}
+
+	vaultABI, err := abi.JSON(strings.NewReader(`[{"name":"deposit","type":"function","inputs":[{"name":"assets","type":"uint256"},{"name":"receiver","type":"address"}]}]`))
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+	deposit, err := EncodeFunctionCall("deposit(uint256,address)", []string{"7", "0x00000000000000000000000000000000000000aa"})
+	if err != nil {
+		t.Fatalf("Failed to encode deposit: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		data      []byte
+		abis      []abi.ABI
+		signature string
+		source    string
+		params    []DecodedParam
+	}{
+		{
+			name:      "ERC20 transfer",
+			data:      EncodeERC20Transfer("0x00000000000000000000000000000000000000bb", big.NewInt(1500000)),
+			signature: "transfer(address,uint256)",
+			source:    CallSourceStandard,
+			params:    []DecodedParam{{"to", "address", common.HexToAddress("0xbb").Hex()}, {"amount", "uint256", "1500000"}},
+		},
+		{
+			name:      "ERC721 approve",
+			data:      EncodeERC721Approve("0x00000000000000000000000000000000000000bb", big.NewInt(42)),
+			signature: "approve(address,uint256)",
+			source:    CallSourceStandard,
+			params:    []DecodedParam{{"spender", "address", common.HexToAddress("0xbb").Hex()}, {"amountOrTokenId", "uint256", "42"}},
+		},
+		{
+			name:      "4-byte database with a tuple",
+			data:      exactInputSingle,
+			signature: "exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+			source:    CallSourceFourByte,
+		},
+		{
+			name:      "4-byte database",
+			data:      deposit,
+			signature: "deposit(uint256,address)",
+			source:    CallSourceFourByte,
+			params:    []DecodedParam{{"arg0", "uint256", "7"}, {"arg1", "address", common.HexToAddress("0xaa").Hex()}},
+		},
+		{
+			name:      "user ABI first",
+			data:      deposit,
+			abis:      []abi.ABI{vaultABI},
+			signature: "deposit(uint256,address)",
+			source:    CallSourceABI,
+			params:    []DecodedParam{{"assets", "uint256", "7"}, {"receiver", "address", common.HexToAddress("0xaa").Hex()}},
+		},
+	}
+
+	for _, test := range tests {
+		decoded := DecodeCalldata(test.data, test.abis)
+		if decoded == nil {
+			t.Errorf("Unexpected nil decoding for %s", test.name)
+			continue
+		}
+		if decoded.Signature != test.signature || decoded.Source != test.source {
+			t.Errorf("Unexpected decoding for %s: %s from %s", test.name, decoded.Signature, decoded.Source)
+		}
+		if test.params == nil {
+			continue
+		}
+		if len(decoded.Params) != len(test.params) {
+			t.Errorf("Unexpected params for %s: %+v", test.name, decoded.Params)
+			continue
+		}
+		for i, param := range test.params {
+			if decoded.Params[i] != param {
+				t.Errorf("Unexpected param %d for %s: got %+v, want %+v", i, test.name, decoded.Params[i], param)
+			}
+		}
+	}
+}
+
+func TestDecodeCalldataRejectsMismatch(t *testing.T) {
+	transfer := EncodeERC20Transfer("0x00000000000000000000000000000000000000bb", big.NewInt(1))
+
+	tests := map[string][]byte{
+		"unknown selector": append([]byte{0xde, 0xad, 0xbe, 0xef}, transfer[4:]...),
+		"short calldata":   transfer[:3],
+		"truncated args":   transfer[:36],
+		"trailing bytes":   append(append([]byte{}, transfer...), 0x01),
+		// Non-zero padding above an address does not encode back to the same calldata
+		"dirty address": append(append(append([]byte{}, transfer[:4]...), 0xff), transfer[5:]...),
+	}
+	for name, data := range tests {
+		if decoded := DecodeCalldata(data, nil); decoded != nil {
+			t.Errorf("Expected no decoding for %s, got %s", name, decoded.Signature)
+		}
+	}
+}
+
+func TestBuiltinSignatures(t *testing.T) {
+	candidates := loadBuiltinCandidates()
+	if len(candidates) == 0 {
+		t.Fatal("Expected built-in signatures")
+	}
+	// transfer(address,uint256) is a token standard function, not a 4-byte entry
+	if got := candidates[[4]byte{0xa9, 0x05, 0x9c, 0xbb}]; len(got) != 1 || got[0].standard != "ERC20" {
+		t.Errorf("Unexpected candidates for transfer: %+v", got)
+	}
+}
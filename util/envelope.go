@@ -0,0 +1,293 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EnvelopeVersion is the version of the transaction envelope format
+const EnvelopeVersion = 1
+
+// Envelope intents, derived from the transaction itself
+const (
+	IntentTransferETH   = "transfer-eth"
+	IntentTransferERC20 = "transfer-erc20"
+	IntentApproveERC20  = "approve-erc20"
+	IntentContractCall  = "contract-call"
+	IntentDeploy        = "deploy"
+)
+
+// EnvelopeToken is the token metadata of an ERC20 intent, as read by the online machine
+type EnvelopeToken struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// EnvelopeIntent is the decoded meaning of a transaction
+type EnvelopeIntent struct {
+	Action    string `json:"action"`
+	Recipient string `json:"recipient,omitempty"` // ETH or token recipient, or the spender of an approval
+	Amount    string `json:"amount,omitempty"`    // in wei or token base units
+	Selector  string `json:"selector,omitempty"`  // function selector of a contract call
+}
+
+// EnvelopeFees is the type, gas limit and fees of a transaction, amounts in wei
+type EnvelopeFees struct {
+	Type                 string           `json:"type"`
+	GasLimit             uint64           `json:"gasLimit"`
+	MaxFeePerGas         string           `json:"maxFeePerGas"` // the gas price of legacy and EIP-2930 transactions
+	MaxPriorityFeePerGas string           `json:"maxPriorityFeePerGas,omitempty"`
+	BaseFee              string           `json:"baseFee,omitempty"` // base fee when the envelope was prepared
+	AccessList           types.AccessList `json:"accessList,omitempty"`
+}
+
+// TxEnvelope is an unsigned transaction with the context needed to review it on an offline
+// machine. RawTx is authoritative: the other transaction fields and the intent must match it,
+// which ParseTxEnvelope and UnsignedTransaction check.
+type TxEnvelope struct {
+	Version  int            `json:"version"`
+	ChainID  string         `json:"chainId"`
+	From     string         `json:"from"`
+	Nonce    uint64         `json:"nonce"`
+	To       string         `json:"to,omitempty"` // empty for contract creation
+	Value    string         `json:"value"`
+	Data     string         `json:"data"`
+	Fees     EnvelopeFees   `json:"fees"`
+	Intent   EnvelopeIntent `json:"intent"`
+	Token    *EnvelopeToken `json:"token,omitempty"`
+	RawTx    string         `json:"rawTx"`
+	SignedTx string         `json:"signedTx,omitempty"`
+	TxHash   string         `json:"txHash,omitempty"`
+}
+
+// NewTxEnvelope builds the envelope of an unsigned transaction. token is the metadata of the
+// called token for ERC20 intents, or nil.
+func NewTxEnvelope(from common.Address, to *common.Address, value *big.Int, data []byte, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int, token *EnvelopeToken) (*TxEnvelope, error) {
+	rawTx, err := CreateRawTx(to, value, data, nonce, txParams, gasLimit, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &TxEnvelope{
+		Version: EnvelopeVersion,
+		ChainID: chainID.String(),
+		From:    from.Hex(),
+		Nonce:   nonce,
+		Value:   value.String(),
+		Data:    hexutil.Encode(data),
+		Fees: EnvelopeFees{
+			Type:         txParams.Type,
+			GasLimit:     gasLimit,
+			MaxFeePerGas: txParams.Fees.MaxFeePerGas.String(),
+			AccessList:   txParams.AccessList,
+		},
+		Intent: DecodeEnvelopeIntent(to, value, data),
+		RawTx:  rawTx,
+	}
+	if to != nil {
+		envelope.To = to.Hex()
+	}
+	if txParams.Type == TxTypeDynamicFee {
+		envelope.Fees.MaxPriorityFeePerGas = txParams.Fees.MaxPriorityFeePerGas.String()
+	}
+	if txParams.Fees.BaseFee != nil {
+		envelope.Fees.BaseFee = txParams.Fees.BaseFee.String()
+	}
+
+	if envelope.Intent.Action == IntentTransferERC20 || envelope.Intent.Action == IntentApproveERC20 {
+		if token == nil {
+			return nil, fmt.Errorf("token metadata is required for %s", envelope.Intent.Action)
+		}
+		if !strings.EqualFold(token.Address, envelope.To) {
+			return nil, fmt.Errorf("token metadata is for %s, but the transaction calls %s", token.Address, envelope.To)
+		}
+		envelope.Token = token
+	}
+	return envelope, nil
+}
+
+// DecodeEnvelopeIntent derives the intent of a transaction from its recipient, value and calldata
+func DecodeEnvelopeIntent(to *common.Address, value *big.Int, data []byte) EnvelopeIntent {
+	if to == nil {
+		return EnvelopeIntent{Action: IntentDeploy, Amount: value.String()}
+	}
+	if len(data) == 0 {
+		return EnvelopeIntent{Action: IntentTransferETH, Recipient: to.Hex(), Amount: value.String()}
+	}
+
+	// transfer(address,uint256) and approve(address,uint256) without ETH attached
+	if len(data) == 68 && value.Sign() == 0 && bytes.Equal(data[4:16], make([]byte, 12)) {
+		recipient := common.BytesToAddress(data[16:36]).Hex()
+		amount := new(big.Int).SetBytes(data[36:68]).String()
+		switch {
+		case bytes.Equal(data[:4], crypto.Keccak256([]byte(ERC20TransferSignature))[:4]):
+			return EnvelopeIntent{Action: IntentTransferERC20, Recipient: recipient, Amount: amount}
+		case bytes.Equal(data[:4], crypto.Keccak256([]byte(ERC20ApproveSignature))[:4]):
+			return EnvelopeIntent{Action: IntentApproveERC20, Recipient: recipient, Amount: amount}
+		}
+	}
+
+	intent := EnvelopeIntent{Action: IntentContractCall, Amount: value.String()}
+	if len(data) >= 4 {
+		intent.Selector = hexutil.Encode(data[:4])
+	}
+	return intent
+}
+
+// ParseTxEnvelope parses an envelope JSON and checks that it is consistent with its raw transaction
+func ParseTxEnvelope(data []byte) (*TxEnvelope, error) {
+	var envelope TxEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid envelope JSON: %v", err)
+	}
+	if _, err := envelope.UnsignedTransaction(); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// UnsignedTransaction decodes the raw transaction, checking that every field, the intent and the
+// token metadata address of the envelope match it
+func (e *TxEnvelope) UnsignedTransaction() (*types.Transaction, error) {
+	if e.Version != EnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d, expected %d", e.Version, EnvelopeVersion)
+	}
+	if !common.IsHexAddress(e.From) {
+		return nil, fmt.Errorf("invalid envelope sender: %s", e.From)
+	}
+
+	chainID, ok := new(big.Int).SetString(e.ChainID, 10)
+	if !ok || chainID.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid envelope chain ID: %s", e.ChainID)
+	}
+	value, ok := new(big.Int).SetString(e.Value, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid envelope value: %s", e.Value)
+	}
+	data, err := hexutil.Decode(e.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope data: %v", err)
+	}
+	var to *common.Address
+	if e.To != "" {
+		if !common.IsHexAddress(e.To) {
+			return nil, fmt.Errorf("invalid envelope recipient: %s", e.To)
+		}
+		address := common.HexToAddress(e.To)
+		to = &address
+	}
+
+	txParams, err := e.TxParams()
+	if err != nil {
+		return nil, err
+	}
+
+	// Rebuilding the raw transaction from the fields proves that they describe it
+	rawTx, err := CreateRawTx(to, value, data, e.Nonce, txParams, e.Fees.GasLimit, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(rawTx, e.RawTx) {
+		return nil, fmt.Errorf("the envelope fields do not match its raw transaction")
+	}
+	if intent := DecodeEnvelopeIntent(to, value, data); intent != e.Intent {
+		return nil, fmt.Errorf("the envelope intent %s does not match its transaction (%s)", e.Intent.Action, intent.Action)
+	}
+	if e.Token != nil && !strings.EqualFold(e.Token.Address, e.To) {
+		return nil, fmt.Errorf("the envelope token %s is not the called contract %s", e.Token.Address, e.To)
+	}
+
+	return DecodeSignedTransaction(e.RawTx)
+}
+
+// TxParams returns the type, fees and access list of the envelope
+func (e *TxEnvelope) TxParams() (TxParams, error) {
+	txParams := TxParams{Type: e.Fees.Type, AccessList: e.Fees.AccessList}
+	if e.Fees.Type != TxTypeLegacy && e.Fees.Type != TxTypeAccessList && e.Fees.Type != TxTypeDynamicFee {
+		return txParams, fmt.Errorf("invalid envelope transaction type: %s", e.Fees.Type)
+	}
+
+	maxFee, ok := new(big.Int).SetString(e.Fees.MaxFeePerGas, 10)
+	if !ok {
+		return txParams, fmt.Errorf("invalid envelope max fee: %s", e.Fees.MaxFeePerGas)
+	}
+	txParams.Fees.MaxFeePerGas = maxFee
+	txParams.Fees.MaxPriorityFeePerGas = maxFee
+	if e.Fees.Type == TxTypeDynamicFee {
+		priorityFee, ok := new(big.Int).SetString(e.Fees.MaxPriorityFeePerGas, 10)
+		if !ok {
+			return txParams, fmt.Errorf("invalid envelope priority fee: %s", e.Fees.MaxPriorityFeePerGas)
+		}
+		txParams.Fees.MaxPriorityFeePerGas = priorityFee
+	}
+	if e.Fees.BaseFee != "" {
+		baseFee, ok := new(big.Int).SetString(e.Fees.BaseFee, 10)
+		if !ok {
+			return txParams, fmt.Errorf("invalid envelope base fee: %s", e.Fees.BaseFee)
+		}
+		txParams.Fees.BaseFee = baseFee
+	}
+	return txParams, nil
+}
+
+// ChainIDInt returns the chain ID of the envelope
+func (e *TxEnvelope) ChainIDInt() *big.Int {
+	chainID, _ := new(big.Int).SetString(e.ChainID, 10)
+	return chainID
+}
+
+// SetSignedTx records a signed transaction in the envelope after checking it
+func (e *TxEnvelope) SetSignedTx(signedTx string) error {
+	e.SignedTx = signedTx
+	tx, err := e.SignedTransaction()
+	if err != nil {
+		e.SignedTx = ""
+		return err
+	}
+	e.TxHash = tx.Hash().Hex()
+	return nil
+}
+
+// SignedTransaction decodes the signed transaction, checking that it is the envelope's
+// transaction signed by the envelope sender
+func (e *TxEnvelope) SignedTransaction() (*types.Transaction, error) {
+	if e.SignedTx == "" {
+		return nil, fmt.Errorf("the envelope is not signed")
+	}
+	unsigned, err := e.UnsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := DecodeSignedTransaction(e.SignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := signerForTransaction(unsigned, e.ChainIDInt())
+	if err != nil {
+		return nil, err
+	}
+	if signer.Hash(signed) != signer.Hash(unsigned) {
+		return nil, fmt.Errorf("the signed transaction is not the envelope's transaction")
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover the signer: %v", err)
+	}
+	if sender != common.HexToAddress(e.From) {
+		return nil, fmt.Errorf("the transaction is signed by %s, not by the envelope sender %s", sender.Hex(), e.From)
+	}
+	if e.TxHash != "" && !strings.EqualFold(e.TxHash, signed.Hash().Hex()) {
+		return nil, fmt.Errorf("the envelope transaction hash %s does not match the signed transaction %s", e.TxHash, signed.Hash().Hex())
+	}
+	return signed, nil
+}
@@ -0,0 +1,113 @@
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testEnvelope(t *testing.T, txType string) (*TxEnvelope, string) {
+	privateKey := hex.EncodeToString(crypto.Keccak256([]byte("cow")))
+	from := common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	txParams := TxParams{Type: txType, Fees: FeeParams{MaxFeePerGas: big.NewInt(30e9), MaxPriorityFeePerGas: big.NewInt(1e9), BaseFee: big.NewInt(20e9)}}
+
+	data := EncodeERC20Transfer("0x00000000000000000000000000000000000000bb", big.NewInt(1500000))
+	envelope, err := NewTxEnvelope(from, &token, big.NewInt(0), data, 7, txParams, 65000, big.NewInt(1),
+		&EnvelopeToken{Address: token.Hex(), Symbol: "USDC", Decimals: 6})
+	if err != nil {
+		t.Fatalf("Failed to create envelope: %v", err)
+	}
+	return envelope, privateKey
+}
+
+func TestTxEnvelopeRoundTrip(t *testing.T) {
+	for _, txType := range []string{TxTypeDynamicFee, TxTypeLegacy} {
+		envelope, privateKey := testEnvelope(t, txType)
+		if envelope.Intent.Action != IntentTransferERC20 || envelope.Intent.Amount != "1500000" || envelope.Intent.Recipient != common.HexToAddress("0xbb").Hex() {
+			t.Errorf("Unexpected intent: %+v", envelope.Intent)
+		}
+
+		encoded, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatalf("Failed to encode envelope: %v", err)
+		}
+		parsed, err := ParseTxEnvelope(encoded)
+		if err != nil {
+			t.Fatalf("Failed to parse %s envelope: %v", txType, err)
+		}
+
+		signedTx, err := SignTransactionWithChainID(parsed.RawTx, privateKey, parsed.ChainIDInt())
+		if err != nil {
+			t.Fatalf("Failed to sign envelope: %v", err)
+		}
+		if err := parsed.SetSignedTx(signedTx); err != nil {
+			t.Fatalf("Unexpected error for the signed %s envelope: %v", txType, err)
+		}
+		if parsed.TxHash == "" {
+			t.Error("Expected the transaction hash to be recorded")
+		}
+	}
+}
+
+func TestTxEnvelopeTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(e *TxEnvelope)
+	}{
+		{"nonce", func(e *TxEnvelope) { e.Nonce++ }},
+		{"chain ID", func(e *TxEnvelope) { e.ChainID = "5" }},
+		{"recipient of the intent", func(e *TxEnvelope) { e.Intent.Recipient = "0x00000000000000000000000000000000000000cC" }},
+		{"amount of the intent", func(e *TxEnvelope) { e.Intent.Amount = "1" }},
+		{"max fee", func(e *TxEnvelope) { e.Fees.MaxFeePerGas = "1" }},
+		{"token address", func(e *TxEnvelope) { e.Token.Address = "0x00000000000000000000000000000000000000cC" }},
+		{"version", func(e *TxEnvelope) { e.Version = 2 }},
+	}
+	for _, test := range tests {
+		envelope, _ := testEnvelope(t, TxTypeDynamicFee)
+		test.tamper(envelope)
+		encoded, _ := json.Marshal(envelope)
+		if _, err := ParseTxEnvelope(encoded); err == nil {
+			t.Errorf("Expected error for a tampered %s, but got none", test.name)
+		}
+	}
+}
+
+func TestTxEnvelopeWrongSigner(t *testing.T) {
+	envelope, _ := testEnvelope(t, TxTypeDynamicFee)
+	otherKey := hex.EncodeToString(crypto.Keccak256([]byte("dog")))
+
+	signedTx, err := SignTransactionWithChainID(envelope.RawTx, otherKey, envelope.ChainIDInt())
+	if err != nil {
+		t.Fatalf("Failed to sign envelope: %v", err)
+	}
+	if err := envelope.SetSignedTx(signedTx); err == nil || !strings.Contains(err.Error(), "not by the envelope sender") {
+		t.Errorf("Expected a wrong signer error, got %v", err)
+	}
+	if envelope.SignedTx != "" {
+		t.Error("A rejected signed transaction must not be recorded")
+	}
+}
+
+func TestDecodeEnvelopeIntent(t *testing.T) {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	if intent := DecodeEnvelopeIntent(&to, big.NewInt(5), nil); intent.Action != IntentTransferETH || intent.Amount != "5" {
+		t.Errorf("Unexpected ETH transfer intent: %+v", intent)
+	}
+	if intent := DecodeEnvelopeIntent(&to, big.NewInt(0), EncodeERC20Approve(to.Hex(), big.NewInt(9))); intent.Action != IntentApproveERC20 || intent.Amount != "9" {
+		t.Errorf("Unexpected approval intent: %+v", intent)
+	}
+	// A transfer with ETH attached is not a plain token transfer
+	if intent := DecodeEnvelopeIntent(&to, big.NewInt(1), EncodeERC20Transfer(to.Hex(), big.NewInt(9))); intent.Action != IntentContractCall || intent.Selector != "0xa9059cbb" {
+		t.Errorf("Unexpected contract call intent: %+v", intent)
+	}
+	if intent := DecodeEnvelopeIntent(nil, big.NewInt(0), []byte{0x60}); intent.Action != IntentDeploy {
+		t.Errorf("Unexpected deploy intent: %+v", intent)
+	}
+}
@@ -0,0 +1,115 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// feeHistoryBlocks is the number of recent blocks sampled when falling back to eth_feeHistory
+const feeHistoryBlocks = 10
+
+// feeHistoryPercentile is the reward percentile used as the suggested priority fee
+const feeHistoryPercentile = 50
+
+// FeeParams holds the EIP-1559 fee parameters of a transaction
+type FeeParams struct {
+	MaxFeePerGas         *big.Int // GasFeeCap: the most paid per gas, base fee included
+	MaxPriorityFeePerGas *big.Int // GasTipCap: the tip paid to the block producer per gas
+	BaseFee              *big.Int // base fee of the latest block, nil if unknown
+}
+
+// EffectiveGasPrice returns the price per gas expected to be paid in the next block:
+// min(maxFee, baseFee + priorityFee), or maxFee when the base fee is unknown
+func (f FeeParams) EffectiveGasPrice() *big.Int {
+	if f.BaseFee == nil {
+		return new(big.Int).Set(f.MaxFeePerGas)
+	}
+	price := new(big.Int).Add(f.BaseFee, f.MaxPriorityFeePerGas)
+	if price.Cmp(f.MaxFeePerGas) > 0 {
+		return new(big.Int).Set(f.MaxFeePerGas)
+	}
+	return price
+}
+
+// Validate checks that the fee parameters are set and consistent
+func (f FeeParams) Validate() error {
+	if f.MaxFeePerGas == nil || f.MaxPriorityFeePerGas == nil {
+		return fmt.Errorf("max fee and priority fee must be set")
+	}
+	if f.MaxPriorityFeePerGas.Cmp(f.MaxFeePerGas) > 0 {
+		return fmt.Errorf("priority fee (%s wei) cannot exceed max fee (%s wei)", f.MaxPriorityFeePerGas, f.MaxFeePerGas)
+	}
+	return nil
+}
+
+// GetBaseFee returns the base fee of the latest block, or nil on pre-London chains
+func GetBaseFee(client *ethclient.Client) (*big.Int, error) {
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get latest header failed: %v", err)
+	}
+	return header.BaseFee, nil
+}
+
+// SuggestPriorityFee suggests a priority fee using eth_maxPriorityFeePerGas,
+// falling back to the median reward of recent blocks from eth_feeHistory
+func SuggestPriorityFee(client *ethclient.Client) (*big.Int, error) {
+	tip, err := client.SuggestGasTipCap(context.Background())
+	if err == nil {
+		return tip, nil
+	}
+
+	history, historyErr := client.FeeHistory(context.Background(), feeHistoryBlocks, nil, []float64{feeHistoryPercentile})
+	if historyErr != nil {
+		return nil, fmt.Errorf("get priority fee failed: %v (fee history: %v)", err, historyErr)
+	}
+
+	var rewards []*big.Int
+	for _, blockRewards := range history.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+	if len(rewards) == 0 {
+		return nil, fmt.Errorf("get priority fee failed: no fee history rewards available")
+	}
+
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return new(big.Int).Set(rewards[len(rewards)/2]), nil
+}
+
+// SuggestFees suggests EIP-1559 fees: the suggested priority fee and a max fee of
+// 2 * baseFee + priorityFee, which stays valid through several full blocks.
+// On chains without a base fee both values fall back to eth_gasPrice.
+func SuggestFees(client *ethclient.Client) (FeeParams, error) {
+	baseFee, err := GetBaseFee(client)
+	if err != nil {
+		return FeeParams{}, err
+	}
+
+	if baseFee == nil {
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return FeeParams{}, fmt.Errorf("get gas price failed: %v", err)
+		}
+		return FeeParams{MaxFeePerGas: gasPrice, MaxPriorityFeePerGas: gasPrice}, nil
+	}
+
+	tip, err := SuggestPriorityFee(client)
+	if err != nil {
+		return FeeParams{}, err
+	}
+
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(2))
+	maxFee.Add(maxFee, tip)
+
+	return FeeParams{
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: tip,
+		BaseFee:              baseFee,
+	}, nil
+}
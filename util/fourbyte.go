@@ -0,0 +1,99 @@
+package util
+
+// fourByteSignatures is the bundled offline 4-byte database: signatures of widely used
+// functions (routers, multicalls, wallets, vaults and lending pools) used to decode calldata
+// of contracts without a user-supplied ABI. Selectors are computed from the signatures, so
+// an entry cannot carry a wrong selector.
+var fourByteSignatures = []string{
+	// Common token and ownership functions
+	"mint(address,uint256)",
+	"burn(uint256)",
+	"burnFrom(address,uint256)",
+	"transferOwnership(address)",
+	"renounceOwnership()",
+	"acceptOwnership()",
+	"delegate(address)",
+	"claim()",
+	"upgradeTo(address)",
+	"upgradeToAndCall(address,bytes)",
+
+	// WETH
+	"deposit()",
+	"withdraw(uint256)",
+
+	// ERC4626 vaults
+	"deposit(uint256,address)",
+	"mint(uint256,address)",
+	"withdraw(uint256,address,address)",
+	"redeem(uint256,address,address)",
+
+	// Uniswap V2 style routers
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+	"swapExactETHForTokens(uint256,address[],address,uint256)",
+	"swapETHForExactTokens(uint256,address[],address,uint256)",
+	"swapExactTokensForETH(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactETH(uint256,uint256,address[],address,uint256)",
+	"swapExactTokensForTokensSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)",
+	"swapExactETHForTokensSupportingFeeOnTransferTokens(uint256,address[],address,uint256)",
+	"swapExactTokensForETHSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)",
+	"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+	"addLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+
+	// Uniswap V3 routers and the Universal Router
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactInput((bytes,address,uint256,uint256,uint256))",
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+	"exactOutput((bytes,address,uint256,uint256,uint256))",
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint160))",
+	"exactInput((bytes,address,uint256,uint256))",
+	"multicall(bytes[])",
+	"multicall(uint256,bytes[])",
+	"multicall(bytes32,bytes[])",
+	"unwrapWETH9(uint256,address)",
+	"refundETH()",
+	"sweepToken(address,uint256,address)",
+	"execute(bytes,bytes[])",
+	"execute(bytes,bytes[],uint256)",
+
+	// Permit2
+	"permit(address,((address,uint160,uint48,uint48),address,uint256),bytes)",
+	"permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)",
+	"permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)",
+	"approve(address,address,uint160,uint48)",
+	"invalidateNonces(address,address,uint48)",
+	"invalidateUnorderedNonces(uint256,uint256)",
+	"lockdown((address,address)[])",
+
+	// Multicall3
+	"aggregate((address,bytes)[])",
+	"tryAggregate(bool,(address,bytes)[])",
+	"aggregate3((address,bool,bytes)[])",
+	"aggregate3Value((address,bool,uint256,bytes)[])",
+
+	// Safe
+	"execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)",
+	"addOwnerWithThreshold(address,uint256)",
+	"removeOwner(address,address,uint256)",
+	"swapOwner(address,address,address)",
+	"changeThreshold(uint256)",
+
+	// Aave V3 and Compound V3
+	"supply(address,uint256,address,uint16)",
+	"withdraw(address,uint256,address)",
+	"borrow(address,uint256,uint256,uint16,address)",
+	"repay(address,uint256,uint256,address)",
+	"depositETH(address,address,uint16)",
+	"withdrawETH(address,uint256,address)",
+	"supply(address,uint256)",
+	"withdraw(address,uint256)",
+
+	// Staking
+	"stake(uint256)",
+	"unstake(uint256)",
+	"getReward()",
+	"exit()",
+	"submit(address)",
+}
@@ -0,0 +1,140 @@
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Keystore V3 key derivation functions
+const (
+	KeystoreKDFScrypt = "scrypt"
+	KeystoreKDFPBKDF2 = "pbkdf2"
+)
+
+const (
+	keystoreDKLen       = 32
+	keystoreScryptR     = 8
+	keystorePBKDF2C     = 262144
+	keystoreLightPBKDF2 = 10240
+)
+
+// keystoreV3 is the Web3 Secret Storage (keystore V3) JSON layout
+type keystoreV3 struct {
+	Address string           `json:"address"`
+	Crypto  keystoreV3Crypto `json:"crypto"`
+	ID      string           `json:"id"`
+	Version int              `json:"version"`
+}
+
+type keystoreV3Crypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreV3CipherParams `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreV3CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// EncryptKeystoreV3 encrypts a private key as keystore V3 JSON that geth, Foundry and MetaMask can import.
+// kdf is either KeystoreKDFScrypt or KeystoreKDFPBKDF2; light selects cheaper KDF parameters.
+func EncryptKeystoreV3(privateKey *ecdsa.PrivateKey, password string, kdf string, light bool) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate random salt: %v", err)
+	}
+
+	// 派生密钥
+	var derivedKey []byte
+	kdfParams := map[string]interface{}{
+		"dklen": keystoreDKLen,
+		"salt":  hex.EncodeToString(salt),
+	}
+	switch kdf {
+	case KeystoreKDFScrypt:
+		n, p := keystore.StandardScryptN, keystore.StandardScryptP
+		if light {
+			n, p = keystore.LightScryptN, keystore.LightScryptP
+		}
+		var err error
+		derivedKey, err = scrypt.Key([]byte(password), salt, n, keystoreScryptR, p, keystoreDKLen)
+		if err != nil {
+			return nil, fmt.Errorf("scrypt key derivation failed: %v", err)
+		}
+		kdfParams["n"] = n
+		kdfParams["r"] = keystoreScryptR
+		kdfParams["p"] = p
+	case KeystoreKDFPBKDF2:
+		c := keystorePBKDF2C
+		if light {
+			c = keystoreLightPBKDF2
+		}
+		derivedKey = pbkdf2.Key([]byte(password), salt, c, keystoreDKLen, sha256.New)
+		kdfParams["c"] = c
+		kdfParams["prf"] = "hmac-sha256"
+	default:
+		return nil, fmt.Errorf("unsupported keystore kdf: %s", kdf)
+	}
+
+	// 使用 AES-128-CTR 加密私钥
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, fmt.Errorf("failed to generate random iv: %v", err)
+	}
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	keyBytes := crypto.FromECDSA(privateKey)
+	cipherText := make([]byte, len(keyBytes))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, keyBytes)
+
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	id, err := newUUIDv4()
+	if err != nil {
+		return nil, err
+	}
+
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	return json.MarshalIndent(keystoreV3{
+		Address: strings.ToLower(strings.TrimPrefix(address.Hex(), "0x")),
+		Crypto: keystoreV3Crypto{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreV3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          kdf,
+			KDFParams:    kdfParams,
+			MAC:          hex.EncodeToString(mac),
+		},
+		ID:      id,
+		Version: 3,
+	}, "", "  ")
+}
+
+// newUUIDv4 generates a random RFC 4122 version 4 UUID
+func newUUIDv4() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to generate uuid: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEncryptKeystoreV3(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	for _, kdf := range []string{KeystoreKDFScrypt, KeystoreKDFPBKDF2} {
+		keystoreJSON, err := EncryptKeystoreV3(privateKey, "keystore-password", kdf, true)
+		if err != nil {
+			t.Fatalf("Failed to encrypt %s keystore: %v", kdf, err)
+		}
+
+		// The output must be readable by go-ethereum
+		key, err := keystore.DecryptKey(keystoreJSON, "keystore-password")
+		if err != nil {
+			t.Fatalf("Failed to decrypt %s keystore with go-ethereum: %v", kdf, err)
+		}
+		if key.Address.Hex() != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+			t.Errorf("Unexpected address in %s keystore: %s", kdf, key.Address.Hex())
+		}
+		if key.PrivateKey.D.Cmp(privateKey.D) != 0 {
+			t.Errorf("Private key in %s keystore does not match", kdf)
+		}
+
+		if _, err := keystore.DecryptKey(keystoreJSON, "wrong-password"); err == nil {
+			t.Errorf("Expected error decrypting %s keystore with the wrong password, but got none", kdf)
+		}
+	}
+
+	if _, err := EncryptKeystoreV3(privateKey, "keystore-password", "argon2", true); err == nil {
+		t.Error("Expected error for unsupported kdf, but got none")
+	}
+}
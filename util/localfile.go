@@ -42,8 +42,31 @@ func SaveToFileSystem(data []byte, path string) error {
 		return fmt.Errorf("无法创建目录 %s: %v", dir, err)
 	}
 
-	// 写入文件
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	// 先写入临时文件再重命名，避免覆盖时留下不完整的文件
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("无法创建临时文件 %s: %v", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if err := tmpFile.Chmod(0600); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("无法设置文件权限 %s: %v", tmpPath, err)
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("无法写入文件 %s: %v", path, err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("无法写入文件 %s: %v", path, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("无法写入文件 %s: %v", path, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
 		return fmt.Errorf("无法写入文件 %s: %v", path, err)
 	}
 
@@ -0,0 +1,152 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Multicall3Address is the address of the canonical Multicall3 contract, deployed at the
+// same address on Ethereum and most EVM chains (https://www.multicall3.com)
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// multicall3ABI covers the Multicall3 functions used by the CLI
+const multicall3ABI = `[
+	{"name":"aggregate3","type":"function","stateMutability":"payable",
+	 "inputs":[{"name":"calls","type":"tuple[]","components":[
+		{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],
+	 "outputs":[{"name":"returnData","type":"tuple[]","components":[
+		{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]},
+	{"name":"aggregate3Value","type":"function","stateMutability":"payable",
+	 "inputs":[{"name":"calls","type":"tuple[]","components":[
+		{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"value","type":"uint256"},{"name":"callData","type":"bytes"}]}],
+	 "outputs":[{"name":"returnData","type":"tuple[]","components":[
+		{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]},
+	{"name":"getEthBalance","type":"function","stateMutability":"view",
+	 "inputs":[{"name":"addr","type":"address"}],
+	 "outputs":[{"name":"balance","type":"uint256"}]}
+]`
+
+// parsedMulticall3ABI is parsed once; multicall3ABIErr is returned by every function that
+// needs it so a bad definition surfaces as an error rather than a panic
+var parsedMulticall3ABI, multicall3ABIErr = abi.JSON(strings.NewReader(multicall3ABI))
+
+// Call3 is a call made through Multicall3 aggregate3
+type Call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Call3Value is a call with attached ETH made through Multicall3 aggregate3Value
+type Call3Value struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+// MulticallResult is the outcome of one call in a Multicall3 batch
+type MulticallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Aggregate3 runs several eth_calls in one round trip through Multicall3 at the given
+// block (nil for latest). Calls with AllowFailure set report failure in their result
+// instead of failing the whole batch.
+func Aggregate3(client *ethclient.Client, calls []Call3, blockNumber *big.Int) ([]MulticallResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	data, err := EncodeAggregate3(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &Multicall3Address,
+		Data: data,
+	}
+	output, err := client.CallContract(context.Background(), msg, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("multicall failed: %v", err)
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("multicall failed: Multicall3 is not deployed at %s on this chain", Multicall3Address.Hex())
+	}
+
+	return decodeMulticallResults("aggregate3", output, len(calls))
+}
+
+// EncodeAggregate3 encodes an aggregate3 call
+func EncodeAggregate3(calls []Call3) ([]byte, error) {
+	if multicall3ABIErr != nil {
+		return nil, fmt.Errorf("invalid Multicall3 ABI: %v", multicall3ABIErr)
+	}
+
+	data, err := parsedMulticall3ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("encode multicall failed: %v", err)
+	}
+	return data, nil
+}
+
+// EncodeAggregate3Value encodes an aggregate3Value call and returns the calldata and the
+// total value that must be sent with the transaction
+func EncodeAggregate3Value(calls []Call3Value) ([]byte, *big.Int, error) {
+	if multicall3ABIErr != nil {
+		return nil, nil, fmt.Errorf("invalid Multicall3 ABI: %v", multicall3ABIErr)
+	}
+
+	total := new(big.Int)
+	for i := range calls {
+		if calls[i].Value == nil {
+			calls[i].Value = big.NewInt(0)
+		}
+		total.Add(total, calls[i].Value)
+	}
+
+	data, err := parsedMulticall3ABI.Pack("aggregate3Value", calls)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode multicall failed: %v", err)
+	}
+	return data, total, nil
+}
+
+// EncodeGetEthBalance encodes a Multicall3 getEthBalance call, which lets ETH balances be
+// read in the same batch as token calls
+func EncodeGetEthBalance(address common.Address) []byte {
+	return append(crypto.Keccak256([]byte("getEthBalance(address)"))[:4], common.LeftPadBytes(address.Bytes(), 32)...)
+}
+
+// DecodeAggregate3Value decodes the return data of an aggregate3Value call
+func DecodeAggregate3Value(output []byte, expected int) ([]MulticallResult, error) {
+	return decodeMulticallResults("aggregate3Value", output, expected)
+}
+
+// decodeMulticallResults decodes the (bool,bytes)[] returned by aggregate3 and aggregate3Value
+func decodeMulticallResults(method string, output []byte, expected int) ([]MulticallResult, error) {
+	if multicall3ABIErr != nil {
+		return nil, fmt.Errorf("invalid Multicall3 ABI: %v", multicall3ABIErr)
+	}
+
+	values, err := parsedMulticall3ABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("decode multicall result failed: %v", err)
+	}
+
+	results := *abi.ConvertType(values[0], new([]MulticallResult)).(*[]MulticallResult)
+	if len(results) != expected {
+		return nil, fmt.Errorf("decode multicall result failed: expected %d results, got %d", expected, len(results))
+	}
+	return results, nil
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestMulticall3ABI(t *testing.T) {
+	if multicall3ABIErr != nil {
+		t.Fatalf("Multicall3 ABI does not parse: %v", multicall3ABIErr)
+	}
+	for _, method := range []string{"aggregate3", "aggregate3Value", "getEthBalance"} {
+		if _, ok := parsedMulticall3ABI.Methods[method]; !ok {
+			t.Errorf("Multicall3 ABI is missing %s", method)
+		}
+	}
+}
+
+func TestEncodeAggregate3(t *testing.T) {
+	calls := []Call3{
+		{Target: common.HexToAddress("0x01"), AllowFailure: true, CallData: []byte{0x95, 0xd8, 0x9b, 0x41}},
+		{Target: common.HexToAddress("0x02"), CallData: nil},
+	}
+
+	data, err := EncodeAggregate3(calls)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// keccak256("aggregate3((address,bool,bytes)[])")[:4]
+	if hexutil.Encode(data[:4]) != "0x82ad56cb" {
+		t.Errorf("Unexpected selector: %x", data[:4])
+	}
+
+	// The calls must decode back to the same values
+	values, err := parsedMulticall3ABI.Methods["aggregate3"].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("Failed to decode calls: %v", err)
+	}
+	var decoded []Call3
+	if err := parsedMulticall3ABI.Methods["aggregate3"].Inputs.Copy(&decoded, values); err != nil {
+		t.Fatalf("Failed to copy calls: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].Target != calls[0].Target || !decoded[0].AllowFailure || !bytes.Equal(decoded[0].CallData, calls[0].CallData) || decoded[1].AllowFailure {
+		t.Errorf("Unexpected decoded calls: %+v", decoded)
+	}
+}
+
+func TestEncodeAggregate3Value(t *testing.T) {
+	calls := []Call3Value{
+		{Target: common.HexToAddress("0x01"), Value: big.NewInt(5)},
+		{Target: common.HexToAddress("0x02")},
+		{Target: common.HexToAddress("0x03"), Value: big.NewInt(7)},
+	}
+
+	data, total, err := EncodeAggregate3Value(calls)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total.Int64() != 12 {
+		t.Errorf("Expected total value 12, got %s", total)
+	}
+	// keccak256("aggregate3Value((address,bool,uint256,bytes)[])")[:4]
+	if hexutil.Encode(data[:4]) != "0x174dea71" {
+		t.Errorf("Unexpected selector: %x", data[:4])
+	}
+}
+
+func TestEncodeGetEthBalance(t *testing.T) {
+	address := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data := EncodeGetEthBalance(address)
+
+	// keccak256("getEthBalance(address)")[:4]
+	if hexutil.Encode(data[:4]) != "0x4d2301cc" {
+		t.Errorf("Unexpected selector: %x", data[:4])
+	}
+	packed, err := parsedMulticall3ABI.Pack("getEthBalance", address)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, packed) {
+		t.Errorf("EncodeGetEthBalance = %x, want %x", data, packed)
+	}
+}
+
+func TestDecodeMulticallResults(t *testing.T) {
+	outputs := parsedMulticall3ABI.Methods["aggregate3"].Outputs
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	output, err := outputs.Pack([]result{
+		{Success: true, ReturnData: []byte{0x01, 0x02}},
+		{Success: false, ReturnData: nil},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode results: %v", err)
+	}
+
+	results, err := decodeMulticallResults("aggregate3", output, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !results[0].Success || !bytes.Equal(results[0].ReturnData, []byte{0x01, 0x02}) || results[1].Success {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	// Result count mismatch
+	if _, err := decodeMulticallResults("aggregate3", output, 3); err == nil {
+		t.Error("Expected error for a result count mismatch")
+	}
+
+	// Short and malformed return data
+	if _, err := decodeMulticallResults("aggregate3", output[:40], 2); err == nil {
+		t.Error("Expected error for truncated return data")
+	}
+	if _, err := decodeMulticallResults("aggregate3", nil, 0); err == nil {
+		t.Error("Expected error for empty return data")
+	}
+	malformed := append([]byte{}, output...)
+	copy(malformed[:32], common.LeftPadBytes([]byte{0xff, 0xff}, 32)) // offset past the end
+	if _, err := DecodeAggregate3Value(malformed, 2); err == nil {
+		t.Error("Expected error for an out of range offset")
+	}
+}
@@ -1,9 +1,25 @@
 package util
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/skip2/go-qrcode"
 )
 
+// QRFramePrefix starts every frame of an animated QR code
+const QRFramePrefix = "ETHCLI"
+
+// DefaultQRFrameSize is the number of base64 characters carried by one animated QR frame,
+// small enough for the QR code to fit in a terminal
+const DefaultQRFrameSize = 200
+
+// MaxQRFrames bounds the frame count read from a frame header, so a corrupted or hostile
+// frame cannot make the decoder allocate for millions of frames
+const MaxQRFrames = 1000
+
 func GenerateQRCode(data string) string {
 	qr, err := qrcode.New(data, qrcode.Medium)
 	if err != nil {
@@ -11,3 +27,95 @@ func GenerateQRCode(data string) string {
 	}
 	return qr.ToSmallString(false)
 }
+
+// EncodeQRFrames splits data into the frames of an animated QR code. Each frame is
+// "ETHCLI:<index>/<total>:<base64 chunk>" with 1-based indexes, so a scanner can collect
+// the frames in any order.
+func EncodeQRFrames(data []byte, frameSize int) []string {
+	if frameSize <= 0 {
+		frameSize = DefaultQRFrameSize
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	total := (len(encoded) + frameSize - 1) / frameSize
+	if total == 0 {
+		total = 1
+	}
+
+	frames := make([]string, total)
+	for i := range frames {
+		end := (i + 1) * frameSize
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		frames[i] = fmt.Sprintf("%s:%d/%d:%s", QRFramePrefix, i+1, total, encoded[i*frameSize:end])
+	}
+	return frames
+}
+
+// DecodeQRFrames reassembles the data of an animated QR code from its scanned frames,
+// which may be repeated and in any order
+func DecodeQRFrames(frames []string) ([]byte, error) {
+	var chunks []string
+	var seen []bool
+	received := 0
+	for _, frame := range frames {
+		frame = strings.TrimSpace(frame)
+		if frame == "" {
+			continue
+		}
+
+		parts := strings.SplitN(frame, ":", 3)
+		if len(parts) != 3 || parts[0] != QRFramePrefix {
+			return nil, fmt.Errorf("invalid QR frame: %.40s", frame)
+		}
+		indexStr, totalStr, ok := strings.Cut(parts[1], "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid QR frame number: %s", parts[1])
+		}
+		index, err := strconv.Atoi(indexStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid QR frame number: %s", parts[1])
+		}
+		total, err := strconv.Atoi(totalStr)
+		if err != nil || total <= 0 || index < 1 || index > total {
+			return nil, fmt.Errorf("invalid QR frame number: %s", parts[1])
+		}
+		if total > MaxQRFrames {
+			return nil, fmt.Errorf("QR code of %d frames exceeds the limit of %d frames", total, MaxQRFrames)
+		}
+
+		if chunks == nil {
+			chunks = make([]string, total)
+			seen = make([]bool, total)
+		} else if total != len(chunks) {
+			return nil, fmt.Errorf("QR frames of different codes: %d and %d frames", len(chunks), total)
+		}
+		if !seen[index-1] {
+			seen[index-1] = true
+			received++
+		} else if chunks[index-1] != parts[2] {
+			return nil, fmt.Errorf("QR frame %d was scanned twice with different content", index)
+		}
+		chunks[index-1] = parts[2]
+	}
+
+	if chunks == nil {
+		return nil, fmt.Errorf("no QR frames found")
+	}
+	if received != len(chunks) {
+		var missing []string
+		for i := range chunks {
+			if !seen[i] {
+				missing = append(missing, strconv.Itoa(i+1))
+			}
+		}
+		return nil, fmt.Errorf("missing QR frame(s) %s of %d", strings.Join(missing, ", "), len(chunks))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(chunks, ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid QR frame data: %v", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestQRFramesRoundTrip(t *testing.T) {
+	data := []byte(strings.Repeat(`{"rawTx":"0x02f8b1018080"}`, 40))
+	frames := EncodeQRFrames(data, 100)
+	if len(frames) < 2 || !strings.HasPrefix(frames[0], "ETHCLI:1/") {
+		t.Fatalf("Unexpected frames: %d, %q", len(frames), frames[0])
+	}
+
+	// Scanners read frames out of order and more than once
+	scanned := append([]string{frames[len(frames)-1]}, frames...)
+	scanned = append(scanned, frames[0])
+	decoded, err := DecodeQRFrames(scanned)
+	if err != nil {
+		t.Fatalf("Failed to decode frames: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("Decoded data does not match")
+	}
+
+	if _, err := DecodeQRFrames(frames[1:]); err == nil || !strings.Contains(err.Error(), "missing QR frame(s) 1") {
+		t.Errorf("Expected a missing frame error, got %v", err)
+	}
+	for _, invalid := range [][]string{nil, {"hello"}, {"ETHCLI:3/2:abcd"}, {"ETHCLI:1/2:abcd", "ETHCLI:1/3:abcd"}, {"ETHCLI:1/2000000000:abcd"}} {
+		if _, err := DecodeQRFrames(invalid); err == nil {
+			t.Errorf("Expected error for %q, but got none", invalid)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// Shamir secret sharing over GF(2^8)
+//
+// Every byte of the secret is shared independently with a random polynomial of degree
+// threshold-1 whose constant term is the secret byte. Share i (1..255) holds the
+// evaluation of every polynomial at x = i. Arithmetic uses the AES field polynomial
+// x^8 + x^4 + x^3 + x + 1 (0x11b). Any threshold shares recover the secret with
+// Lagrange interpolation at x = 0; fewer shares reveal nothing about it.
+
+// ShamirShare is one share of a split secret
+type ShamirShare struct {
+	Index uint8  // x coordinate, 1..255
+	Value []byte // y coordinates, one per secret byte
+}
+
+var gfExp [510]byte
+var gfLog [256]byte
+
+func init() {
+	// 生成 GF(2^8) 的指数表和对数表（生成元为 3）
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfExp[i+255] = x
+		gfLog[x] = byte(i)
+		x ^= gfMulNoTable(x, 2)
+	}
+}
+
+// gfMulNoTable multiplies in GF(2^8) without lookup tables (used to build the tables)
+func gfMulNoTable(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// SplitSecret splits a secret into the given number of shares, any threshold of which recover it
+func SplitSecret(secret []byte, threshold, shares int) ([]ShamirShare, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret cannot be empty")
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold must be at least 2")
+	}
+	if shares < threshold {
+		return nil, fmt.Errorf("number of shares (%d) must be at least the threshold (%d)", shares, threshold)
+	}
+	if shares > 255 {
+		return nil, fmt.Errorf("number of shares cannot exceed 255")
+	}
+
+	result := make([]ShamirShare, shares)
+	for i := range result {
+		result[i] = ShamirShare{Index: uint8(i + 1), Value: make([]byte, len(secret))}
+	}
+
+	// 每个字节使用一个随机多项式，常数项为秘密字节
+	coefficients := make([]byte, threshold)
+	for b, secretByte := range secret {
+		coefficients[0] = secretByte
+		if _, err := io.ReadFull(rand.Reader, coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("failed to generate random coefficients: %v", err)
+		}
+
+		for i := range result {
+			// Horner 法求值
+			x := result[i].Index
+			var y byte
+			for c := threshold - 1; c >= 0; c-- {
+				y = gfMul(y, x) ^ coefficients[c]
+			}
+			result[i].Value[b] = y
+		}
+	}
+
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+
+	return result, nil
+}
+
+// CombineShares recovers a secret from shares produced by SplitSecret.
+// The caller must supply at least the threshold number of shares; fewer shares yield garbage.
+func CombineShares(shares []ShamirShare) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("at least 2 shares are required")
+	}
+
+	length := len(shares[0].Value)
+	seen := make(map[uint8]bool)
+	for _, share := range shares {
+		if share.Index == 0 {
+			return nil, fmt.Errorf("invalid share index 0")
+		}
+		if seen[share.Index] {
+			return nil, fmt.Errorf("duplicate share index %d", share.Index)
+		}
+		seen[share.Index] = true
+		if len(share.Value) != length || length == 0 {
+			return nil, fmt.Errorf("shares have inconsistent lengths")
+		}
+	}
+
+	// 在 x = 0 处进行拉格朗日插值
+	secret := make([]byte, length)
+	for i, share := range shares {
+		basis := byte(1)
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			basis = gfMul(basis, gfDiv(other.Index, share.Index^other.Index))
+		}
+		for b := range secret {
+			secret[b] ^= gfMul(share.Value[b], basis)
+		}
+	}
+
+	return secret, nil
+}
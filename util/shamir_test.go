@@ -0,0 +1,63 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitAndCombineShares(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	shares, err := SplitSecret(secret, 3, 5)
+	if err != nil {
+		t.Fatalf("Failed to split secret: %v", err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("Expected 5 shares, got %d", len(shares))
+	}
+
+	// Every combination of 3 shares must recover the secret
+	for a := 0; a < 5; a++ {
+		for b := a + 1; b < 5; b++ {
+			for c := b + 1; c < 5; c++ {
+				recovered, err := CombineShares([]ShamirShare{shares[c], shares[a], shares[b]})
+				if err != nil {
+					t.Fatalf("Failed to combine shares %d,%d,%d: %v", a, b, c, err)
+				}
+				if !bytes.Equal(recovered, secret) {
+					t.Errorf("Shares %d,%d,%d recovered the wrong secret", a, b, c)
+				}
+			}
+		}
+	}
+
+	// Two shares are below the threshold
+	recovered, err := CombineShares(shares[:2])
+	if err != nil {
+		t.Fatalf("Failed to combine shares: %v", err)
+	}
+	if bytes.Equal(recovered, secret) {
+		t.Error("Expected two shares not to recover a threshold 3 secret")
+	}
+
+	if _, err := CombineShares([]ShamirShare{shares[0], shares[0], shares[1]}); err == nil {
+		t.Error("Expected error for duplicate shares, but got none")
+	}
+}
+
+func TestSplitSecretValidation(t *testing.T) {
+	secret := []byte{1, 2, 3}
+
+	if _, err := SplitSecret(secret, 1, 3); err == nil {
+		t.Error("Expected error for threshold 1, but got none")
+	}
+	if _, err := SplitSecret(secret, 4, 3); err == nil {
+		t.Error("Expected error for threshold above share count, but got none")
+	}
+	if _, err := SplitSecret(secret, 2, 256); err == nil {
+		t.Error("Expected error for more than 255 shares, but got none")
+	}
+	if _, err := SplitSecret(nil, 2, 3); err == nil {
+		t.Error("Expected error for empty secret, but got none")
+	}
+}
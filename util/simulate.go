@@ -0,0 +1,402 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Simulation methods, in the order they are tried
+const (
+	SimulateMethodSimulateV1 = "eth_simulateV1"
+	SimulateMethodTraceCall  = "debug_traceCall"
+)
+
+// EthAddress stands for ETH in asset changes. eth_simulateV1 reports ETH transfers as ERC20
+// Transfer logs from this address, and ETH transfers found by debug_traceCall are turned into
+// the same logs.
+var EthAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Topics of the token events decoded into asset changes
+var (
+	TransferEventTopic       = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	ApprovalEventTopic       = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+	ApprovalForAllEventTopic = crypto.Keccak256Hash([]byte("ApprovalForAll(address,address,bool)"))
+	TransferSingleEventTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	TransferBatchEventTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// ErrSimulationUnsupported is returned when the node supports no simulation method
+var ErrSimulationUnsupported = errors.New("the RPC node supports neither eth_simulateV1 nor debug_traceCall")
+
+// SimulationResult is the outcome of a simulated transaction
+type SimulationResult struct {
+	Method       string // SimulateMethodSimulateV1 or SimulateMethodTraceCall
+	GasUsed      uint64
+	Logs         []types.Log // logs of the calls that did not revert, ETH transfers included
+	Reverted     bool
+	RevertReason string
+}
+
+// simulatedLog is a log as returned by eth_simulateV1 and the call tracer, which do not
+// carry every field types.Log requires
+type simulatedLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// SimulateTransaction executes a transaction on the latest state without sending it, with
+// eth_simulateV1 (eth_call with state overrides and logs) or, when the node does not support
+// it, debug_traceCall with the call tracer. It returns ErrSimulationUnsupported if the node
+// supports neither.
+func SimulateTransaction(ctx context.Context, client *rpc.Client, msg ethereum.CallMsg) (*SimulationResult, error) {
+	result, simulateErr := simulateV1(ctx, client, msg)
+	if simulateErr == nil {
+		return result, nil
+	}
+	if !isMethodUnsupported(simulateErr) {
+		return nil, fmt.Errorf("%s failed: %v", SimulateMethodSimulateV1, simulateErr)
+	}
+
+	result, traceErr := traceCall(ctx, client, msg)
+	if traceErr == nil {
+		return result, nil
+	}
+	if !isMethodUnsupported(traceErr) {
+		return nil, fmt.Errorf("%s failed: %v", SimulateMethodTraceCall, traceErr)
+	}
+	return nil, ErrSimulationUnsupported
+}
+
+// isMethodUnsupported reports whether an RPC error means that the method is not available,
+// as opposed to the simulated transaction failing. Providers word this differently.
+func isMethodUnsupported(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, hint := range []string{"not found", "does not exist", "not available", "not supported", "unsupported", "unknown method", "not whitelisted", "not allowed"} {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// callArgs converts a call message into the JSON-RPC transaction call object
+func callArgs(msg ethereum.CallMsg) map[string]interface{} {
+	args := map[string]interface{}{
+		"from":  msg.From,
+		"input": hexutil.Bytes(msg.Data),
+	}
+	if msg.To != nil {
+		args["to"] = msg.To
+	}
+	if msg.Value != nil {
+		args["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		args["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	return args
+}
+
+// simulateV1 simulates the call with eth_simulateV1. ETH transfers are traced as logs; fee
+// validation is off so the sender only needs the value it sends.
+func simulateV1(ctx context.Context, client *rpc.Client, msg ethereum.CallMsg) (*SimulationResult, error) {
+	params := map[string]interface{}{
+		"blockStateCalls": []interface{}{map[string]interface{}{"calls": []interface{}{callArgs(msg)}}},
+		"traceTransfers":  true,
+		"validation":      false,
+	}
+
+	var blocks []struct {
+		Calls []struct {
+			Status     hexutil.Uint64 `json:"status"`
+			GasUsed    hexutil.Uint64 `json:"gasUsed"`
+			ReturnData hexutil.Bytes  `json:"returnData"`
+			Logs       []simulatedLog `json:"logs"`
+			Error      *struct {
+				Message string `json:"message"`
+				Data    string `json:"data"`
+			} `json:"error"`
+		} `json:"calls"`
+	}
+	if err := client.CallContext(ctx, &blocks, SimulateMethodSimulateV1, params, "latest"); err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != 1 {
+		return nil, fmt.Errorf("unexpected %s response", SimulateMethodSimulateV1)
+	}
+
+	call := blocks[0].Calls[0]
+	result := &SimulationResult{Method: SimulateMethodSimulateV1, GasUsed: uint64(call.GasUsed)}
+	if call.Status != 1 {
+		result.Reverted = true
+		revertData := []byte(call.ReturnData)
+		message := ""
+		if call.Error != nil {
+			message = call.Error.Message
+			if data, err := hexutil.Decode(call.Error.Data); err == nil {
+				revertData = data
+			}
+		}
+		result.RevertReason = revertReason(revertData, message)
+		return result, nil
+	}
+	for _, log := range call.Logs {
+		result.Logs = append(result.Logs, log.toLog())
+	}
+	return result, nil
+}
+
+// callFrame is a frame of the call tracer output
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to"`
+	Value        *hexutil.Big    `json:"value"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Output       hexutil.Bytes   `json:"output"`
+	Error        string          `json:"error"`
+	RevertReason string          `json:"revertReason"`
+	Logs         []simulatedLog  `json:"logs"`
+	Calls        []callFrame     `json:"calls"`
+}
+
+// traceCall simulates the call with debug_traceCall and the call tracer
+func traceCall(ctx context.Context, client *rpc.Client, msg ethereum.CallMsg) (*SimulationResult, error) {
+	config := map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}
+
+	var frame callFrame
+	if err := client.CallContext(ctx, &frame, SimulateMethodTraceCall, callArgs(msg), "latest", config); err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{Method: SimulateMethodTraceCall, GasUsed: uint64(frame.GasUsed)}
+	if frame.Error != "" {
+		result.Reverted = true
+		result.RevertReason = frame.RevertReason
+		if result.RevertReason == "" {
+			result.RevertReason = revertReason(frame.Output, frame.Error)
+		}
+		return result, nil
+	}
+	result.Logs = frame.collectLogs(nil)
+	return result, nil
+}
+
+// collectLogs appends the logs and ETH transfers of a frame and its subcalls, skipping the
+// frames that reverted since their effects were undone
+func (f callFrame) collectLogs(logs []types.Log) []types.Log {
+	if f.Error != "" {
+		return logs
+	}
+	switch f.Type {
+	case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+		if f.Value != nil && f.Value.ToInt().Sign() > 0 && f.To != nil {
+			logs = append(logs, ethTransferLog(f.From, *f.To, f.Value.ToInt()))
+		}
+	}
+	for _, log := range f.Logs {
+		logs = append(logs, log.toLog())
+	}
+	for _, call := range f.Calls {
+		logs = call.collectLogs(logs)
+	}
+	return logs
+}
+
+func (l simulatedLog) toLog() types.Log {
+	return types.Log{Address: l.Address, Topics: l.Topics, Data: l.Data}
+}
+
+// ethTransferLog returns an ETH transfer in the form eth_simulateV1 reports it
+func ethTransferLog(from, to common.Address, value *big.Int) types.Log {
+	return types.Log{
+		Address: EthAddress,
+		Topics:  []common.Hash{TransferEventTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(value).Bytes(),
+	}
+}
+
+// revertReason describes why a simulated call reverted, from its Error(string) revert data
+// or else the node's message
+func revertReason(data []byte, message string) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) >= 4 {
+		return fmt.Sprintf("%s (revert data %s)", message, hexutil.Encode(data))
+	}
+	if message == "" {
+		return "execution reverted"
+	}
+	return message
+}
+
+// Kinds of asset changes
+const (
+	AssetChangeBalance     = "balance"      // Amount of Token gained (positive) or lost (negative)
+	AssetChangeNFT         = "nft"          // TokenID gained or lost, Amount copies (1 for ERC721)
+	AssetChangeAllowance   = "allowance"    // Spender may spend Amount of Token
+	AssetChangeNFTApproval = "nft-approval" // Spender may transfer TokenID
+	AssetChangeOperator    = "operator"     // Spender may (Approved) or may no longer transfer every NFT of Token
+)
+
+// AssetChange is a change of the balances or approvals of an account
+type AssetChange struct {
+	Kind     string
+	Token    common.Address // EthAddress for ETH
+	Spender  common.Address
+	TokenID  *big.Int
+	Amount   *big.Int
+	Approved bool
+}
+
+// AssetChangesFromLogs decodes the Transfer, Approval, ApprovalForAll, TransferSingle and
+// TransferBatch logs of a transaction into the balance and approval changes of owner.
+// Balance changes of the same asset are added up and dropped if they cancel out; for
+// approvals the last one wins.
+func AssetChangesFromLogs(logs []types.Log, owner common.Address) []AssetChange {
+	var changes []AssetChange
+	index := make(map[string]int)
+	record := func(key string, change AssetChange, add bool) {
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(changes)
+			changes = append(changes, change)
+			return
+		}
+		if add {
+			changes[i].Amount = new(big.Int).Add(changes[i].Amount, change.Amount)
+		} else {
+			changes[i] = change
+		}
+	}
+	signed := func(amount *big.Int, from common.Address) *big.Int {
+		if from == owner {
+			return new(big.Int).Neg(amount)
+		}
+		return amount
+	}
+	nftKey := func(token common.Address, id *big.Int) string {
+		return AssetChangeNFT + token.Hex() + id.String()
+	}
+
+	isOwner := func(topic common.Hash) bool { return common.BytesToAddress(topic.Bytes()) == owner }
+	topicAddress := func(topic common.Hash) common.Address { return common.BytesToAddress(topic.Bytes()) }
+
+	var clearedApprovals []types.Log
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		switch {
+		case log.Topics[0] == TransferEventTopic && len(log.Topics) == 3 && len(log.Data) == 32:
+			from, to := topicAddress(log.Topics[1]), topicAddress(log.Topics[2])
+			if (from == owner) == (to == owner) {
+				continue
+			}
+			amount := signed(new(big.Int).SetBytes(log.Data), from)
+			record(AssetChangeBalance+log.Address.Hex(), AssetChange{Kind: AssetChangeBalance, Token: log.Address, Amount: amount}, true)
+
+		case log.Topics[0] == TransferEventTopic && len(log.Topics) == 4:
+			from, to := topicAddress(log.Topics[1]), topicAddress(log.Topics[2])
+			if (from == owner) == (to == owner) {
+				continue
+			}
+			id := log.Topics[3].Big()
+			record(nftKey(log.Address, id), AssetChange{Kind: AssetChangeNFT, Token: log.Address, TokenID: id, Amount: signed(big.NewInt(1), from)}, true)
+
+		case log.Topics[0] == TransferSingleEventTopic && len(log.Topics) == 4 && len(log.Data) == 64:
+			from, to := topicAddress(log.Topics[2]), topicAddress(log.Topics[3])
+			if (from == owner) == (to == owner) {
+				continue
+			}
+			id := new(big.Int).SetBytes(log.Data[:32])
+			amount := signed(new(big.Int).SetBytes(log.Data[32:]), from)
+			record(nftKey(log.Address, id), AssetChange{Kind: AssetChangeNFT, Token: log.Address, TokenID: id, Amount: amount}, true)
+
+		case log.Topics[0] == TransferBatchEventTopic && len(log.Topics) == 4:
+			from, to := topicAddress(log.Topics[2]), topicAddress(log.Topics[3])
+			if (from == owner) == (to == owner) {
+				continue
+			}
+			ids, amounts, ok := decodeTransferBatch(log.Data)
+			if !ok {
+				continue
+			}
+			for i, id := range ids {
+				record(nftKey(log.Address, id), AssetChange{Kind: AssetChangeNFT, Token: log.Address, TokenID: id, Amount: signed(amounts[i], from)}, true)
+			}
+
+		case log.Topics[0] == ApprovalEventTopic && len(log.Topics) == 3 && len(log.Data) == 32 && isOwner(log.Topics[1]):
+			spender := topicAddress(log.Topics[2])
+			change := AssetChange{Kind: AssetChangeAllowance, Token: log.Address, Spender: spender, Amount: new(big.Int).SetBytes(log.Data)}
+			record(AssetChangeAllowance+log.Address.Hex()+spender.Hex(), change, false)
+
+		case log.Topics[0] == ApprovalEventTopic && len(log.Topics) == 4 && isOwner(log.Topics[1]):
+			spender, id := topicAddress(log.Topics[2]), log.Topics[3].Big()
+			if spender == (common.Address{}) {
+				// Transfers clear the approval of the token, reported with the transfer
+				clearedApprovals = append(clearedApprovals, log)
+				continue
+			}
+			record(AssetChangeNFTApproval+log.Address.Hex()+id.String(), AssetChange{Kind: AssetChangeNFTApproval, Token: log.Address, Spender: spender, TokenID: id}, false)
+
+		case log.Topics[0] == ApprovalForAllEventTopic && len(log.Topics) == 3 && len(log.Data) == 32 && isOwner(log.Topics[1]):
+			operator := topicAddress(log.Topics[2])
+			change := AssetChange{Kind: AssetChangeOperator, Token: log.Address, Spender: operator, Approved: new(big.Int).SetBytes(log.Data).Sign() != 0}
+			record(AssetChangeOperator+log.Address.Hex()+operator.Hex(), change, false)
+		}
+	}
+
+	// An approval cleared without a transfer of the token is an explicit revocation
+	for _, log := range clearedApprovals {
+		id := log.Topics[3].Big()
+		if _, transferred := index[nftKey(log.Address, id)]; !transferred {
+			record(AssetChangeNFTApproval+log.Address.Hex()+id.String(), AssetChange{Kind: AssetChangeNFTApproval, Token: log.Address, TokenID: id}, false)
+		}
+	}
+
+	// Drop balance changes that cancel out
+	var result []AssetChange
+	for _, change := range changes {
+		if (change.Kind == AssetChangeBalance || change.Kind == AssetChangeNFT) && change.Amount.Sign() == 0 {
+			continue
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// decodeTransferBatch decodes the ids and values of a TransferBatch event
+func decodeTransferBatch(data []byte) ([]*big.Int, []*big.Int, bool) {
+	uintArray, _ := abi.NewType("uint256[]", "", nil)
+	values, err := abi.Arguments{{Type: uintArray}, {Type: uintArray}}.Unpack(data)
+	if err != nil || len(values) != 2 {
+		return nil, nil, false
+	}
+	ids, ok1 := values[0].([]*big.Int)
+	amounts, ok2 := values[1].([]*big.Int)
+	if !ok1 || !ok2 || len(ids) != len(amounts) {
+		return nil, nil, false
+	}
+	return ids, amounts, true
+}
@@ -0,0 +1,194 @@
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	simOwner = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	simOther = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	simToken = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	simNFT   = common.HexToAddress("0x00000000000000000000000000000000000000dd")
+)
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func transferLog(token, from, to common.Address, amount int64) types.Log {
+	return types.Log{Address: token, Topics: []common.Hash{TransferEventTopic, addressTopic(from), addressTopic(to)}, Data: common.BigToHash(big.NewInt(amount)).Bytes()}
+}
+
+func TestAssetChangesFromLogs(t *testing.T) {
+	tokenID := common.BigToHash(big.NewInt(7))
+	batchData := append(common.BigToHash(big.NewInt(64)).Bytes(), common.BigToHash(big.NewInt(128)).Bytes()...)
+	batchData = append(batchData, common.BigToHash(big.NewInt(1)).Bytes()...)
+	batchData = append(batchData, common.BigToHash(big.NewInt(3)).Bytes()...)
+	batchData = append(batchData, common.BigToHash(big.NewInt(1)).Bytes()...)
+	batchData = append(batchData, common.BigToHash(big.NewInt(5)).Bytes()...)
+
+	logs := []types.Log{
+		ethTransferLog(simOwner, simOther, big.NewInt(100)),
+		transferLog(simToken, simOwner, simOther, 50),
+		transferLog(simToken, simOther, simOwner, 20),
+		// Transfers between other accounts and round trips do not change the owner's balance
+		transferLog(simToken, simOther, simToken, 999),
+		transferLog(simNFT, simOwner, simOther, 3),
+		transferLog(simNFT, simOther, simOwner, 3),
+		// The approval of a transferred NFT is cleared with the transfer
+		{Address: simNFT, Topics: []common.Hash{ApprovalEventTopic, addressTopic(simOwner), {}, tokenID}},
+		{Address: simNFT, Topics: []common.Hash{TransferEventTopic, addressTopic(simOwner), addressTopic(simOther), tokenID}},
+		{Address: simToken, Topics: []common.Hash{ApprovalEventTopic, addressTopic(simOwner), addressTopic(simOther)}, Data: common.BigToHash(big.NewInt(1)).Bytes()},
+		{Address: simToken, Topics: []common.Hash{ApprovalEventTopic, addressTopic(simOwner), addressTopic(simOther)}, Data: common.BigToHash(big.NewInt(2)).Bytes()},
+		// Approvals by other owners are not the owner's
+		{Address: simToken, Topics: []common.Hash{ApprovalEventTopic, addressTopic(simOther), addressTopic(simOwner)}, Data: common.BigToHash(big.NewInt(9)).Bytes()},
+		{Address: simNFT, Topics: []common.Hash{ApprovalForAllEventTopic, addressTopic(simOwner), addressTopic(simOther)}, Data: common.BigToHash(big.NewInt(1)).Bytes()},
+		{Address: simNFT, Topics: []common.Hash{TransferSingleEventTopic, addressTopic(simOther), addressTopic(simOther), addressTopic(simOwner)}, Data: append(common.BigToHash(big.NewInt(9)).Bytes(), common.BigToHash(big.NewInt(4)).Bytes()...)},
+		{Address: simNFT, Topics: []common.Hash{TransferBatchEventTopic, addressTopic(simOwner), addressTopic(simOwner), addressTopic(simOther)}, Data: batchData},
+	}
+
+	want := []AssetChange{
+		{Kind: AssetChangeBalance, Token: EthAddress, Amount: big.NewInt(-100)},
+		{Kind: AssetChangeBalance, Token: simToken, Amount: big.NewInt(-30)},
+		{Kind: AssetChangeNFT, Token: simNFT, TokenID: big.NewInt(7), Amount: big.NewInt(-1)},
+		{Kind: AssetChangeAllowance, Token: simToken, Spender: simOther, Amount: big.NewInt(2)},
+		{Kind: AssetChangeOperator, Token: simNFT, Spender: simOther, Approved: true},
+		{Kind: AssetChangeNFT, Token: simNFT, TokenID: big.NewInt(9), Amount: big.NewInt(4)},
+		{Kind: AssetChangeNFT, Token: simNFT, TokenID: big.NewInt(3), Amount: big.NewInt(-5)},
+	}
+
+	got := AssetChangesFromLogs(logs, simOwner)
+	if len(got) != len(want) {
+		t.Fatalf("Unexpected asset changes: %+v", got)
+	}
+	for i := range want {
+		if !sameAssetChange(got[i], want[i]) {
+			t.Errorf("Unexpected asset change %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAssetChangesNFTRevocation(t *testing.T) {
+	// An approval cleared without a transfer is an explicit revocation
+	logs := []types.Log{{Address: simNFT, Topics: []common.Hash{ApprovalEventTopic, addressTopic(simOwner), {}, common.BigToHash(big.NewInt(7))}}}
+	got := AssetChangesFromLogs(logs, simOwner)
+	want := AssetChange{Kind: AssetChangeNFTApproval, Token: simNFT, TokenID: big.NewInt(7)}
+	if len(got) != 1 || !sameAssetChange(got[0], want) {
+		t.Errorf("Unexpected asset changes: %+v", got)
+	}
+}
+
+func sameAssetChange(a, b AssetChange) bool {
+	sameInt := func(x, y *big.Int) bool { return (x == nil && y == nil) || (x != nil && y != nil && x.Cmp(y) == 0) }
+	return a.Kind == b.Kind && a.Token == b.Token && a.Spender == b.Spender && a.Approved == b.Approved &&
+		sameInt(a.TokenID, b.TokenID) && sameInt(a.Amount, b.Amount)
+}
+
+// fakeRPC answers JSON-RPC requests by method with a result or an error
+func fakeRPC(t *testing.T, responses map[string]string) *rpc.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Invalid request: %v", err)
+			return
+		}
+		response, ok := responses[request.Method]
+		if !ok {
+			response = `"error":{"code":-32601,"message":"the method ` + request.Method + ` does not exist/is not available"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(request.ID) + `,` + response + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := rpc.Dial(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to dial fake RPC: %v", err)
+	}
+	return client
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	msg := ethereum.CallMsg{From: simOwner, To: &simToken, Data: EncodeERC20Transfer(simOther.Hex(), big.NewInt(50))}
+	transferJSON := `{"address":"` + simToken.Hex() + `","topics":["` + TransferEventTopic.Hex() + `","` + addressTopic(simOwner).Hex() + `","` + addressTopic(simOther).Hex() + `"],"data":"` + common.BigToHash(big.NewInt(50)).Hex() + `"}`
+	// Error(string) revert data for "insufficient balance"
+	revertData := "0x08c379a0" + common.BigToHash(big.NewInt(32)).Hex()[2:] + common.BigToHash(big.NewInt(20)).Hex()[2:] + hex.EncodeToString(common.RightPadBytes([]byte("insufficient balance"), 32))
+
+	tests := []struct {
+		name      string
+		responses map[string]string
+		method    string
+		reverted  string
+		logs      int
+	}{
+		{
+			name:      "eth_simulateV1",
+			responses: map[string]string{"eth_simulateV1": `"result":[{"calls":[{"status":"0x1","gasUsed":"0x8000","returnData":"0x","logs":[` + transferJSON + `]}]}]`},
+			method:    SimulateMethodSimulateV1,
+			logs:      1,
+		},
+		{
+			name:      "eth_simulateV1 revert",
+			responses: map[string]string{"eth_simulateV1": `"result":[{"calls":[{"status":"0x0","gasUsed":"0x8000","returnData":"` + revertData + `","logs":[],"error":{"code":3,"message":"execution reverted","data":"` + revertData + `"}}]}]`},
+			method:    SimulateMethodSimulateV1,
+			reverted:  "insufficient balance",
+		},
+		{
+			name: "debug_traceCall fallback",
+			responses: map[string]string{"debug_traceCall": `"result":{"type":"CALL","from":"` + simOwner.Hex() + `","to":"` + simToken.Hex() + `","value":"0x5","gasUsed":"0x8000","logs":[` + transferJSON + `],"calls":[` +
+				`{"type":"CALL","from":"` + simToken.Hex() + `","to":"` + simOwner.Hex() + `","value":"0x1","gasUsed":"0x10"},` +
+				`{"type":"CALL","from":"` + simToken.Hex() + `","to":"` + simOther.Hex() + `","value":"0x1","gasUsed":"0x10","error":"execution reverted","logs":[` + transferJSON + `]}]}`},
+			method: SimulateMethodTraceCall,
+			// Value of the call, transfer log and the internal ETH transfer; the reverted frame is skipped
+			logs: 3,
+		},
+		{
+			name:      "debug_traceCall revert",
+			responses: map[string]string{"debug_traceCall": `"result":{"type":"CALL","from":"` + simOwner.Hex() + `","to":"` + simToken.Hex() + `","gasUsed":"0x8000","output":"` + revertData + `","error":"execution reverted"}`},
+			method:    SimulateMethodTraceCall,
+			reverted:  "insufficient balance",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := SimulateTransaction(context.Background(), fakeRPC(t, test.responses), msg)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if result.Method != test.method {
+			t.Errorf("Unexpected method for %s: %s", test.name, result.Method)
+		}
+		if result.Reverted != (test.reverted != "") || result.RevertReason != test.reverted {
+			t.Errorf("Unexpected revert for %s: %v %q", test.name, result.Reverted, result.RevertReason)
+		}
+		if len(result.Logs) != test.logs {
+			t.Errorf("Unexpected logs for %s: %+v", test.name, result.Logs)
+		}
+	}
+
+	if _, err := SimulateTransaction(context.Background(), fakeRPC(t, nil), msg); !errors.Is(err, ErrSimulationUnsupported) {
+		t.Errorf("Expected ErrSimulationUnsupported, got %v", err)
+	}
+
+	// Errors other than a missing method are reported instead of falling back
+	failing := map[string]string{"eth_simulateV1": `"error":{"code":-32000,"message":"insufficient funds for transfer"}`}
+	if _, err := SimulateTransaction(context.Background(), fakeRPC(t, failing), msg); err == nil || errors.Is(err, ErrSimulationUnsupported) {
+		t.Errorf("Expected a simulation error, got %v", err)
+	}
+}
@@ -62,3 +62,14 @@ func ParseTokenAmount(amountStr string, decimals uint8) (*big.Int, error) {
 
 	return result, nil
 }
+
+// FormatTokenAmount formats an amount in the token's smallest unit as a decimal string
+func FormatTokenAmount(amount *big.Int, decimals uint8) string {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	intPart := new(big.Int).Div(amount, divisor)
+	remainder := new(big.Int).Mod(amount, divisor)
+	if decimals == 0 {
+		return intPart.String()
+	}
+	return fmt.Sprintf("%d.%0*d", intPart, int(decimals), remainder)
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatTokenAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     string
+	}{
+		{"1500000", 6, "1.500000"},
+		{"1", 18, "0.000000000000000001"},
+		{"0", 2, "0.00"},
+		{"42", 0, "42"},
+		{"123456789012345678901234567890", 18, "123456789012.345678901234567890"},
+	}
+
+	for _, tc := range tests {
+		amount, _ := new(big.Int).SetString(tc.amount, 10)
+		got := FormatTokenAmount(amount, tc.decimals)
+		if got != tc.want {
+			t.Errorf("FormatTokenAmount(%s, %d) = %s, want %s", tc.amount, tc.decimals, got, tc.want)
+		}
+
+		// Formatting must round trip through ParseTokenAmount
+		parsed, err := ParseTokenAmount(got, tc.decimals)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", got, err)
+			continue
+		}
+		if parsed.Cmp(amount) != 0 {
+			t.Errorf("Round trip of %s gave %s", tc.amount, parsed)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
@@ -28,6 +29,15 @@ const ERC721TransferFromSignature = "transferFrom(address,address,uint256)"
 // ERC721ApproveSignature is the function signature for the ERC721 approve function
 const ERC721ApproveSignature = "approve(address,uint256)"
 
+// SetApprovalForAllSignature is the function signature for the ERC721/ERC1155 setApprovalForAll function
+const SetApprovalForAllSignature = "setApprovalForAll(address,bool)"
+
+// ERC1155SafeTransferFromSignature is the function signature for the ERC1155 safeTransferFrom function
+const ERC1155SafeTransferFromSignature = "safeTransferFrom(address,address,uint256,uint256,bytes)"
+
+// ERC1155SafeBatchTransferFromSignature is the function signature for the ERC1155 safeBatchTransferFrom function
+const ERC1155SafeBatchTransferFromSignature = "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)"
+
 func GetNonce(client *ethclient.Client, address common.Address) (uint64, error) {
 	nonce, err := client.PendingNonceAt(context.Background(), address)
 	if err != nil {
@@ -36,9 +46,95 @@ func GetNonce(client *ethclient.Client, address common.Address) (uint64, error)
 	return nonce, nil
 }
 
+// Transaction types
+const (
+	TxTypeLegacy     = "legacy"
+	TxTypeAccessList = "2930"
+	TxTypeDynamicFee = "1559"
+)
+
+// TxParams holds the type, fees and access list of a transaction
+type TxParams struct {
+	Type       string           // TxTypeLegacy, TxTypeAccessList or TxTypeDynamicFee
+	Fees       FeeParams        // legacy and 2930 transactions use MaxFeePerGas as the gas price
+	AccessList types.AccessList // not supported by legacy transactions
+}
+
+// TxTypeName returns a display name for a transaction type
+func TxTypeName(txType string) string {
+	switch txType {
+	case TxTypeLegacy:
+		return "Legacy"
+	case TxTypeAccessList:
+		return "EIP-2930"
+	default:
+		return "EIP-1559"
+	}
+}
+
+// ParseAccessList parses an access list in JSON-RPC format:
+// [{"address": "0x...", "storageKeys": ["0x..."]}]
+func ParseAccessList(data []byte) (types.AccessList, error) {
+	var accessList types.AccessList
+	if err := json.Unmarshal(data, &accessList); err != nil {
+		return nil, fmt.Errorf("invalid access list JSON: %v", err)
+	}
+	return accessList, nil
+}
+
+// newTransaction creates an unsigned transaction of the type selected in txParams.
+// Legacy transactions carry the chain ID in V (with R = S = 0) as in EIP-155 signing,
+// so SignTransaction can recover it from the raw transaction.
+func newTransaction(txParams TxParams, chainID *big.Int, nonce uint64, to *common.Address, value *big.Int, data []byte, gasLimit uint64) *types.Transaction {
+	switch txParams.Type {
+	case TxTypeLegacy:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: txParams.Fees.MaxFeePerGas,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+			V:        new(big.Int).Set(chainID),
+			R:        new(big.Int),
+			S:        new(big.Int),
+		})
+	case TxTypeAccessList:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasPrice:   txParams.Fees.MaxFeePerGas,
+			Gas:        gasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: txParams.AccessList,
+		})
+	default:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasTipCap:  txParams.Fees.MaxPriorityFeePerGas, // 小费
+			GasFeeCap:  txParams.Fees.MaxFeePerGas,         // 最大总费用（包括基础费和小费）
+			Gas:        gasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: txParams.AccessList,
+		})
+	}
+}
+
 // SignTransaction 签署交易
 // 函数1: 输入原始交易字符串、私钥，返回签署后的16进制数据
 func SignTransaction(rawTxHex string, privateKeyHex string) (string, error) {
+	return SignTransactionWithChainID(rawTxHex, privateKeyHex, nil)
+}
+
+// SignTransactionWithChainID 签署交易，并使用与交易类型匹配的签名器
+// chainID is required for legacy transactions that do not carry it in V; for typed
+// transactions it must match the chain ID in the transaction if set.
+func SignTransactionWithChainID(rawTxHex string, privateKeyHex string, chainID *big.Int) (string, error) {
 	// 解码原始交易
 	rawTxData, err := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
 	if err != nil {
@@ -57,11 +153,14 @@ func SignTransaction(rawTxHex string, privateKeyHex string) (string, error) {
 		return "", fmt.Errorf("invalid private key: %v", err)
 	}
 
-	// 获取链ID
-	chainID := tx.ChainId()
+	// 选择签名器
+	signer, err := signerForTransaction(&tx, chainID)
+	if err != nil {
+		return "", err
+	}
 
 	// 使用私钥签署交易
-	signedTx, err := types.SignTx(&tx, types.NewLondonSigner(chainID), privateKey)
+	signedTx, err := types.SignTx(&tx, signer, privateKey)
 	if err != nil {
 		return "", fmt.Errorf("sign transaction failed: %v", err)
 	}
@@ -76,23 +175,54 @@ func SignTransaction(rawTxHex string, privateKeyHex string) (string, error) {
 	return "0x" + hex.EncodeToString(signedTxData), nil
 }
 
+// signerForTransaction returns the signer matching the transaction type:
+// EIP-155 for legacy transactions and London (EIP-2930/EIP-1559) for typed transactions
+func signerForTransaction(tx *types.Transaction, chainID *big.Int) (types.Signer, error) {
+	if tx.Type() != types.LegacyTxType {
+		txChainID := tx.ChainId()
+		if chainID != nil && chainID.Cmp(txChainID) != 0 {
+			return nil, fmt.Errorf("chain ID %s does not match chain ID %s in the transaction", chainID, txChainID)
+		}
+		return types.NewLondonSigner(txChainID), nil
+	}
+
+	// Unsigned legacy transactions carry the EIP-155 chain ID in V (R = S = 0)
+	v, r, s := tx.RawSignatureValues()
+	if r.Sign() == 0 && s.Sign() == 0 && v.Sign() > 0 {
+		if chainID != nil && chainID.Cmp(v) != 0 {
+			return nil, fmt.Errorf("chain ID %s does not match chain ID %s in the transaction", chainID, v)
+		}
+		chainID = v
+	}
+	if chainID == nil || chainID.Sign() == 0 {
+		return nil, fmt.Errorf("chain ID is required to sign a legacy transaction")
+	}
+	return types.NewEIP155Signer(chainID), nil
+}
+
+// CreateRawTx 构造任意交易（未签署，原始交易）
+// to may be nil for contract creation.
+func CreateRawTx(to *common.Address, value *big.Int, data []byte, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
+	tx := newTransaction(txParams, chainID, nonce, to, value, data, gasLimit)
+
+	// 将交易编码为字节
+	txData, err := tx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("marshal transaction failed: %v", err)
+	}
+
+	// 返回十六进制字符串
+	return "0x" + hex.EncodeToString(txData), nil
+}
+
 // CreateEthTransferTx 构造ETH转账交易
 // 函数2: 构造原始eth转账交易数据（未签署，原始交易）
-func CreateEthTransferTx(fromAddress, toAddress string, amountInWei *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (string, error) {
+func CreateEthTransferTx(fromAddress, toAddress string, amountInWei *big.Int, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
 	// 转换地址
 	to := common.HexToAddress(toAddress)
 
 	// 创建交易对象，包含链ID
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: gasPrice, // 1 Gwei tip
-		GasFeeCap: gasPrice, // 最大总费用（包括基础费和小费）
-		Gas:       gasLimit,
-		To:        &to,
-		Value:     amountInWei,
-		Data:      []byte{},
-	})
+	tx := newTransaction(txParams, chainID, nonce, &to, amountInWei, []byte{}, gasLimit)
 
 	// 将交易编码为字节
 	txData, err := tx.MarshalBinary()
@@ -104,11 +234,8 @@ func CreateEthTransferTx(fromAddress, toAddress string, amountInWei *big.Int, no
 	return "0x" + hex.EncodeToString(txData), nil
 }
 
-// CreateERC20TransferTx 构造ERC20 Transfer交易
-// 函数3: 构造原始的erc20 transfer交易数据（未签署，原始交易）
-func CreateERC20TransferTx(fromAddress, tokenAddress, toAddress string, amount *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (string, error) {
-	// 解析合约和接收者地址
-	contract := common.HexToAddress(tokenAddress)
+// EncodeERC20Transfer 构造ERC20 transfer的调用数据
+func EncodeERC20Transfer(toAddress string, amount *big.Int) []byte {
 	to := common.HexToAddress(toAddress)
 
 	// 创建ERC20 transfer的函数签名（前4字节）和参数
@@ -123,18 +250,18 @@ func CreateERC20TransferTx(fromAddress, tokenAddress, toAddress string, amount *
 	data = append(data, transferFnSignature...)
 	data = append(data, paddedAddress...)
 	data = append(data, paddedAmount...)
+	return data
+}
+
+// CreateERC20TransferTx 构造ERC20 Transfer交易
+// 函数3: 构造原始的erc20 transfer交易数据（未签署，原始交易）
+func CreateERC20TransferTx(fromAddress, tokenAddress, toAddress string, amount *big.Int, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
+	// 解析合约地址
+	contract := common.HexToAddress(tokenAddress)
+	data := EncodeERC20Transfer(toAddress, amount)
 
 	// 创建交易对象
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: gasPrice,
-		GasFeeCap: gasPrice,
-		Gas:       gasLimit,
-		To:        &contract,
-		Value:     big.NewInt(0), // ERC20转账不包含ETH
-		Data:      data,
-	})
+	tx := newTransaction(txParams, chainID, nonce, &contract, big.NewInt(0), data, gasLimit) // ERC20转账不包含ETH
 
 	// 将交易编码为字节
 	txData, err := tx.MarshalBinary()
@@ -146,11 +273,8 @@ func CreateERC20TransferTx(fromAddress, tokenAddress, toAddress string, amount *
 	return "0x" + hex.EncodeToString(txData), nil
 }
 
-// CreateERC20ApproveTx 构造ERC20 Approve交易
-// 函数4: 构造原始的erc20 approve交易数据（未签署，原始交易）
-func CreateERC20ApproveTx(fromAddress, tokenAddress, spenderAddress string, amount *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (string, error) {
-	// 解析合约和授权者地址
-	contract := common.HexToAddress(tokenAddress)
+// EncodeERC20Approve 构造ERC20 approve的调用数据，数量为零即撤销授权
+func EncodeERC20Approve(spenderAddress string, amount *big.Int) []byte {
 	spender := common.HexToAddress(spenderAddress)
 
 	// 创建ERC20 approve的函数签名（前4字节）和参数
@@ -165,18 +289,18 @@ func CreateERC20ApproveTx(fromAddress, tokenAddress, spenderAddress string, amou
 	data = append(data, approveFnSignature...)
 	data = append(data, paddedAddress...)
 	data = append(data, paddedAmount...)
+	return data
+}
+
+// CreateERC20ApproveTx 构造ERC20 Approve交易
+// 函数4: 构造原始的erc20 approve交易数据（未签署，原始交易）
+func CreateERC20ApproveTx(fromAddress, tokenAddress, spenderAddress string, amount *big.Int, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
+	// 解析合约地址
+	contract := common.HexToAddress(tokenAddress)
+	data := EncodeERC20Approve(spenderAddress, amount)
 
 	// 创建交易对象
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: gasPrice,
-		GasFeeCap: gasPrice,
-		Gas:       gasLimit,
-		To:        &contract,
-		Value:     big.NewInt(0), // Approve不包含ETH
-		Data:      data,
-	})
+	tx := newTransaction(txParams, chainID, nonce, &contract, big.NewInt(0), data, gasLimit) // Approve不包含ETH
 
 	// 将交易编码为字节
 	txData, err := tx.MarshalBinary()
@@ -190,7 +314,7 @@ func CreateERC20ApproveTx(fromAddress, tokenAddress, spenderAddress string, amou
 
 // CreateERC721TransferTx 构造ERC721转账交易
 // 函数5: 构造原始的erc721的转账交易
-func CreateERC721TransferTx(fromAddress, contractAddress, toAddress string, tokenID *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (string, error) {
+func CreateERC721TransferTx(fromAddress, contractAddress, toAddress string, tokenID *big.Int, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
 	// 解析地址
 	contract := common.HexToAddress(contractAddress)
 	from := common.HexToAddress(fromAddress)
@@ -212,16 +336,7 @@ func CreateERC721TransferTx(fromAddress, contractAddress, toAddress string, toke
 	data = append(data, paddedTokenID...)
 
 	// 创建交易对象
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: gasPrice,
-		GasFeeCap: gasPrice,
-		Gas:       gasLimit,
-		To:        &contract,
-		Value:     big.NewInt(0), // NFT转账不包含ETH
-		Data:      data,
-	})
+	tx := newTransaction(txParams, chainID, nonce, &contract, big.NewInt(0), data, gasLimit) // NFT转账不包含ETH
 
 	// 将交易编码为字节
 	txData, err := tx.MarshalBinary()
@@ -233,11 +348,8 @@ func CreateERC721TransferTx(fromAddress, contractAddress, toAddress string, toke
 	return "0x" + hex.EncodeToString(txData), nil
 }
 
-// CreateERC721ApproveTx 构造ERC721授权交易
-// 函数6: 构造原始的erc721的授权交易
-func CreateERC721ApproveTx(fromAddress, contractAddress, approvedAddress string, tokenID *big.Int, nonce uint64, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) (string, error) {
-	// 解析地址
-	contract := common.HexToAddress(contractAddress)
+// EncodeERC721Approve 构造ERC721 approve的调用数据，授权给零地址即撤销授权
+func EncodeERC721Approve(approvedAddress string, tokenID *big.Int) []byte {
 	approved := common.HexToAddress(approvedAddress)
 
 	// 创建ERC721 approve的函数签名（前4字节）和参数
@@ -252,18 +364,18 @@ func CreateERC721ApproveTx(fromAddress, contractAddress, approvedAddress string,
 	data = append(data, approveFnSignature...)
 	data = append(data, paddedApprovedAddress...)
 	data = append(data, paddedTokenID...)
+	return data
+}
+
+// CreateERC721ApproveTx 构造ERC721授权交易
+// 函数6: 构造原始的erc721的授权交易
+func CreateERC721ApproveTx(fromAddress, contractAddress, approvedAddress string, tokenID *big.Int, nonce uint64, txParams TxParams, gasLimit uint64, chainID *big.Int) (string, error) {
+	// 解析地址
+	contract := common.HexToAddress(contractAddress)
+	data := EncodeERC721Approve(approvedAddress, tokenID)
 
 	// 创建交易对象
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasTipCap: gasPrice,
-		GasFeeCap: gasPrice,
-		Gas:       gasLimit,
-		To:        &contract,
-		Value:     big.NewInt(0), // 授权不包含ETH
-		Data:      data,
-	})
+	tx := newTransaction(txParams, chainID, nonce, &contract, big.NewInt(0), data, gasLimit) // 授权不包含ETH
 
 	// 将交易编码为字节
 	txData, err := tx.MarshalBinary()
@@ -275,6 +387,56 @@ func CreateERC721ApproveTx(fromAddress, contractAddress, approvedAddress string,
 	return "0x" + hex.EncodeToString(txData), nil
 }
 
+// EncodeSetApprovalForAll 构造setApprovalForAll的调用数据（ERC721和ERC1155通用）
+func EncodeSetApprovalForAll(operatorAddress string, approved bool) []byte {
+	operator := common.HexToAddress(operatorAddress)
+
+	// 创建setApprovalForAll的函数签名（前4字节）和参数
+	fnSignature := crypto.Keccak256Hash([]byte(SetApprovalForAllSignature)).Bytes()[:4]
+	paddedApproved := make([]byte, 32)
+	if approved {
+		paddedApproved[31] = 1
+	}
+
+	// 组合数据
+	var data []byte
+	data = append(data, fnSignature...)
+	data = append(data, common.LeftPadBytes(operator.Bytes(), 32)...)
+	data = append(data, paddedApproved...)
+	return data
+}
+
+// EncodeERC1155SafeTransferFrom 构造ERC1155 safeTransferFrom的调用数据
+func EncodeERC1155SafeTransferFrom(fromAddress, toAddress string, tokenID, amount *big.Int, data []byte) ([]byte, error) {
+	return EncodeArgumentValues(ERC1155SafeTransferFromSignature,
+		common.HexToAddress(fromAddress), common.HexToAddress(toAddress), tokenID, amount, nonNilBytes(data))
+}
+
+// EncodeERC1155SafeBatchTransferFrom 构造ERC1155 safeBatchTransferFrom的调用数据
+func EncodeERC1155SafeBatchTransferFrom(fromAddress, toAddress string, tokenIDs, amounts []*big.Int, data []byte) ([]byte, error) {
+	if len(tokenIDs) != len(amounts) {
+		return nil, fmt.Errorf("%d token IDs but %d amounts", len(tokenIDs), len(amounts))
+	}
+	return EncodeArgumentValues(ERC1155SafeBatchTransferFromSignature,
+		common.HexToAddress(fromAddress), common.HexToAddress(toAddress), tokenIDs, amounts, nonNilBytes(data))
+}
+
+// EncodeERC1155Transfer 构造ERC1155转账的调用数据（单个ID用safeTransferFrom，多个ID用safeBatchTransferFrom）
+func EncodeERC1155Transfer(fromAddress, toAddress string, tokenIDs, amounts []*big.Int, data []byte) ([]byte, error) {
+	if len(tokenIDs) == 1 && len(amounts) == 1 {
+		return EncodeERC1155SafeTransferFrom(fromAddress, toAddress, tokenIDs[0], amounts[0], data)
+	}
+	return EncodeERC1155SafeBatchTransferFrom(fromAddress, toAddress, tokenIDs, amounts, data)
+}
+
+// nonNilBytes returns an empty slice for nil, as the ABI encoder expects for bytes
+func nonNilBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
 // EstimateGas 估算交易需要的gas limit
 func EstimateGas(client *ethclient.Client, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error) {
 	msg := ethereum.CallMsg{
@@ -351,6 +513,61 @@ func EstimateGasAndSimulateTx(signedTxHex string, rpcURL string) (uint64, string
 	return estimatedGas, "0x" + hex.EncodeToString(result), nil
 }
 
+// DecodeSignedTransaction decodes a hex-encoded signed transaction
+func DecodeSignedTransaction(signedTxHex string) (*types.Transaction, error) {
+	signedTxData, err := hexutil.Decode(signedTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("decode signed transaction failed: %v", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(signedTxData); err != nil {
+		return nil, fmt.Errorf("unmarshal transaction failed: %v", err)
+	}
+	return tx, nil
+}
+
+// IsSignedTransaction reports whether a transaction carries a signature
+func IsSignedTransaction(tx *types.Transaction) bool {
+	_, r, s := tx.RawSignatureValues()
+	return r.Sign() != 0 || s.Sign() != 0
+}
+
+// TransactionChainID returns the chain ID of a transaction, read from V for unsigned legacy
+// transactions. It returns nil for legacy transactions without replay protection.
+func TransactionChainID(tx *types.Transaction) *big.Int {
+	if tx.Type() != types.LegacyTxType {
+		return tx.ChainId()
+	}
+	v, _, _ := tx.RawSignatureValues()
+	if !IsSignedTransaction(tx) {
+		if v.Sign() > 0 {
+			return new(big.Int).Set(v)
+		}
+		return nil
+	}
+	if tx.Protected() {
+		return tx.ChainId()
+	}
+	return nil
+}
+
+// TransactionSender recovers the sender of a signed transaction
+func TransactionSender(tx *types.Transaction) (common.Address, error) {
+	if !IsSignedTransaction(tx) {
+		return common.Address{}, fmt.Errorf("the transaction is not signed")
+	}
+	var signer types.Signer = types.HomesteadSigner{}
+	if chainID := TransactionChainID(tx); chainID != nil {
+		signer = types.LatestSignerForChainID(chainID)
+	}
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover the sender: %v", err)
+	}
+	return sender, nil
+}
+
 // BroadcastTransaction 广播交易到网络
 // 函数8: 广播交易
 func BroadcastTransaction(signedTxHex string, rpcURL string) (string, error) {
@@ -393,18 +610,39 @@ func SignMessage(message string, privateKeyHex string, hexMessage bool) (string,
 		return "", fmt.Errorf("invalid private key: %v", err)
 	}
 
+	prefixedHash, err := hashPersonalMessage(message, hexMessage)
+	if err != nil {
+		return "", err
+	}
+
+	// Sign the message
+	signature, err := crypto.Sign(prefixedHash, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %v", err)
+	}
+
+	// Adjust v value (last byte) in signature: v = 27 + v
+	signature[64] += 27
+
+	// Return hex-encoded signature
+	return "0x" + hex.EncodeToString(signature), nil
+}
+
+// hashPersonalMessage returns the EIP-191 personal_sign hash of a text or 0x-prefixed hex message
+func hashPersonalMessage(message string, hexMessage bool) ([]byte, error) {
 	// Process message based on type
 	var messageBytes []byte
 	if hexMessage {
 		// Verify hex string format
 		if !strings.HasPrefix(message, "0x") {
-			return "", fmt.Errorf("hex message must start with 0x")
+			return nil, fmt.Errorf("hex message must start with 0x")
 		}
 
 		// Decode hex string
+		var err error
 		messageBytes, err = hex.DecodeString(strings.TrimPrefix(message, "0x"))
 		if err != nil {
-			return "", fmt.Errorf("invalid hex message: %v", err)
+			return nil, fmt.Errorf("invalid hex message: %v", err)
 		}
 	} else {
 		// Use plain text message
@@ -413,17 +651,39 @@ func SignMessage(message string, privateKeyHex string, hexMessage bool) (string,
 
 	// Create Ethereum specific message
 	prefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(messageBytes), messageBytes)
-	prefixedHash := crypto.Keccak256Hash([]byte(prefixedMessage))
+	return crypto.Keccak256([]byte(prefixedMessage)), nil
+}
 
-	// Sign the message
-	signature, err := crypto.Sign(prefixedHash.Bytes(), privateKey)
+// RecoverMessageSigner recovers the address that signed a message with SignMessage
+func RecoverMessageSigner(message string, hexMessage bool, signature string) (common.Address, error) {
+	prefixedHash, err := hashPersonalMessage(message, hexMessage)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign message: %v", err)
+		return common.Address{}, err
 	}
+	return recoverSigner(prefixedHash, signature)
+}
 
-	// Adjust v value (last byte) in signature: v = 27 + v
-	signature[64] += 27
+// recoverSigner recovers the signer of a 32-byte hash from a 65-byte signature with v = 0/1 or 27/28
+func recoverSigner(hash []byte, signature string) (common.Address, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "0x"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature hex: %v", err)
+	}
+	if len(sig) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
 
-	// Return hex-encoded signature
-	return "0x" + hex.EncodeToString(signature), nil
+	// Normalize v value (last byte) to 0/1
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	if sig[64] > 1 {
+		return common.Address{}, fmt.Errorf("invalid signature recovery id: %d", sig[64])
+	}
+
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
 }
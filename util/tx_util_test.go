@@ -0,0 +1,238 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRecoverMessageSigner(t *testing.T) {
+	privateKey := "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	expected := "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+
+	for _, tc := range []struct {
+		message    string
+		hexMessage bool
+	}{
+		{"Hello, world!", false},
+		{"0xdeadbeef", true},
+	} {
+		signature, err := SignMessage(tc.message, privateKey, tc.hexMessage)
+		if err != nil {
+			t.Fatalf("Failed to sign %q: %v", tc.message, err)
+		}
+
+		signer, err := RecoverMessageSigner(tc.message, tc.hexMessage, signature)
+		if err != nil {
+			t.Fatalf("Failed to recover signer of %q: %v", tc.message, err)
+		}
+		if signer.Hex() != expected {
+			t.Errorf("Unexpected signer of %q: %s", tc.message, signer.Hex())
+		}
+
+		// v = 0/1 must be accepted as well as v = 27/28
+		var v byte
+		fmt.Sscanf(signature[len(signature)-2:], "%02x", &v)
+		rawV := fmt.Sprintf("%s%02x", signature[:len(signature)-2], v-27)
+		signer, err = RecoverMessageSigner(tc.message, tc.hexMessage, rawV)
+		if err != nil {
+			t.Fatalf("Failed to recover signer with v = %d: %v", v-27, err)
+		}
+		if signer.Hex() != expected {
+			t.Errorf("Unexpected signer with v = %d: %s", v-27, signer.Hex())
+		}
+
+		// A different message must not recover the same signer
+		signer, err = RecoverMessageSigner("tampered", false, signature)
+		if err == nil && signer.Hex() == expected {
+			t.Errorf("Expected tampered message not to recover %s", expected)
+		}
+	}
+
+	if _, err := RecoverMessageSigner("Hello", false, "0x1234"); err == nil {
+		t.Error("Expected error for short signature, but got none")
+	}
+}
+
+func TestSignTransactionTypes(t *testing.T) {
+	privateKey := "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	from := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	to := "0x000000000000000000000000000000000000dEaD"
+	chainID := big.NewInt(5)
+	fees := FeeParams{MaxFeePerGas: big.NewInt(2e9), MaxPriorityFeePerGas: big.NewInt(1e8)}
+	accessList := types.AccessList{{Address: common.HexToAddress(to), StorageKeys: []common.Hash{{}}}}
+
+	for _, tc := range []struct {
+		txType string
+		want   uint8
+	}{
+		{TxTypeLegacy, types.LegacyTxType},
+		{TxTypeAccessList, types.AccessListTxType},
+		{TxTypeDynamicFee, types.DynamicFeeTxType},
+	} {
+		txParams := TxParams{Type: tc.txType, Fees: fees}
+		if tc.txType != TxTypeLegacy {
+			txParams.AccessList = accessList
+		}
+
+		rawTx, err := CreateEthTransferTx(from.Hex(), to, big.NewInt(1), 7, txParams, 21000, chainID)
+		if err != nil {
+			t.Fatalf("Failed to create %s transaction: %v", tc.txType, err)
+		}
+
+		unsigned, err := DecodeSignedTransaction(rawTx)
+		if err != nil {
+			t.Fatalf("Failed to decode raw %s transaction: %v", tc.txType, err)
+		}
+		if IsSignedTransaction(unsigned) {
+			t.Errorf("Expected the raw %s transaction to be unsigned", tc.txType)
+		}
+		if got := TransactionChainID(unsigned); got == nil || got.Cmp(chainID) != 0 {
+			t.Errorf("Unexpected chain ID of the raw %s transaction: %v", tc.txType, got)
+		}
+		if _, err := TransactionSender(unsigned); err == nil {
+			t.Errorf("Expected error for the sender of the raw %s transaction, but got none", tc.txType)
+		}
+
+		signedTxHex, err := SignTransaction(rawTx, privateKey)
+		if err != nil {
+			t.Fatalf("Failed to sign %s transaction: %v", tc.txType, err)
+		}
+
+		var signedTx types.Transaction
+		if err := signedTx.UnmarshalBinary(common.FromHex(signedTxHex)); err != nil {
+			t.Fatalf("Failed to decode signed %s transaction: %v", tc.txType, err)
+		}
+		if signedTx.Type() != tc.want {
+			t.Errorf("Expected transaction type %d, got %d", tc.want, signedTx.Type())
+		}
+		if signedTx.ChainId().Cmp(chainID) != 0 {
+			t.Errorf("Unexpected chain ID in signed %s transaction: %s", tc.txType, signedTx.ChainId())
+		}
+		if len(signedTx.AccessList()) != len(txParams.AccessList) {
+			t.Errorf("Unexpected access list in signed %s transaction", tc.txType)
+		}
+
+		decoded, err := DecodeSignedTransaction(signedTxHex)
+		if err != nil || decoded.Hash() != signedTx.Hash() {
+			t.Errorf("Failed to decode signed %s transaction: %v", tc.txType, err)
+		}
+
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), &signedTx)
+		if err != nil {
+			t.Fatalf("Failed to recover sender of %s transaction: %v", tc.txType, err)
+		}
+		if sender != from {
+			t.Errorf("Unexpected sender of %s transaction: %s", tc.txType, sender.Hex())
+		}
+		if sender, err := TransactionSender(decoded); err != nil || sender != from {
+			t.Errorf("Unexpected recovered sender of %s transaction: %s, %v", tc.txType, sender.Hex(), err)
+		}
+		if got := TransactionChainID(decoded); got == nil || got.Cmp(chainID) != 0 {
+			t.Errorf("Unexpected chain ID of the signed %s transaction: %v", tc.txType, got)
+		}
+
+		if _, err := SignTransactionWithChainID(rawTx, privateKey, big.NewInt(1)); err == nil {
+			t.Errorf("Expected error for mismatched chain ID on %s transaction, but got none", tc.txType)
+		}
+	}
+}
+
+func TestTransactionSenderWithoutReplayProtection(t *testing.T) {
+	key, _ := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1e9), Gas: 21000, Value: big.NewInt(1)})
+	signed, err := types.SignTx(tx, types.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatalf("Failed to sign transaction: %v", err)
+	}
+
+	if chainID := TransactionChainID(signed); chainID != nil {
+		t.Errorf("Expected no chain ID, got %s", chainID)
+	}
+	sender, err := TransactionSender(signed)
+	if err != nil || sender.Hex() != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("Unexpected sender: %s, %v", sender.Hex(), err)
+	}
+}
+
+func TestDecodeSignedTransactionInvalid(t *testing.T) {
+	for _, input := range []string{"", "0xzz", "0x02f8"} {
+		if _, err := DecodeSignedTransaction(input); err == nil {
+			t.Errorf("Expected error for %q, but got none", input)
+		}
+	}
+}
+
+func TestEncodeERC1155Transfers(t *testing.T) {
+	from := "0x0000000000000000000000000000000000000001"
+	to := "0x0000000000000000000000000000000000000002"
+	word := func(n int64) string {
+		return fmt.Sprintf("%064x", n)
+	}
+
+	data, err := EncodeERC1155SafeTransferFrom(from, to, big.NewInt(7), big.NewInt(3), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// keccak256("safeTransferFrom(address,address,uint256,uint256,bytes)")[:4] = 0xf242432a
+	want := "f242432a" + word(1) + word(2) + word(7) + word(3) + word(0xa0) + word(0)
+	if fmt.Sprintf("%x", data) != want {
+		t.Errorf("Unexpected safeTransferFrom data:\n got %x\nwant %s", data, want)
+	}
+
+	data, err = EncodeERC1155SafeBatchTransferFrom(from, to, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}, []byte{0xab})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// keccak256("safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)")[:4] = 0x2eb2c2d6
+	want = "2eb2c2d6" + word(1) + word(2) + word(0xa0) + word(0x100) + word(0x160) +
+		word(2) + word(1) + word(2) + word(2) + word(10) + word(20) + word(1) + "ab" + fmt.Sprintf("%062x", 0)
+	if fmt.Sprintf("%x", data) != want {
+		t.Errorf("Unexpected safeBatchTransferFrom data:\n got %x\nwant %s", data, want)
+	}
+
+	if _, err := EncodeERC1155SafeBatchTransferFrom(from, to, []*big.Int{big.NewInt(1)}, nil, nil); err == nil {
+		t.Error("Expected error for mismatched ids and amounts, but got none")
+	}
+
+	// EncodeERC1155Transfer uses safeTransferFrom for a single id only
+	if data, err := EncodeERC1155Transfer(from, to, []*big.Int{big.NewInt(7)}, []*big.Int{big.NewInt(3)}, nil); err != nil || fmt.Sprintf("%x", data[:4]) != "f242432a" {
+		t.Errorf("Unexpected single transfer data: %x, %v", data, err)
+	}
+	if data, err := EncodeERC1155Transfer(from, to, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}, nil); err != nil || fmt.Sprintf("%x", data[:4]) != "2eb2c2d6" {
+		t.Errorf("Unexpected batch transfer data: %x, %v", data, err)
+	}
+}
+
+func TestEncodeSetApprovalForAll(t *testing.T) {
+	operator := "0x00000000000000000000000000000000000000aa"
+
+	// keccak256("setApprovalForAll(address,bool)")[:4] = 0xa22cb465
+	if got := fmt.Sprintf("%x", EncodeSetApprovalForAll(operator, true)); got != "a22cb465"+fmt.Sprintf("%064x%064x", 0xaa, 1) {
+		t.Errorf("Unexpected approval data: %s", got)
+	}
+	if got := fmt.Sprintf("%x", EncodeSetApprovalForAll(operator, false)); got != "a22cb465"+fmt.Sprintf("%064x%064x", 0xaa, 0) {
+		t.Errorf("Unexpected revocation data: %s", got)
+	}
+}
+
+func TestEncodeERC721Approve(t *testing.T) {
+	// keccak256("approve(address,uint256)")[:4] = 0x095ea7b3
+	if got := fmt.Sprintf("%x", EncodeERC721Approve("0x00000000000000000000000000000000000000aa", big.NewInt(42))); got != "095ea7b3"+fmt.Sprintf("%064x%064x", 0xaa, 42) {
+		t.Errorf("Unexpected approval data: %s", got)
+	}
+	if got := fmt.Sprintf("%x", EncodeERC721Approve("0x0000000000000000000000000000000000000000", big.NewInt(42))); got != "095ea7b3"+fmt.Sprintf("%064x%064x", 0, 42) {
+		t.Errorf("Unexpected revocation data: %s", got)
+	}
+}
+
+func TestEncodeERC20Approve(t *testing.T) {
+	// keccak256("approve(address,uint256)")[:4] = 0x095ea7b3
+	if got := fmt.Sprintf("%x", EncodeERC20Approve("0x00000000000000000000000000000000000000aa", big.NewInt(0))); got != "095ea7b3"+fmt.Sprintf("%064x%064x", 0xaa, 0) {
+		t.Errorf("Unexpected revocation data: %s", got)
+	}
+}
@@ -0,0 +1,274 @@
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Permit2Address is the address of Uniswap's Permit2 contract, deployed at the same address
+// on Ethereum and most EVM chains
+var Permit2Address = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+
+// PermitDomain is the EIP-712 domain of a token implementing EIP-2612 permit
+type PermitDomain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// eip712DomainType is the EIP712Domain type with name, version, chainId and verifyingContract
+var eip712DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+// permit2DomainType is the EIP712Domain type of Permit2, which has no version
+var permit2DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+// TypedDataHashes holds the EIP-712 hashes of a typed data document
+type TypedDataHashes struct {
+	DomainSeparator []byte
+	MessageHash     []byte
+	Digest          []byte // keccak256("\x19\x01" || domainSeparator || messageHash)
+}
+
+// ParseTypedData parses an eth_signTypedData_v4 JSON document.
+// If the EIP712Domain type is missing it is derived from the fields present in the domain.
+func ParseTypedData(data []byte) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(data, &typedData); err != nil {
+		return typedData, fmt.Errorf("invalid typed data JSON: %v", err)
+	}
+
+	if typedData.PrimaryType == "" {
+		return typedData, fmt.Errorf("typed data has no primaryType")
+	}
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return typedData, fmt.Errorf("primary type %s is not defined in types", typedData.PrimaryType)
+	}
+
+	if _, ok := typedData.Types["EIP712Domain"]; !ok {
+		if typedData.Types == nil {
+			typedData.Types = apitypes.Types{}
+		}
+		typedData.Types["EIP712Domain"] = domainTypes(typedData.Domain)
+	}
+
+	return typedData, nil
+}
+
+// domainTypes returns the EIP712Domain fields in the order defined by EIP-712
+func domainTypes(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// HashTypedData computes the domain separator, message hash and signing digest of typed data
+func HashTypedData(typedData apitypes.TypedData) (TypedDataHashes, error) {
+	var hashes TypedDataHashes
+
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return hashes, fmt.Errorf("failed to hash domain: %v", err)
+	}
+
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return hashes, fmt.Errorf("failed to hash message: %v", err)
+	}
+
+	hashes.DomainSeparator = domainSeparator
+	hashes.MessageHash = messageHash
+	hashes.Digest = crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash)
+	return hashes, nil
+}
+
+// SignTypedData signs typed data the same way as eth_signTypedData_v4
+func SignTypedData(typedData apitypes.TypedData, privateKeyHex string) (string, error) {
+	// Parse private key
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %v", err)
+	}
+
+	hashes, err := HashTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := crypto.Sign(hashes.Digest, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign typed data: %v", err)
+	}
+
+	// Adjust v value (last byte) in signature: v = 27 + v
+	signature[64] += 27
+
+	return "0x" + hex.EncodeToString(signature), nil
+}
+
+// RecoverTypedDataSigner recovers the address that signed typed data with eth_signTypedData_v4
+func RecoverTypedDataSigner(typedData apitypes.TypedData, signature string) (common.Address, error) {
+	hashes, err := HashTypedData(typedData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverSigner(hashes.Digest, signature)
+}
+
+// NewPermitTypedData builds the EIP-2612 Permit typed data allowing spender to spend value
+// of the owner's tokens until deadline
+func NewPermitTypedData(domain PermitDomain, owner, spender common.Address, value, nonce, deadline *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    owner.Hex(),
+			"spender":  spender.Hex(),
+			"value":    value.String(),
+			"nonce":    nonce.String(),
+			"deadline": deadline.String(),
+		},
+	}
+}
+
+// NewPermitSingleTypedData builds the Permit2 PermitSingle typed data setting the Permit2 allowance
+// of spender for token to amount until expiration. nonce is the current nonce of the
+// (owner, token, spender) allowance and sigDeadline the expiry of the signature itself.
+func NewPermitSingleTypedData(chainID *big.Int, token common.Address, amount, expiration, nonce *big.Int, spender common.Address, sigDeadline *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": permit2DomainType,
+			"PermitSingle": {
+				{Name: "details", Type: "PermitDetails"},
+				{Name: "spender", Type: "address"},
+				{Name: "sigDeadline", Type: "uint256"},
+			},
+			"PermitDetails": {
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint160"},
+				{Name: "expiration", Type: "uint48"},
+				{Name: "nonce", Type: "uint48"},
+			},
+		},
+		PrimaryType: "PermitSingle",
+		Domain:      permit2Domain(chainID),
+		Message: apitypes.TypedDataMessage{
+			"details": map[string]interface{}{
+				"token":      token.Hex(),
+				"amount":     amount.String(),
+				"expiration": expiration.String(),
+				"nonce":      nonce.String(),
+			},
+			"spender":     spender.Hex(),
+			"sigDeadline": sigDeadline.String(),
+		},
+	}
+}
+
+// NewPermitTransferFromTypedData builds the Permit2 PermitTransferFrom typed data letting spender
+// transfer up to amount of token once before deadline. nonce is an unordered nonce, see
+// Permit2's nonceBitmap.
+func NewPermitTransferFromTypedData(chainID *big.Int, token common.Address, amount *big.Int, spender common.Address, nonce, deadline *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": permit2DomainType,
+			"PermitTransferFrom": {
+				{Name: "permitted", Type: "TokenPermissions"},
+				{Name: "spender", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+			"TokenPermissions": {
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+		},
+		PrimaryType: "PermitTransferFrom",
+		Domain:      permit2Domain(chainID),
+		Message: apitypes.TypedDataMessage{
+			"permitted": map[string]interface{}{
+				"token":  token.Hex(),
+				"amount": amount.String(),
+			},
+			"spender":  spender.Hex(),
+			"nonce":    nonce.String(),
+			"deadline": deadline.String(),
+		},
+	}
+}
+
+// permit2Domain returns the EIP-712 domain of Permit2 on a chain
+func permit2Domain(chainID *big.Int) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              "Permit2",
+		ChainId:           (*math.HexOrDecimal256)(chainID),
+		VerifyingContract: Permit2Address.Hex(),
+	}
+}
+
+// SplitSignature splits a 65-byte r || s || v signature into its v, r and s values
+func SplitSignature(signature string) (uint8, [32]byte, [32]byte, error) {
+	var r, s [32]byte
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return 0, r, s, fmt.Errorf("invalid signature hex: %v", err)
+	}
+	if len(sig) != 65 {
+		return 0, r, s, fmt.Errorf("invalid signature length: %d bytes, expected 65", len(sig))
+	}
+
+	copy(r[:], sig[:32])
+	copy(s[:], sig[32:64])
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	return v, r, s, nil
+}
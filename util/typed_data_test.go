@@ -0,0 +1,167 @@
+package util
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// mailTypedData is the example from the EIP-712 specification
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestSignTypedData(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		t.Fatalf("Failed to parse typed data: %v", err)
+	}
+
+	hashes, err := HashTypedData(typedData)
+	if err != nil {
+		t.Fatalf("Failed to hash typed data: %v", err)
+	}
+	if got := hex.EncodeToString(hashes.DomainSeparator); got != "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f" {
+		t.Errorf("Unexpected domain separator: %s", got)
+	}
+	if got := hex.EncodeToString(hashes.Digest); got != "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Errorf("Unexpected digest: %s", got)
+	}
+
+	privateKey := hex.EncodeToString(crypto.Keccak256([]byte("cow")))
+	signature, err := SignTypedData(typedData, privateKey)
+	if err != nil {
+		t.Fatalf("Failed to sign typed data: %v", err)
+	}
+	expected := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if signature != expected {
+		t.Errorf("Unexpected signature: %s", signature)
+	}
+
+	signer, err := RecoverTypedDataSigner(typedData, signature)
+	if err != nil {
+		t.Fatalf("Failed to recover typed data signer: %v", err)
+	}
+	if signer.Hex() != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Errorf("Unexpected typed data signer: %s", signer.Hex())
+	}
+}
+
+func TestParseTypedDataDerivesDomainType(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(`{
+  "types": {"Mail": [{"name": "contents", "type": "string"}]},
+  "primaryType": "Mail",
+  "domain": {"name": "Ether Mail", "chainId": 1},
+  "message": {"contents": "Hello"}
+}`))
+	if err != nil {
+		t.Fatalf("Failed to parse typed data: %v", err)
+	}
+
+	fields := typedData.Types["EIP712Domain"]
+	if len(fields) != 2 || fields[0].Name != "name" || fields[1].Name != "chainId" {
+		t.Errorf("Unexpected derived EIP712Domain type: %v", fields)
+	}
+
+	if _, err := HashTypedData(typedData); err != nil {
+		t.Errorf("Failed to hash typed data with derived domain type: %v", err)
+	}
+
+	if _, err := ParseTypedData([]byte(`{"types": {}, "primaryType": "Mail", "domain": {}, "message": {}}`)); err == nil {
+		t.Error("Expected error for undefined primary type, but got none")
+	}
+}
+
+func TestPermitTypeHashes(t *testing.T) {
+	chainID := big.NewInt(1)
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	spender := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	one := big.NewInt(1)
+
+	// The type hashes hard-coded in the token and Permit2 contracts
+	tests := []struct {
+		typedData apitypes.TypedData
+		typeName  string
+		expected  string
+	}{
+		{NewPermitTypedData(PermitDomain{Name: "USD Coin", Version: "2", ChainID: chainID, VerifyingContract: token}, owner, spender, one, one, one), "Permit",
+			"6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9"},
+		{NewPermitSingleTypedData(chainID, token, one, one, one, spender, one), "PermitSingle",
+			"f3841cd1ff0085026a6327b620b67997ce40f282c88a8e905a7a5626e310f3d0"},
+		{NewPermitSingleTypedData(chainID, token, one, one, one, spender, one), "PermitDetails",
+			"65626cad6cb96493bf6f5ebea28756c966f023ab9e8a83a7101849d5573b3678"},
+		{NewPermitTransferFromTypedData(chainID, token, one, spender, one, one), "PermitTransferFrom",
+			"939c21a48a8dbe3a9a2404a1d46691e4d39f6583d6ec6b35714604c986d80106"},
+		{NewPermitTransferFromTypedData(chainID, token, one, spender, one, one), "TokenPermissions",
+			"618358ac3db8dc274f0cd8829da7e234bd48cd73c4a740aede1adec9846d06a1"},
+	}
+	for _, test := range tests {
+		if got := hex.EncodeToString(test.typedData.TypeHash(test.typeName)); got != test.expected {
+			t.Errorf("Unexpected %s type hash: %s", test.typeName, got)
+		}
+		if _, err := HashTypedData(test.typedData); err != nil {
+			t.Errorf("Failed to hash %s: %v", test.typedData.PrimaryType, err)
+		}
+	}
+}
+
+func TestPermit2DomainSeparator(t *testing.T) {
+	// Permit2's DOMAIN_SEPARATOR() on Ethereum mainnet
+	hashes, err := HashTypedData(NewPermitTransferFromTypedData(big.NewInt(1), common.Address{}, big.NewInt(0), common.Address{}, big.NewInt(0), big.NewInt(0)))
+	if err != nil {
+		t.Fatalf("Failed to hash typed data: %v", err)
+	}
+	if got := hex.EncodeToString(hashes.DomainSeparator); got != "866a5aba21966af95d6c7ab78eb2b2fc913915c28be3b9aa07cc04ff903e3f28" {
+		t.Errorf("Unexpected Permit2 domain separator: %s", got)
+	}
+}
+
+func TestSplitSignature(t *testing.T) {
+	signature := "0x" + strings.Repeat("11", 32) + strings.Repeat("22", 32) + "01"
+	v, r, s, err := SplitSignature(signature)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v != 28 || r[0] != 0x11 || s[31] != 0x22 {
+		t.Errorf("Unexpected v/r/s: %d %x %x", v, r, s)
+	}
+
+	if _, _, _, err := SplitSignature("0x1234"); err == nil {
+		t.Error("Expected error for a short signature, but got none")
+	}
+}